@@ -0,0 +1,42 @@
+package cron
+
+import "time"
+
+// AtSchedule activates once, at a time, for one-off jobs among recurring ones.
+// After its time, it returns the zero time, so that its entry never runs
+// again and may be removed.
+type AtSchedule struct {
+	Time time.Time
+}
+
+// At returns a schedule that activates once, at t, truncated to the second.
+func At(t time.Time) AtSchedule {
+	return AtSchedule{Time: t.Truncate(time.Second)}
+}
+
+// Next returns the schedule's time if it is after t, and the zero time
+// otherwise.
+func (s AtSchedule) Next(t time.Time) time.Time {
+	if s.Time.After(t) {
+		return s.Time
+	}
+	return time.Time{}
+}
+
+// Prev returns the schedule's time if it is before t, and the zero time
+// otherwise.
+func (s AtSchedule) Prev(t time.Time) time.Time {
+	if s.Time.Before(t) {
+		return s.Time
+	}
+	return time.Time{}
+}
+
+// parseAt parses the time of an "@at" descriptor, in RFC 3339 format.
+func parseAt(value string) (Schedule, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, parseError("failed to parse time %s: %s", value, err)
+	}
+	return At(t), nil
+}
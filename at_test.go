@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAt(t *testing.T) {
+	s, err := ParseStandard("@at 2025-07-01T09:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	if got := s.Next(at.Add(-time.Hour)); !got.Equal(at) {
+		t.Errorf("expected %v, got %v", at, got)
+	}
+	for _, after := range []time.Time{at, at.Add(time.Second), at.AddDate(1, 0, 0)} {
+		if got := s.Next(after); !got.IsZero() {
+			t.Errorf("after %v: expected the zero time, got %v", after, got)
+		}
+	}
+
+	// The offset of the time is kept, and a time zone prefix does not change it.
+	s, err = ParseStandard("TZ=Asia/Tokyo @at 2025-07-01T09:00:00+02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.(AtSchedule).Time, at.Add(-2*time.Hour); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAtErrors(t *testing.T) {
+	for _, spec := range []string{
+		"@at",
+		"@at tomorrow",
+		"@at 2025-07-01",
+		"@at 2025-07-01T09:00:00",
+		"@at 2025-07-01T09:00:00Z now",
+	} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+	if _, err := NewParser(Minute | Hour | Dom | Month | Dow).Parse("@at 2025-07-01T09:00:00Z"); err == nil {
+		t.Error("expected an error without descriptors")
+	}
+}
+
+func TestAtEntry(t *testing.T) {
+	now := time.Date(2025, 6, 30, 9, 0, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)))
+	past, _ := c.AddFunc("@at 2025-06-01T09:00:00Z", func() {})
+	future, _ := c.AddFunc("@at 2025-07-01T09:00:00Z", func() {})
+	c.Start()
+	defer c.Stop()
+
+	if next := c.Entry(past).Next; !next.IsZero() {
+		t.Errorf("expected a past time never to run, got next %v", next)
+	}
+	if next, want := c.Entry(future).Next, now.Add(24*time.Hour); !next.Equal(want) {
+		t.Errorf("expected next %v, got %v", want, next)
+	}
+}
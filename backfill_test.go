@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackfill(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	tests := []struct {
+		policy  BackfillPolicy
+		ran     int
+		skipped int
+	}{
+		{BackfillAll, 4, 0},
+		{BackfillLatest, 1, 3},
+		{BackfillNone, 0, 4},
+	}
+	for _, test := range tests {
+		store := NewMemoryStore()
+		c := New(WithCheckpoints(store), WithLocation(time.UTC))
+		var ran []time.Time
+		id, _ := c.AddContextFunc("0 * * * *", func(ctx context.Context) error {
+			s, _ := ScheduledTimeFromContext(ctx)
+			ran = append(ran, s)
+			if s.Equal(to) {
+				return errors.New("boom")
+			}
+			return nil
+		}, WithName("hourly"))
+
+		runs, err := c.Backfill(id, from, to, test.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 4 || !runs[0].Scheduled.Equal(from) || !runs[3].Scheduled.Equal(to) {
+			t.Fatalf("policy %d: expected the 4 hourly occurrences, got %+v", test.policy, runs)
+		}
+		skipped := 0
+		for _, r := range runs {
+			if r.Skipped {
+				skipped++
+			}
+		}
+		if len(ran) != test.ran || skipped != test.skipped {
+			t.Errorf("policy %d: expected %d run and %d skipped, got %d and %d", test.policy, test.ran, test.skipped, len(ran), skipped)
+		}
+		if test.policy != BackfillNone && runs[3].Err == nil {
+			t.Errorf("policy %d: expected the last run's error", test.policy)
+		}
+		for i := 1; i < len(ran); i++ {
+			if !ran[i].After(ran[i-1]) {
+				t.Errorf("policy %d: expected runs in order, got %v", test.policy, ran)
+			}
+		}
+		if cp, ok, _ := Checkpoint(context.Background(), store, "hourly"); !ok || cp.Before(from.Add(2*time.Hour)) {
+			t.Errorf("policy %d: expected the checkpoint advanced, got %v", test.policy, cp)
+		}
+	}
+
+	if _, err := New().Backfill(42, from, to, BackfillAll); err == nil {
+		t.Error("expected an error for a missing entry")
+	}
+}
@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// WithFailureBackoff stretches the entry's interval while its runs keep
+// failing, to ease the pressure on a broken dependency without removing the
+// job. After a failed run, the entry's firings are skipped for initial, and
+// the wait doubles with each further failure, up to max. The first run that
+// succeeds returns the entry to its schedule.
+//
+//	c.AddFunc("@every 1m", poll, cron.WithFailureBackoff(time.Minute, time.Hour))
+func WithFailureBackoff(initial, max time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.FailureBackoff = initial
+		e.MaxFailureBackoff = max
+	}
+}
+
+// failureBackoffs tracks each failing entry's consecutive failures.
+type failureBackoffs struct {
+	mu      sync.Mutex
+	entries map[EntryID]backoffState
+}
+
+type backoffState struct {
+	failures int
+	until    time.Time // firings are skipped before this
+}
+
+// record updates the entry's backoff with the result of a run that ended at
+// end.
+func (b *failureBackoffs) record(e Entry, end time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.entries, e.ID)
+		return
+	}
+	if b.entries == nil {
+		b.entries = make(map[EntryID]backoffState)
+	}
+	s := b.entries[e.ID]
+	s.failures++
+	s.until = end.Add(backoffDelay(e.FailureBackoff, e.MaxFailureBackoff, s.failures))
+	b.entries[e.ID] = s
+}
+
+// until returns the time before which the entry's firings are skipped, and
+// its number of consecutive failures.
+func (b *failureBackoffs) until(id EntryID) (time.Time, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.entries[id]
+	return s.until, s.failures
+}
+
+func (b *failureBackoffs) remove(id EntryID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, id)
+}
+
+// backoffDelay returns initial doubled for each failure after the first, up
+// to max if it is positive.
+func backoffDelay(initial, max time.Duration, failures int) time.Duration {
+	d := initial
+	for i := 1; i < failures && (max <= 0 || d < max); i++ {
+		d *= 2
+	}
+	if max > 0 && d > max {
+		d = max
+	}
+	return d
+}
+
+// backingOff reports whether the run's entry is backing off after failures,
+// logging if so.
+func (c *Cron) backingOff(ri *runInfo) bool {
+	until, failures := c.backoffs.until(ri.entry.ID)
+	if !c.now().Before(until) {
+		return false
+	}
+	c.logger.Info("skip", "entry", ri.entry.ID, "run", ri.id, "reason", "backing off after failures",
+		"failures", failures, "until", until)
+	return true
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// movingClock is a Clock whose time the test sets.
+type movingClock struct{ now time.Time }
+
+func (c *movingClock) Now() time.Time                 { return c.now }
+func (c *movingClock) NewTimer(d time.Duration) Timer { return realClock{}.NewTimer(d) }
+
+func TestFailureBackoff(t *testing.T) {
+	clock := &movingClock{now: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	c := New(WithClock(clock))
+	var runs int
+	fail := true
+	id, _ := c.AddContextFunc("@every 1m", func(ctx context.Context) error {
+		runs++
+		if fail {
+			return errors.New("dependency down")
+		}
+		return nil
+	}, WithFailureBackoff(time.Minute, 3*time.Minute))
+
+	// Fire every minute: after each failure the wait doubles, up to 3m.
+	var ran []int
+	for minute := 0; minute < 12; minute++ {
+		before := runs
+		c.runEntry(c.Entry(id), clock.now)
+		if runs > before {
+			ran = append(ran, minute)
+		}
+		clock.now = clock.now.Add(time.Minute)
+	}
+	if want := []int{0, 1, 3, 6, 9}; fmt.Sprint(ran) != fmt.Sprint(want) {
+		t.Errorf("expected runs at minutes %v, got %v", want, ran)
+	}
+
+	// A success snaps back to the schedule.
+	fail = false
+	clock.now = clock.now.Add(3 * time.Minute)
+	c.runEntry(c.Entry(id), clock.now)
+	before := runs
+	c.runEntry(c.Entry(id), clock.now.Add(time.Minute))
+	if runs != before+1 {
+		t.Error("expected the entry to run on schedule after a success")
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	for _, test := range []struct {
+		failures  int
+		max, want time.Duration
+	}{
+		{1, 0, time.Second},
+		{4, 0, 8 * time.Second},
+		{4, 5 * time.Second, 5 * time.Second},
+		{100, time.Hour, time.Hour},
+	} {
+		if got := backoffDelay(time.Second, test.max, test.failures); got != test.want {
+			t.Errorf("%d failures, max %v: expected %v, got %v", test.failures, test.max, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// nextAllChunk is how many schedules a worker of NextAll takes at a time.
+const nextAllChunk = 256
+
+// specKey identifies the times a SpecSchedule activates at.
+type specKey struct {
+	second, minute, hour, dom, month, dow uint64
+	loc                                   *time.Location
+}
+
+// NextAll returns the next activation time after after of each of the
+// schedules, at the same index, as their Next methods do, for services that
+// evaluate many stored schedules at once. Identical specs, which are common
+// among stored schedules, are computed only once, and the rest are computed
+// in parallel.
+//
+//	due := cron.NextAll(schedules, time.Now())
+func NextAll(schedules []Schedule, after time.Time) []time.Time {
+	next := make([]time.Time, len(schedules))
+
+	// Find the first of each set of identical specs; the others copy it.
+	same := make([]int, len(schedules))
+	var todo []int
+	first := make(map[specKey]int)
+	for i, s := range schedules {
+		same[i] = i
+		if ss, ok := s.(*SpecSchedule); ok && ss != nil {
+			k := specKey{ss.Second, ss.Minute, ss.Hour, ss.Dom, ss.Month, ss.Dow, ss.Location}
+			if j, ok := first[k]; ok {
+				same[i] = j
+				continue
+			}
+			first[k] = i
+		}
+		todo = append(todo, i)
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if chunks := (len(todo) + nextAllChunk - 1) / nextAllChunk; workers > chunks {
+		workers = chunks
+	}
+	var (
+		chunk int64 = -1
+		wg    sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(atomic.AddInt64(&chunk, 1)) * nextAllChunk
+				if start >= len(todo) {
+					return
+				}
+				end := start + nextAllChunk
+				if end > len(todo) {
+					end = len(todo)
+				}
+				for _, i := range todo[start:end] {
+					next[i] = schedules[i].Next(after)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, j := range same {
+		if i != j {
+			next[i] = next[j]
+		}
+	}
+	return next
+}
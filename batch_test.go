@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNextAll(t *testing.T) {
+	after := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	var schedules []Schedule
+	for i := 0; i < 3000; i++ {
+		// Parse each spec anew, so that identical specs are separate values.
+		s, err := ParseStandard(fmt.Sprintf("%d 9 * * *", i%60))
+		if err != nil {
+			t.Fatal(err)
+		}
+		schedules = append(schedules, s)
+	}
+	schedules = append(schedules, Every(time.Minute), OffsetSchedule{schedules[0], time.Hour})
+
+	got := NextAll(schedules, after)
+	if len(got) != len(schedules) {
+		t.Fatalf("expected %d times, got %d", len(schedules), len(got))
+	}
+	for i, s := range schedules {
+		if want := s.Next(after); !got[i].Equal(want) {
+			t.Fatalf("schedule %d: expected %v, got %v", i, want, got[i])
+		}
+	}
+	if len(NextAll(nil, after)) != 0 {
+		t.Error("expected no times for no schedules")
+	}
+}
+
+func BenchmarkNextAll(b *testing.B) {
+	var schedules []Schedule
+	for i := 0; i < 10000; i++ {
+		s, _ := ParseStandard(fmt.Sprintf("%d %d * * %d", i%60, i%24, i%7))
+		schedules = append(schedules, s)
+	}
+	after := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NextAll(schedules, after)
+	}
+}
@@ -0,0 +1,155 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BlackoutPolicy selects what happens to firings during a blackout.
+type BlackoutPolicy int
+
+const (
+	// BlackoutSkip drops firings that fall in the blackout.
+	BlackoutSkip BlackoutPolicy = iota
+
+	// BlackoutDefer holds firings that fall in the blackout and runs them
+	// when it ends. Deferred runs are listed by Pending.
+	BlackoutDefer
+)
+
+// Blackout is a window during which no jobs are fired, such as a deploy
+// freeze or a maintenance window. A one-off blackout runs from Start to End.
+// A recurring blackout starts at each activation of Schedule and lasts for
+// Duration.
+type Blackout struct {
+	// Name identifies the blackout for RemoveBlackout.
+	Name string
+
+	Start, End time.Time
+
+	Schedule Schedule
+	Duration time.Duration
+
+	Policy BlackoutPolicy
+}
+
+// activeUntil reports whether the blackout covers t, and if so when it ends.
+func (b Blackout) activeUntil(t time.Time) (time.Time, bool) {
+	if b.Schedule != nil {
+		start := b.Schedule.Next(t.Add(-b.Duration - time.Nanosecond))
+		end := start.Add(b.Duration)
+		if start.IsZero() || start.After(t) || !end.After(t) {
+			return time.Time{}, false
+		}
+		return end, true
+	}
+	if t.Before(b.Start) || !t.Before(b.End) {
+		return time.Time{}, false
+	}
+	return b.End, true
+}
+
+// blackouts is the set of blackouts configured on a Cron.
+type blackouts struct {
+	mu   sync.Mutex
+	list []Blackout
+}
+
+// AddBlackout adds a blackout, replacing any with the same name. It may be
+// called while the Cron is running.
+func (c *Cron) AddBlackout(b Blackout) {
+	c.blackouts.mu.Lock()
+	defer c.blackouts.mu.Unlock()
+	c.removeBlackoutLocked(b.Name)
+	c.blackouts.list = append(c.blackouts.list, b)
+}
+
+// RemoveBlackout removes the named blackout. Firings deferred by it still
+// wait for its end.
+func (c *Cron) RemoveBlackout(name string) {
+	c.blackouts.mu.Lock()
+	defer c.blackouts.mu.Unlock()
+	c.removeBlackoutLocked(name)
+}
+
+// Blackouts returns the configured blackouts.
+func (c *Cron) Blackouts() []Blackout {
+	c.blackouts.mu.Lock()
+	defer c.blackouts.mu.Unlock()
+	return append([]Blackout(nil), c.blackouts.list...)
+}
+
+func (c *Cron) removeBlackoutLocked(name string) {
+	list := c.blackouts.list[:0]
+	for _, b := range c.blackouts.list {
+		if b.Name != name {
+			list = append(list, b)
+		}
+	}
+	c.blackouts.list = list
+}
+
+// blackedOut reports whether t falls in a blackout, and if so with which
+// policy and until when. Skipping takes precedence over deferring, and a
+// deferral lasts until the last overlapping blackout ends.
+func (c *Cron) blackedOut(t time.Time) (BlackoutPolicy, time.Time, bool) {
+	c.blackouts.mu.Lock()
+	defer c.blackouts.mu.Unlock()
+	var (
+		until time.Time
+		found bool
+	)
+	for _, b := range c.blackouts.list {
+		end, ok := b.activeUntil(t)
+		if !ok {
+			continue
+		}
+		if b.Policy == BlackoutSkip {
+			return BlackoutSkip, end, true
+		}
+		found = true
+		if end.After(until) {
+			until = end
+		}
+	}
+	return BlackoutDefer, until, found
+}
+
+// deferJob starts the entry's job once the blackouts covering the firing have
+// ended, waiting in the pending list until then.
+func (c *Cron) deferJob(e *Entry, scheduled time.Time, until time.Time) {
+	entry := *e
+	c.logger.Info("defer", "entry", entry.ID, "until", until)
+	c.jobWaiter.Add(1)
+	go func() {
+		defer c.jobWaiter.Done()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ri := &runInfo{id: newRunID(), entry: entry, scheduled: scheduled, cancel: cancel, cron: c}
+		c.pending.add(ri, "blackout")
+		for {
+			timer := c.clock.NewTimer(until.Sub(c.now()))
+			select {
+			case <-timer.C():
+			case <-ctx.Done():
+				timer.Stop()
+				c.pending.remove(ri)
+				c.logger.Info("abandoned", "entry", entry.ID, "run", ri.id, "reason", ctx.Err())
+				return
+			}
+			// Blackouts may have been extended while waiting.
+			policy, end, ok := c.blackedOut(c.now())
+			if !ok || policy != BlackoutDefer {
+				break
+			}
+			until = end
+		}
+		c.pending.remove(ri)
+		if c.dispatcher != nil {
+			c.dispatch(&entry, scheduled)
+			return
+		}
+		c.runEntryID(entry, scheduled, ri.id)
+	}()
+}
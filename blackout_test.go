@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBlackoutActive(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	oneOff := Blackout{Start: base, End: base.Add(time.Hour)}
+	daily, _ := ParseStandard("0 2 * * *")
+	maintenance := Blackout{Schedule: daily, Duration: 30 * time.Minute}
+
+	tests := []struct {
+		b     Blackout
+		t     time.Time
+		until time.Time
+		ok    bool
+	}{
+		{oneOff, base.Add(-time.Second), time.Time{}, false},
+		{oneOff, base, base.Add(time.Hour), true},
+		{oneOff, base.Add(time.Hour), time.Time{}, false},
+		{maintenance, base.Add(2*time.Hour + 10*time.Minute), base.Add(2*time.Hour + 30*time.Minute), true},
+		{maintenance, base.Add(2*time.Hour + 30*time.Minute), time.Time{}, false},
+		{maintenance, base.Add(time.Hour), time.Time{}, false},
+	}
+	for _, test := range tests {
+		until, ok := test.b.activeUntil(test.t)
+		if ok != test.ok || !until.Equal(test.until) {
+			t.Errorf("%v: expected %v, %v, got %v, %v", test.t, test.until, test.ok, until, ok)
+		}
+	}
+}
+
+func TestBlackoutRuntimeConfig(t *testing.T) {
+	now := time.Now()
+	c := New()
+	c.AddBlackout(Blackout{Name: "freeze", Start: now.Add(-time.Hour), End: now.Add(time.Hour), Policy: BlackoutDefer})
+	c.AddBlackout(Blackout{Name: "freeze", Start: now.Add(-time.Hour), End: now.Add(2 * time.Hour), Policy: BlackoutDefer})
+	if bs := c.Blackouts(); len(bs) != 1 {
+		t.Fatalf("expected the blackout replaced, got %v", bs)
+	}
+	c.AddBlackout(Blackout{Name: "short", Start: now.Add(-time.Hour), End: now.Add(time.Minute), Policy: BlackoutDefer})
+	if policy, until, ok := c.blackedOut(now); !ok || policy != BlackoutDefer || !until.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("expected a deferral until the last blackout ends, got %v, %v, %v", policy, until, ok)
+	}
+	c.AddBlackout(Blackout{Name: "outage", Start: now.Add(-time.Hour), End: now.Add(time.Minute)})
+	if policy, _, ok := c.blackedOut(now); !ok || policy != BlackoutSkip {
+		t.Errorf("expected skipping to take precedence, got %v, %v", policy, ok)
+	}
+	c.RemoveBlackout("freeze")
+	c.RemoveBlackout("short")
+	c.RemoveBlackout("outage")
+	if _, _, ok := c.blackedOut(now); ok {
+		t.Error("expected no blackout")
+	}
+}
+
+func TestBlackoutSkipAndDefer(t *testing.T) {
+	var runs int32
+	c := New(WithParser(secondParser))
+	c.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	now := time.Now()
+	c.AddBlackout(Blackout{Name: "outage", Start: now, End: now.Add(time.Hour)})
+	c.Start()
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Fatalf("expected firings skipped, got %d runs", n)
+	}
+
+	c.AddBlackout(Blackout{Name: "outage", Start: now, End: time.Now().Add(2 * OneSecond), Policy: BlackoutDefer})
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n != 0 || len(c.Pending()) == 0 {
+		t.Fatalf("expected firings deferred, got %d runs and %v pending", n, c.Pending())
+	}
+	<-c.Stop().Done()
+	if n := atomic.LoadInt32(&runs); n == 0 {
+		t.Error("expected deferred firings to run after the blackout")
+	}
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// WithDailyBudget limits the total run time of the entry's jobs per day, in
+// the Cron's time zone. Once the entry's runs that started that day have
+// taken longer than budget altogether, its firings are skipped until
+// midnight, each emitting EventBudgetExceeded. A run in progress is not
+// interrupted, so usage may overshoot the budget by up to one run.
+func WithDailyBudget(budget time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.DailyBudget = budget
+	}
+}
+
+// budgetUsage tracks each entry's run time for the current day.
+type budgetUsage struct {
+	mu      sync.Mutex
+	entries map[EntryID]dayUsage
+}
+
+type dayUsage struct {
+	day  time.Time // midnight
+	used time.Duration
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// add charges the run from start to end to the day it started.
+func (b *budgetUsage) add(id EntryID, start, end time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.entries == nil {
+		b.entries = make(map[EntryID]dayUsage)
+	}
+	day := startOfDay(start)
+	u := b.entries[id]
+	if !u.day.Equal(day) {
+		if u.day.After(day) {
+			// Charged to a day that is already over.
+			return
+		}
+		u = dayUsage{day: day}
+	}
+	u.used += end.Sub(start)
+	b.entries[id] = u
+}
+
+// used returns the entry's run time on the day of now.
+func (b *budgetUsage) used(id EntryID, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	u := b.entries[id]
+	if !u.day.Equal(startOfDay(now)) {
+		return 0
+	}
+	return u.used
+}
+
+func (b *budgetUsage) remove(id EntryID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, id)
+}
+
+// overBudget reports whether the run's entry has used up its daily budget,
+// logging and emitting an event if so.
+func (c *Cron) overBudget(ri *runInfo) bool {
+	now := c.now()
+	used := c.budgets.used(ri.entry.ID, now)
+	if used < ri.entry.DailyBudget {
+		return false
+	}
+	c.logger.Info("skip", "entry", ri.entry.ID, "run", ri.id, "reason", "daily budget exceeded", "used", used)
+	c.emit(Event{Type: EventBudgetExceeded, Entry: ri.entry.ID, Name: ri.entry.Name, RunID: ri.id,
+		Scheduled: ri.scheduled, Time: now, Duration: used})
+	return true
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyBudget(t *testing.T) {
+	var events []Event
+	c := New(WithEventListener(func(e Event) {
+		if e.Type == EventBudgetExceeded {
+			events = append(events, e)
+		}
+	}))
+	var runs int
+	id, _ := c.AddFunc("@every 1h", func() {
+		runs++
+		time.Sleep(20 * time.Millisecond)
+	}, WithDailyBudget(30*time.Millisecond))
+
+	for i := 0; i < 4; i++ {
+		c.runEntry(c.Entry(id), time.Now())
+	}
+	if runs != 2 {
+		t.Errorf("expected runs to stop once the budget was used, got %d runs", runs)
+	}
+	if len(events) != 2 || events[0].Duration < 30*time.Millisecond {
+		t.Errorf("expected an event per skipped firing, got %+v", events)
+	}
+}
+
+func TestBudgetUsageResetsDaily(t *testing.T) {
+	var b budgetUsage
+	day := time.Date(2020, 1, 1, 23, 0, 0, 0, time.UTC)
+	b.add(1, day, day.Add(30*time.Minute))
+	if used := b.used(1, day.Add(45*time.Minute)); used != 30*time.Minute {
+		t.Errorf("expected 30m used, got %v", used)
+	}
+	if used := b.used(1, day.Add(2*time.Hour)); used != 0 {
+		t.Errorf("expected the budget reset the next day, got %v", used)
+	}
+	b.add(1, day.Add(2*time.Hour), day.Add(3*time.Hour))
+	b.add(1, day, day.Add(time.Hour))
+	if used := b.used(1, day.Add(3*time.Hour)); used != time.Hour {
+		t.Errorf("expected late charges to past days ignored, got %v", used)
+	}
+}
@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// EntryBuilder builds an Entry step by step, as an alternative to long lists
+// of EntryOptions:
+//
+//	e, err := cron.NewEntry().
+//		Spec("0 * * * *").
+//		Name("sync").
+//		Timeout(time.Minute).
+//		Retries(3).
+//		Job(job)
+//	if err != nil {
+//		return err
+//	}
+//	c.ScheduleEntry(e)
+//
+// The first mistake, such as a spec that does not parse, is reported by Job.
+type EntryBuilder struct {
+	entry    Entry
+	parser   ScheduleParser
+	spec     string
+	logger   Logger
+	timeout  time.Duration
+	retries  int
+	backoff  time.Duration
+	wrappers []JobWrapper
+	opts     []EntryOption
+	err      error
+}
+
+// NewEntry returns an EntryBuilder for a new entry. Specs are parsed with the
+// standard parser unless another is given with Parser.
+func NewEntry() *EntryBuilder {
+	return &EntryBuilder{parser: standardParser, logger: DefaultLogger}
+}
+
+// Parser sets the parser that the spec is parsed with. It should match the
+// parser of the Cron that the entry is added to.
+func (b *EntryBuilder) Parser(p ScheduleParser) *EntryBuilder {
+	b.parser = p
+	return b
+}
+
+// Spec sets the schedule of the entry from a spec string.
+func (b *EntryBuilder) Spec(spec string) *EntryBuilder {
+	b.spec = spec
+	return b
+}
+
+// Schedule sets the schedule of the entry, instead of a spec string.
+func (b *EntryBuilder) Schedule(s Schedule) *EntryBuilder {
+	b.entry.Schedule = s
+	return b
+}
+
+// Name sets the name of the entry, as WithName does.
+func (b *EntryBuilder) Name(name string) *EntryBuilder {
+	b.entry.Name = name
+	return b
+}
+
+// Tags adds tags to the entry, as WithTags does.
+func (b *EntryBuilder) Tags(tags ...string) *EntryBuilder {
+	b.entry.Tags = append(b.entry.Tags, tags...)
+	return b
+}
+
+// Priority sets the priority of the entry, as WithPriority does.
+func (b *EntryBuilder) Priority(priority int) *EntryBuilder {
+	b.entry.Priority = priority
+	return b
+}
+
+// Logger sets the logger used by the timeout and retry wrappers. It defaults
+// to DefaultLogger.
+func (b *EntryBuilder) Logger(logger Logger) *EntryBuilder {
+	b.logger = logger
+	return b
+}
+
+// Timeout cancels the context of each attempt to run the job after d, as the
+// hard deadline of the Timeout wrapper.
+func (b *EntryBuilder) Timeout(d time.Duration) *EntryBuilder {
+	if d < 0 {
+		b.fail(fmt.Errorf("negative timeout: %v", d))
+	}
+	b.timeout = d
+	return b
+}
+
+// Retries runs the job up to n more times when it fails, with the Retry
+// wrapper.
+func (b *EntryBuilder) Retries(n int) *EntryBuilder {
+	if n < 0 {
+		b.fail(fmt.Errorf("negative retries: %d", n))
+	}
+	b.retries = n
+	return b
+}
+
+// Backoff sets how long to wait before each retry. The default is none.
+func (b *EntryBuilder) Backoff(d time.Duration) *EntryBuilder {
+	b.backoff = d
+	return b
+}
+
+// Wrap adds wrappers to the job of this entry only. They are applied inside
+// the timeout and retries, and inside the Cron's Chain.
+func (b *EntryBuilder) Wrap(wrappers ...JobWrapper) *EntryBuilder {
+	b.wrappers = append(b.wrappers, wrappers...)
+	return b
+}
+
+// Options adds EntryOptions, for settings without a builder method. They are
+// applied by Job, once the schedule is known.
+func (b *EntryBuilder) Options(opts ...EntryOption) *EntryBuilder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+// Job completes the entry with the job to run, and returns it for
+// Cron.ScheduleEntry. It returns an error if the entry is not valid.
+func (b *EntryBuilder) Job(j Job) (Entry, error) {
+	if b.err != nil {
+		return Entry{}, b.err
+	}
+	if j == nil {
+		return Entry{}, fmt.Errorf("entry has no job")
+	}
+	e := b.entry
+	if b.spec != "" {
+		if e.Schedule != nil {
+			return Entry{}, fmt.Errorf("entry has both a spec and a schedule")
+		}
+		schedule, err := parseEntry(b.parser, b.spec, e.Name, b.opts)
+		if err != nil {
+			return Entry{}, err
+		}
+		e.Spec, e.Schedule = b.spec, schedule
+	}
+	if e.Schedule == nil {
+		return Entry{}, fmt.Errorf("entry has no schedule")
+	}
+	for _, opt := range b.opts {
+		opt(&e)
+	}
+
+	var wrappers []JobWrapper
+	if b.retries > 0 {
+		wrappers = append(wrappers, Retry(b.logger, b.retries, b.backoff))
+	}
+	if b.timeout > 0 {
+		wrappers = append(wrappers, Timeout(b.logger, 0, b.timeout))
+	}
+	e.Job = j
+	e.WrappedJob = NewChain(append(wrappers, b.wrappers...)...).Then(j)
+	e.Tags = append([]string(nil), e.Tags...)
+	return e, nil
+}
+
+// Func is Job for a func.
+func (b *EntryBuilder) Func(f func()) (Entry, error) {
+	return b.Job(FuncJob(f))
+}
+
+// fail records the first mistake made while building.
+func (b *EntryBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEntryBuilder(t *testing.T) {
+	var attempts int
+	job := FuncContextJob(func(ctx context.Context) error {
+		attempts++
+		return errors.New("boom")
+	})
+	e, err := NewEntry().
+		Spec("0 * * * *").
+		Name("sync").
+		Tags("db").
+		Timeout(time.Minute).
+		Retries(2).
+		Logger(DiscardLogger).
+		Job(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "sync" || e.Spec != "0 * * * *" || e.Tags[0] != "db" || e.Job == nil {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+
+	c := New(WithChain())
+	id := c.ScheduleEntry(e)
+	entry := c.Entry(id)
+	if entry.ID != id || entry.Name != "sync" {
+		t.Errorf("unexpected scheduled entry: %+v", entry)
+	}
+	c.runEntry(entry, time.Now())
+	if attempts != 3 {
+		t.Errorf("expected the run retried twice, got %d attempts", attempts)
+	}
+}
+
+func TestEntryBuilderErrors(t *testing.T) {
+	job := FuncJob(func() {})
+	tests := []struct {
+		name string
+		b    *EntryBuilder
+		job  Job
+	}{
+		{"no schedule", NewEntry(), job},
+		{"no job", NewEntry().Spec("@hourly"), nil},
+		{"bad spec", NewEntry().Spec("* * *"), job},
+		{"spec and schedule", NewEntry().Spec("@hourly").Schedule(Every(time.Hour)), job},
+		{"negative retries", NewEntry().Spec("@hourly").Retries(-1), job},
+		{"negative timeout", NewEntry().Spec("@hourly").Timeout(-time.Second), job},
+	}
+	for _, test := range tests {
+		if _, err := test.b.Job(test.job); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
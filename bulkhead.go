@@ -0,0 +1,44 @@
+package cron
+
+// WithBulkhead gives the entries tagged tag a concurrency limit of their own,
+// n runs at once, so that a slow group of entries, such as report generation,
+// cannot take the slots needed by others. Its runs do not count against
+// WithConcurrencyLimit, which limits the entries outside any bulkhead. An
+// entry with the tags of several bulkheads is in the first one listed in its
+// tags. Runs waiting for a slot are listed by Pending, and are ordered as
+// WithFairQueueing and WithPriorityQueueing say.
+//
+//	cron.New(
+//		cron.WithConcurrencyLimit(8),
+//		cron.WithBulkhead("reports", 2))
+func WithBulkhead(tag string, n int) Option {
+	return func(c *Cron) {
+		if c.bulkheads == nil {
+			c.bulkheads = make(map[string]*limiter)
+		}
+		c.bulkheads[tag] = &limiter{limit: n, pending: &c.pending, queueing: c.queueing, reason: "bulkhead"}
+	}
+}
+
+// limiterFor returns the limiter the entry's runs take slots from, or nil if
+// they are not limited.
+func (c *Cron) limiterFor(e Entry) *limiter {
+	for _, tag := range e.Tags {
+		if l, ok := c.bulkheads[tag]; ok {
+			return l
+		}
+	}
+	return c.limiter
+}
+
+// setQueueing configures the order in which runs waiting for a slot start,
+// in all limiters.
+func (c *Cron) setQueueing(q queueing) {
+	c.queueing = q
+	if c.limiter != nil {
+		c.limiter.queueing = q
+	}
+	for _, l := range c.bulkheads {
+		l.queueing = q
+	}
+}
@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// A full bulkhead holds its own entries' runs without taking slots from the
+// rest.
+func TestBulkhead(t *testing.T) {
+	c := New(WithConcurrencyLimit(1), WithBulkhead("reports", 1))
+	started, done := make(chan string, 4), make(chan error, 4)
+	report := func(id EntryID) Entry {
+		e := blockingEntry(id, started, done)
+		e.Tags = []string{"reports"}
+		return e
+	}
+
+	go c.runEntry(report(1), time.Now())
+	first := <-started
+	go c.runEntry(report(2), time.Now())
+	for deadline := time.Now().Add(time.Second); len(c.Pending()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the second report to wait")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if p := c.Pending()[0]; p.Entry != 2 || p.Reason != "bulkhead" {
+		t.Errorf("unexpected pending run: %+v", p)
+	}
+
+	// Entries outside the bulkhead still have their slot.
+	go c.runEntry(blockingEntry(3, started, done), time.Now())
+	select {
+	case housekeeping := <-started:
+		c.CancelRun(housekeeping)
+		<-done
+	case <-time.After(time.Second):
+		t.Fatal("expected an entry outside the bulkhead to run")
+	}
+
+	c.CancelRun(first)
+	<-done
+	c.CancelRun(<-started)
+	<-done
+}
+
+func TestBulkheadQueueing(t *testing.T) {
+	c := New(WithBulkhead("reports", 1), WithPriorityQueueing(time.Minute))
+	if l := c.limiterFor(Entry{Tags: []string{"other", "reports"}}); l == nil || !l.priority {
+		t.Errorf("expected the bulkhead to follow the queueing options, got %+v", l)
+	}
+	if l := c.limiterFor(Entry{Tags: []string{"other"}}); l != nil {
+		t.Errorf("expected no limit outside the bulkhead, got %+v", l)
+	}
+}
@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CalendarDelaySchedule activates every so many calendar months, as the
+// descriptors "@every 3mo" and "@every 1y" do. Unlike a ConstantDelaySchedule
+// of 90 days, it keeps to the same day of the month and time of day across
+// months of different lengths and daylight saving changes.
+//
+// Activations are counted from an anchor, so a day the month lacks is clamped
+// to its last day without drifting: from January 31, monthly activations fall
+// on February 29, March 31, April 30 and so on.
+type CalendarDelaySchedule struct {
+	Months int
+
+	// Anchor is the time after which activations are counted, in whose time
+	// zone they fall. If zero, it is set to the time given to the first call
+	// of Next, which is when the Cron schedules the entry.
+	Anchor time.Time
+
+	mu sync.Mutex
+}
+
+// EveryMonths returns a schedule that activates every n calendar months. An
+// n below 1 is taken as 1.
+func EveryMonths(n int) *CalendarDelaySchedule {
+	if n < 1 {
+		n = 1
+	}
+	return &CalendarDelaySchedule{Months: n}
+}
+
+// Next returns the first activation after t: the anchor plus the least
+// multiple of Months that is after t.
+func (s *CalendarDelaySchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	if s.Anchor.IsZero() {
+		s.Anchor = t.Truncate(time.Second)
+	}
+	anchor := s.Anchor
+	s.mu.Unlock()
+	n := s.Months
+	if n < 1 {
+		n = 1
+	}
+
+	// Start from an estimate of the months that have passed, then correct it.
+	local := t.In(anchor.Location())
+	passed := (local.Year()-anchor.Year())*12 + int(local.Month()-anchor.Month())
+	k := passed / n
+	if k < 1 {
+		k = 1
+	}
+	for k > 1 && addMonths(anchor, (k-1)*n).After(t) {
+		k--
+	}
+	next := addMonths(anchor, k*n)
+	for !next.After(t) {
+		k++
+		next = addMonths(anchor, k*n)
+	}
+	return next.In(t.Location())
+}
+
+// addMonths adds months to t on the calendar, keeping its time of day and
+// clamping its day to the length of the month.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	hour, min, sec := t.Clock()
+	return time.Date(first.Year(), first.Month(), day, hour, min, sec, 0, t.Location())
+}
+
+// parseEvery returns the schedule of "@every d": a CalendarDelaySchedule if
+// d counts years and months, such as "1y" or "1y6mo", and a
+// ConstantDelaySchedule if it is a time.Duration.
+func parseEvery(d string) (Schedule, error) {
+	if months, ok := parseMonths(d); ok {
+		return EveryMonths(months), nil
+	}
+	duration, err := time.ParseDuration(d)
+	if err != nil {
+		return nil, parseError("failed to parse duration %s: %s", "@every "+d, err)
+	}
+	return Every(duration), nil
+}
+
+// parseMonths parses a count of years and months, such as "3mo", "1y" or
+// "1y6mo", returning it in months.
+func parseMonths(d string) (int, bool) {
+	months := 0
+	for d != "" {
+		i := 0
+		for i < len(d) && d[i] >= '0' && d[i] <= '9' {
+			i++
+		}
+		n, err := strconv.Atoi(d[:i])
+		if err != nil || n > 10000 {
+			return 0, false
+		}
+		switch d = d[i:]; {
+		case len(d) >= 2 && d[:2] == "mo":
+			months += n
+			d = d[2:]
+		case len(d) >= 1 && d[0] == 'y':
+			months += 12 * n
+			d = d[1:]
+		default:
+			return 0, false
+		}
+	}
+	return months, months > 0
+}
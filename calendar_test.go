@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarDelaySchedule(t *testing.T) {
+	utc := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		months int
+		anchor time.Time
+		from   time.Time
+		want   []time.Time
+	}{
+		// Days past the end of a month are clamped, without drifting.
+		{1, utc(2024, 1, 31, 9), utc(2024, 1, 31, 9), []time.Time{
+			utc(2024, 2, 29, 9), utc(2024, 3, 31, 9), utc(2024, 4, 30, 9), utc(2024, 5, 31, 9),
+		}},
+		// Quarterly, starting long after the anchor.
+		{3, utc(2020, 1, 15, 0), utc(2024, 5, 20, 0), []time.Time{
+			utc(2024, 7, 15, 0), utc(2024, 10, 15, 0), utc(2025, 1, 15, 0),
+		}},
+		// Yearly from a leap day.
+		{12, utc(2024, 2, 29, 12), utc(2024, 2, 29, 12), []time.Time{
+			utc(2025, 2, 28, 12), utc(2026, 2, 28, 12), utc(2027, 2, 28, 12), utc(2028, 2, 29, 12),
+		}},
+	}
+	for _, test := range tests {
+		s := &CalendarDelaySchedule{Months: test.months, Anchor: test.anchor}
+		next := test.from
+		for i, want := range test.want {
+			if next = s.Next(next); !next.Equal(want) {
+				t.Errorf("every %d months from %v: activation %d is %v, want %v", test.months, test.anchor, i, next, want)
+				break
+			}
+		}
+	}
+}
+
+func TestCalendarDelayScheduleDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Monthly at 09:00 local time, across the March change to daylight time.
+	s := EveryMonths(1)
+	next := s.Next(time.Date(2024, 1, 10, 9, 0, 0, 0, ny))
+	for _, want := range []time.Time{
+		time.Date(2024, 2, 10, 9, 0, 0, 0, ny),
+		time.Date(2024, 3, 10, 9, 0, 0, 0, ny),
+		time.Date(2024, 4, 10, 9, 0, 0, 0, ny),
+	} {
+		if !next.Equal(want) {
+			t.Errorf("got %v, want %v", next, want)
+		}
+		next = s.Next(next)
+	}
+}
+
+func TestParseEveryMonths(t *testing.T) {
+	for spec, months := range map[string]int{
+		"@every 3mo":   3,
+		"@every 1y":    12,
+		"@every 1y6mo": 18,
+	} {
+		s, err := ParseStandard(spec)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if c, ok := s.(*CalendarDelaySchedule); !ok || c.Months != months {
+			t.Errorf("%s: got %#v, want every %d months", spec, s, months)
+		}
+	}
+	for _, spec := range []string{"@every 0mo", "@every 3m0", "@every 1y2h", "@every mo"} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+	if s, err := ParseStandard("@every 3m"); err != nil || s != Every(3*time.Minute) {
+		t.Errorf("@every 3m: got %v, %v, want three minutes", s, err)
+	}
+}
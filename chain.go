@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -35,9 +36,10 @@ func (c Chain) Then(j Job) Job {
 }
 
 // Recover panics in wrapped jobs and log them with the provided logger.
+// The panic is also returned as the run's error.
 func Recover(logger Logger) JobWrapper {
 	return func(j Job) Job {
-		return FuncJob(func() {
+		return FuncContextJob(func(ctx context.Context) (rerr error) {
 			defer func() {
 				if r := recover(); r != nil {
 					const size = 64 << 10
@@ -48,27 +50,36 @@ func Recover(logger Logger) JobWrapper {
 						err = fmt.Errorf("%v", r)
 					}
 					logger.Error(err, "panic", "stack", "...\n"+string(buf))
+					rerr = err
 				}
 			}()
-			j.Run()
+			return RunJob(ctx, j)
 		})
 	}
 }
 
 // DelayIfStillRunning serializes jobs, delaying subsequent runs until the
 // previous one is complete. Jobs running after a delay of more than a minute
-// have the delay logged at Info.
+// have the delay logged at Info. Delayed runs are listed by Cron.Pending while
+// they wait.
 func DelayIfStillRunning(logger Logger) JobWrapper {
 	return func(j Job) Job {
 		var mu sync.Mutex
-		return FuncJob(func() {
-			start := time.Now()
+		return FuncContextJob(func(ctx context.Context) error {
+			ri, ok := runFromContext(ctx)
+			start := ri.clock().Now()
+			if ok && ri.cron != nil {
+				ri.cron.pending.add(ri, "still running")
+			}
 			mu.Lock()
 			defer mu.Unlock()
-			if dur := time.Since(start); dur > time.Minute {
+			if ok && ri.cron != nil {
+				ri.cron.pending.remove(ri)
+			}
+			if dur := ri.clock().Now().Sub(start); dur > time.Minute {
 				logger.Info("delay", "duration", dur)
 			}
-			j.Run()
+			return RunJob(ctx, j)
 		})
 	}
 }
@@ -79,14 +90,100 @@ func SkipIfStillRunning(logger Logger) JobWrapper {
 	return func(j Job) Job {
 		var ch = make(chan struct{}, 1)
 		ch <- struct{}{}
-		return FuncJob(func() {
+		return FuncContextJob(func(ctx context.Context) error {
 			select {
 			case v := <-ch:
-				j.Run()
-				ch <- v
+				defer func() { ch <- v }()
+				return RunJob(ctx, j)
 			default:
 				logger.Info("skip")
+				return nil
+			}
+		})
+	}
+}
+
+// ReplaceIfStillRunning cancels the context of a previous invocation of the
+// Job that is still running, waits for it to return, and then runs the new
+// invocation. It logs replacements to the given logger at Info level.
+func ReplaceIfStillRunning(logger Logger) JobWrapper {
+	return func(j Job) Job {
+		var (
+			run    sync.Mutex
+			mu     sync.Mutex
+			gen    int
+			cancel context.CancelFunc
+		)
+		return FuncContextJob(func(ctx context.Context) error {
+			ctx, stop := context.WithCancel(ctx)
+			defer stop()
+			mu.Lock()
+			if cancel != nil {
+				logger.Info("replace")
+				cancel()
+			}
+			gen++
+			mine := gen
+			cancel = stop
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				if gen == mine {
+					cancel = nil
+				}
+				mu.Unlock()
+			}()
+
+			run.Lock()
+			defer run.Unlock()
+			if ctx.Err() != nil {
+				// Replaced by a newer invocation while waiting.
+				return nil
+			}
+			return RunJob(ctx, j)
+		})
+	}
+}
+
+// SkipIfLate skips an invocation of the Job that starts more than deadline
+// after its scheduled time, such as one that was delayed by a concurrency
+// limit or a busy machine. It logs skips to the given logger at Info level.
+// Lateness is measured by the Cron's Clock. Invocations that did not come
+// from a Cron always run.
+func SkipIfLate(logger Logger, deadline time.Duration) JobWrapper {
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			if scheduled, ok := ScheduledTimeFromContext(ctx); ok {
+				if late := clockFromContext(ctx).Now().Sub(scheduled); late > deadline {
+					logger.Info("skip late", "scheduled", scheduled, "late", late)
+					return nil
+				}
+			}
+			return RunJob(ctx, j)
+		})
+	}
+}
+
+// Retry runs the Job again when it returns an error, up to the given number of
+// retries, waiting backoff before each one. Failed attempts are logged to the
+// given logger, and the last attempt's error is returned. Retrying stops early
+// if the run's context is done. The backoff is timed by the Cron's Clock.
+func Retry(logger Logger, retries int, backoff time.Duration) JobWrapper {
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			err := RunJob(ctx, j)
+			for attempt := 1; err != nil && attempt <= retries; attempt++ {
+				logger.Error(err, "retry", "attempt", attempt, "of", retries)
+				timer := clockFromContext(ctx).NewTimer(backoff)
+				select {
+				case <-timer.C():
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				}
+				err = RunJob(ctx, j)
 			}
+			return err
 		})
 	}
 }
@@ -1,10 +1,13 @@
 package cron
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"log"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -240,3 +243,99 @@ func TestChainSkipIfStillRunning(t *testing.T) {
 	})
 
 }
+
+func TestChainRecoverReturnsPanic(t *testing.T) {
+	job := NewChain(Recover(DiscardLogger)).Then(FuncJob(func() {
+		panic("YOLO")
+	}))
+	if err := RunJob(context.Background(), job); err == nil || err.Error() != "YOLO" {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+}
+
+func TestChainPassesErrors(t *testing.T) {
+	boom := errors.New("boom")
+	job := NewChain(Recover(DiscardLogger), SkipIfStillRunning(DiscardLogger), DelayIfStillRunning(DiscardLogger)).
+		Then(FuncContextJob(func(ctx context.Context) error { return boom }))
+	if err := RunJob(context.Background(), job); err != boom {
+		t.Errorf("expected the job's error, got %v", err)
+	}
+}
+
+func TestChainReplaceIfStillRunning(t *testing.T) {
+	started := make(chan struct{}, 2)
+	var canceled int32
+	job := NewChain(ReplaceIfStillRunning(DiscardLogger)).Then(FuncContextJob(func(ctx context.Context) error {
+		started <- struct{}{}
+		select {
+		case <-ctx.Done():
+			atomic.AddInt32(&canceled, 1)
+		case <-time.After(50 * time.Millisecond):
+		}
+		return nil
+	}))
+
+	first := make(chan struct{})
+	go func() {
+		RunJob(context.Background(), job)
+		close(first)
+	}()
+	<-started
+	RunJob(context.Background(), job)
+	<-first
+	if len(started) != 1 || atomic.LoadInt32(&canceled) != 1 {
+		t.Errorf("expected the first run canceled and the second run, got %d canceled", canceled)
+	}
+}
+
+func TestChainSkipIfLate(t *testing.T) {
+	var j countJob
+	job := NewChain(SkipIfLate(DiscardLogger, time.Minute)).Then(&j)
+	run := func(scheduled time.Time) {
+		ctx := context.WithValue(context.Background(), runKey, &runInfo{scheduled: scheduled})
+		RunJob(ctx, job)
+	}
+	run(time.Now())
+	run(time.Now().Add(-2 * time.Minute))
+	job.Run()
+	if c := j.Done(); c != 2 {
+		t.Errorf("expected the late run skipped, got %d runs", c)
+	}
+}
+
+// Lateness is measured by the Cron's Clock rather than the wall clock.
+func TestChainSkipIfLateClock(t *testing.T) {
+	clock := &movingClock{now: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	c := New(WithClock(clock), WithChain(SkipIfLate(DiscardLogger, time.Minute)))
+	var runs int
+	id, _ := c.AddFunc("@every 1h", func() { runs++ })
+	c.runEntry(c.Entry(id), clock.now)
+	c.runEntry(c.Entry(id), clock.now.Add(-2*time.Minute))
+	if runs != 1 {
+		t.Errorf("expected the late run skipped by the Cron's clock, got %d runs", runs)
+	}
+}
+
+func TestChainRetry(t *testing.T) {
+	var attempts int
+	boom := errors.New("boom")
+	job := NewChain(Retry(DiscardLogger, 2, time.Millisecond)).Then(FuncContextJob(func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return boom
+		}
+		return nil
+	}))
+	if err := RunJob(context.Background(), job); err != nil || attempts != 3 {
+		t.Errorf("expected success on the third attempt, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	job = NewChain(Retry(DiscardLogger, 1, time.Millisecond)).Then(FuncContextJob(func(ctx context.Context) error {
+		attempts++
+		return boom
+	}))
+	if err := RunJob(context.Background(), job); err != boom || attempts != 2 {
+		t.Errorf("expected the error after 2 attempts, got %v after %d", err, attempts)
+	}
+}
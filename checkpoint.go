@@ -0,0 +1,99 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// checkpointPrefix is the Store key prefix of entry checkpoints.
+const checkpointPrefix = "checkpoint/"
+
+// WithCheckpoints records in the given Store the scheduled time of each named
+// entry's latest successful run. When the Cron starts, or a named entry is
+// added, its checkpoint is restored into Entry.Prev, so that after a restart
+// Prev reflects the work that was actually completed rather than being zero.
+// Backfill and catch-up decisions can then start from it.
+func WithCheckpoints(s Store) Option {
+	return func(c *Cron) {
+		c.lastRuns = s
+	}
+}
+
+// Checkpoint returns the scheduled time of the latest successful run of the
+// named entry recorded in s, and false if there is none.
+func Checkpoint(ctx context.Context, s Store, name string) (time.Time, bool, error) {
+	return checkpoint(ctx, s, name, nil)
+}
+
+// checkpoint is Checkpoint, upgrading the value with m.
+func checkpoint(ctx context.Context, s Store, name string, m Migration) (time.Time, bool, error) {
+	value, err := s.Get(ctx, checkpointPrefix+name)
+	if err == ErrNotFound {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	data, err := decodeValue("checkpoint", value, m)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	var t time.Time
+	if err := t.UnmarshalText(data); err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+// saveCheckpoint advances the entry's checkpoint to the run's scheduled time,
+// unless a later run has already completed.
+func (c *Cron) saveCheckpoint(ri *runInfo) {
+	if c.lastRuns == nil || ri.entry.Name == "" {
+		return
+	}
+	ctx := context.Background()
+	key := checkpointPrefix + ri.entry.Name
+	text, _ := ri.scheduled.UTC().MarshalText()
+	data := encodeValue(text)
+	for {
+		old, err := c.lastRuns.Get(ctx, key)
+		if err == ErrNotFound {
+			old, err = nil, nil
+		}
+		if err != nil {
+			c.logger.Error(err, "checkpoint", "entry", ri.entry.ID, "run", ri.id)
+			return
+		}
+		if old != nil {
+			var prev time.Time
+			text, err := decodeValue("checkpoint", old, c.migration)
+			if err == nil && prev.UnmarshalText(text) == nil && !prev.Before(ri.scheduled) {
+				return
+			}
+		}
+		ok, err := c.lastRuns.CompareAndSwap(ctx, key, old, data)
+		if err != nil {
+			c.logger.Error(err, "checkpoint", "entry", ri.entry.ID, "run", ri.id)
+			return
+		}
+		if ok {
+			return
+		}
+	}
+}
+
+// restoreCheckpoint sets the entry's Prev from its checkpoint, if it has one.
+func (c *Cron) restoreCheckpoint(e *Entry) {
+	if c.lastRuns == nil || e.Name == "" {
+		return
+	}
+	t, ok, err := checkpoint(context.Background(), c.lastRuns, e.Name, c.migration)
+	if err != nil {
+		c.logger.Error(err, "checkpoint", "entry", e.ID)
+		return
+	}
+	if ok {
+		e.Prev = t.In(c.Location())
+		c.logger.Info("restored", "entry", e.ID, "prev", e.Prev)
+	}
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckpoints(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	c := New(WithCheckpoints(store))
+	fail := false
+	id, _ := c.AddContextFunc("@every 1h", func(context.Context) error {
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}, WithName("report"))
+
+	t1 := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	c.runEntry(c.Entry(id), t2)
+	c.runEntry(c.Entry(id), t1) // completes out of order
+	fail = true
+	c.runEntry(c.Entry(id), t2.Add(time.Hour))
+	if got, ok, err := Checkpoint(ctx, store, "report"); !ok || err != nil || !got.Equal(t2) {
+		t.Errorf("expected the latest successful run %v, got %v, %v, %v", t2, got, ok, err)
+	}
+
+	// A new scheduler restores the checkpoint into Prev.
+	c2 := New(WithCheckpoints(store), WithLocation(time.UTC))
+	id2, _ := c2.AddFunc("@every 1h", func() {}, WithName("report"))
+	c2.Start()
+	defer c2.Stop()
+	if prev := c2.Entry(id2).Prev; !prev.Equal(t2) {
+		t.Errorf("expected Prev restored to %v, got %v", t2, prev)
+	}
+	id3, _ := c2.AddFunc("@every 1h", func() {}, WithName("report"))
+	if prev := c2.Entry(id3).Prev; !prev.Equal(t2) {
+		t.Errorf("expected Prev restored for an added entry, got %v", prev)
+	}
+
+	if _, ok, err := Checkpoint(ctx, store, "missing"); ok || err != nil {
+		t.Errorf("expected no checkpoint, got %v, %v", ok, err)
+	}
+}
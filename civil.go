@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// Daily returns a schedule that activates every day at hour:minute, as the
+// spec "minute hour * * *" does, for Go code that builds schedules without
+// spec strings. A nil loc means the time zone of the Cron, as a spec without
+// CRON_TZ does. It panics if hour or minute is out of range.
+//
+//	c.Schedule(cron.Daily(9, 30, nil), job)
+func Daily(hour, minute int, loc *time.Location) *SpecSchedule {
+	return civil("Daily", hour, minute, loc)
+}
+
+// Weekly returns a schedule that activates every week on the day at
+// hour:minute, as the spec "minute hour * * day" does. A nil loc means the
+// time zone of the Cron. It panics if an argument is out of range.
+//
+//	c.Schedule(cron.Weekly(time.Monday, 8, 0, nil), job)
+func Weekly(day time.Weekday, hour, minute int, loc *time.Location) *SpecSchedule {
+	s := civil("Weekly", hour, minute, loc)
+	checkCivil("Weekly", "day", int(day), dow)
+	s.Dow = 1 << uint(day)
+	return s
+}
+
+// Monthly returns a schedule that activates every month on the day at
+// hour:minute, as the spec "minute hour day * *" does. Like the spec, it
+// skips months that lack the day; see MissingDaySchedule to run in them
+// anyway. A nil loc means the time zone of the Cron. It panics if an
+// argument is out of range.
+//
+//	c.Schedule(cron.Monthly(1, 0, 0, time.UTC), job)
+func Monthly(day, hour, minute int, loc *time.Location) *SpecSchedule {
+	s := civil("Monthly", hour, minute, loc)
+	checkCivil("Monthly", "day", day, dom)
+	s.Dom = 1 << uint(day)
+	return s
+}
+
+// civil returns a schedule that activates every day at hour:minute.
+func civil(fn string, hour, minute int, loc *time.Location) *SpecSchedule {
+	checkCivil(fn, "hour", hour, hours)
+	checkCivil(fn, "minute", minute, minutes)
+	if loc == nil {
+		loc = time.Local
+	}
+	return &SpecSchedule{
+		Second:   1 << seconds.min,
+		Minute:   1 << uint(minute),
+		Hour:     1 << uint(hour),
+		Dom:      all(dom),
+		Month:    all(months),
+		Dow:      all(dow),
+		Location: loc,
+	}
+}
+
+func checkCivil(fn, name string, v int, r bounds) {
+	if v < int(r.min) || v > int(r.max) {
+		panic(fmt.Sprintf("cron: %s: %s %d is out of range [%d, %d]", fn, name, v, r.min, r.max))
+	}
+}
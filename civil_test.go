@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCivilSchedules(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	tests := []struct {
+		got  *SpecSchedule
+		spec string
+	}{
+		{Daily(9, 30, nil), "30 9 * * *"},
+		{Daily(0, 0, tokyo), "CRON_TZ=Asia/Tokyo 0 0 * * *"},
+		{Weekly(time.Monday, 8, 0, nil), "0 8 * * MON"},
+		{Weekly(time.Sunday, 23, 59, nil), "59 23 * * SUN"},
+		{Monthly(1, 0, 0, nil), "0 0 1 * *"},
+		{Monthly(31, 12, 15, tokyo), "CRON_TZ=Asia/Tokyo 15 12 31 * *"},
+	}
+	for _, test := range tests {
+		want, err := ParseStandard(test.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(test.got, want) {
+			t.Errorf("%s: got %+v, want %+v", test.spec, test.got, want)
+		}
+	}
+}
+
+func TestCivilSchedulesNext(t *testing.T) {
+	from := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	if next := Monthly(31, 0, 0, time.UTC).Next(from); !next.Equal(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected February to be skipped, got %v", next)
+	}
+	if next := Weekly(time.Friday, 9, 0, time.UTC).Next(from); !next.Equal(time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Friday, got %v", next)
+	}
+}
+
+func TestCivilSchedulesPanic(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"hour":    func() { Daily(24, 0, nil) },
+		"minute":  func() { Daily(0, -1, nil) },
+		"weekday": func() { Weekly(7, 0, 0, nil) },
+		"day":     func() { Monthly(0, 0, 0, nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,73 @@
+package cron
+
+import "time"
+
+// Clock is the source of time for a Cron: the current time and timers that
+// wake the scheduler. It is replaced in tests to control time, as the
+// crontest package does.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event that a Clock sends on C after a duration, like a
+// time.Timer. Reset rearms it to fire after a new duration, and like
+// time.Timer.Reset, must only be called on a timer that has been stopped or
+// whose value has been received.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// WithClock uses the given Clock in place of the system clock.
+func WithClock(clock Clock) Option {
+	return func(c *Cron) {
+		c.clock = clock
+	}
+}
+
+// realClock is the system clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+
+func (t realTimer) Stop() bool { return t.t.Stop() }
+
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+// afterFunc calls f in its own goroutine once the clock has passed d, as
+// time.AfterFunc does, unless the returned function is called first to stop
+// it.
+func afterFunc(clock Clock, d time.Duration, f func()) (stop func()) {
+	t := clock.NewTimer(d)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-t.C():
+			f()
+		case <-done:
+		}
+	}()
+	return func() {
+		t.Stop()
+		close(done)
+	}
+}
+
+// stopTimer stops the timer and discards a value it sent but that was not
+// received, so that it can be Reset.
+func stopTimer(t Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C():
+		default:
+		}
+	}
+}
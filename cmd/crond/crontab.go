@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// crontabEntry is a command line of a system crontab, with the variables set
+// before it.
+type crontabEntry struct {
+	file    string
+	line    int
+	spec    string // "@reboot" to run once when crond starts
+	user    string
+	command string
+	env     []string // "NAME=value", in the order they were set
+}
+
+// name identifies the entry in logs.
+func (e crontabEntry) name() string {
+	return fmt.Sprintf("%s:%d", e.file, e.line)
+}
+
+// getenv returns the value of the variable as set for the entry.
+func (e crontabEntry) getenv(name string) (string, bool) {
+	for i := len(e.env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(e.env[i], name+"=") {
+			return e.env[i][len(name)+1:], true
+		}
+	}
+	return "", false
+}
+
+var assignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// parseCrontab parses a file in the format of /etc/crontab, where each
+// command line has a user field between the schedule and the command:
+//
+//	SHELL=/bin/sh
+//	MAILTO=ops@example.com
+//	17 *	* * *	root	cd / && run-parts /etc/cron.hourly
+//	@daily		root	/usr/local/bin/rotate
+//
+// Lines starting with "#" are comments. Percent signs in commands are not
+// special, unlike in classic cron, so "\%" is passed to the shell as is,
+// which reads it as "%".
+func parseCrontab(r io.Reader, file string) ([]crontabEntry, error) {
+	var (
+		entries []crontabEntry
+		env     []string
+		scanner = bufio.NewScanner(r)
+	)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if m := assignment.FindStringSubmatch(line); m != nil {
+			env = append(env[:len(env):len(env)], m[1]+"="+unquote(m[2]))
+			continue
+		}
+
+		nspec := 5
+		if line[0] == '@' {
+			nspec = 1
+		}
+		fields, command := splitFields(line, nspec+1)
+		if command == "" {
+			return nil, fmt.Errorf("%s:%d: expected a schedule, user and command", file, n)
+		}
+		entries = append(entries, crontabEntry{
+			file:    file,
+			line:    n,
+			spec:    strings.Join(fields[:nspec], " "),
+			user:    fields[nspec],
+			command: command,
+			env:     env,
+		})
+	}
+	return entries, scanner.Err()
+}
+
+// splitFields returns the first n whitespace-separated fields of the line,
+// and the rest of it, or an empty rest if there are not that many fields.
+func splitFields(line string, n int) ([]string, string) {
+	var fields []string
+	for len(fields) < n {
+		line = strings.TrimLeft(line, " \t")
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			return nil, ""
+		}
+		fields = append(fields, line[:i])
+		line = line[i:]
+	}
+	return fields, strings.TrimSpace(line)
+}
+
+// unquote removes matching single or double quotes around a value.
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// validCronDName is the pattern of the names of files in /etc/cron.d that
+// are read, as by Debian's cron, so that backups and package manager
+// leftovers such as "job.dpkg-old" are skipped.
+var validCronDName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// loadCrontabs parses the crontab and then the files in dir, in order of
+// name. Either may be empty or missing.
+func loadCrontabs(crontab, dir string) ([]crontabEntry, error) {
+	var files []string
+	if crontab != "" {
+		files = append(files, crontab)
+	}
+	if dir != "" {
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.Mode().IsRegular() && validCronDName.MatchString(info.Name()) {
+				files = append(files, filepath.Join(dir, info.Name()))
+			}
+		}
+	}
+
+	var entries []crontabEntry
+	for _, file := range files {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseCrontab(f, file)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, parsed...)
+	}
+	return entries, nil
+}
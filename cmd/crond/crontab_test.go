@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	entries, err := parseCrontab(strings.NewReader(`# comment
+SHELL=/bin/sh
+MAILTO="ops@example.com"
+17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+
+PATH = /usr/local/bin:/usr/bin:/bin
+@daily  backup  /usr/local/bin/backup  --all
+`), "crontab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []crontabEntry{{
+		file:    "crontab",
+		line:    4,
+		spec:    "17 * * * *",
+		user:    "root",
+		command: "cd / && run-parts --report /etc/cron.hourly",
+		env:     []string{"SHELL=/bin/sh", "MAILTO=ops@example.com"},
+	}, {
+		file:    "crontab",
+		line:    7,
+		spec:    "@daily",
+		user:    "backup",
+		command: "/usr/local/bin/backup  --all",
+		env:     []string{"SHELL=/bin/sh", "MAILTO=ops@example.com", "PATH=/usr/local/bin:/usr/bin:/bin"},
+	}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("expected %+v, got %+v", want, entries)
+	}
+	if mailto, _ := entries[1].getenv("MAILTO"); mailto != "ops@example.com" {
+		t.Errorf("expected MAILTO, got %q", mailto)
+	}
+
+	if _, err := parseCrontab(strings.NewReader("0 * * * * root\n"), "bad"); err == nil || !strings.Contains(err.Error(), "bad:1") {
+		t.Errorf("expected an error for a line without a command, got %v", err)
+	}
+}
+
+func TestLoadCrontabs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("b-job", "0 1 * * * root b\n")
+	write("a_job", "0 2 * * * root a\n")
+	write("c.dpkg-old", "0 3 * * * root c\n")
+	write("d~", "0 4 * * * root d\n")
+
+	entries, err := loadCrontabs(filepath.Join(dir, "missing"), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commands []string
+	for _, e := range entries {
+		commands = append(commands, e.command)
+	}
+	if !reflect.DeepEqual(commands, []string{"a", "b"}) {
+		t.Errorf("expected the valid files in order, got %v", commands)
+	}
+}
+
+func TestJobMailsOutput(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("requires /bin/sh")
+	}
+	var got []string
+	m := &mailer{addr: "smtp:25", from: "cron@host", sendMail: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		got = append(got, strings.Join(to, ",")+"\n"+string(msg))
+		return nil
+	}}
+	quiet := &job{mailer: m, entry: crontabEntry{user: "nobody", command: "true", env: []string{"MAILTO=a@x"}}}
+	loud := &job{mailer: m, entry: crontabEntry{user: "nobody", command: "echo $LOGNAME", env: []string{"MAILTO=a@x, b@x"}}}
+	unset := &job{mailer: m, entry: crontabEntry{user: "nobody", command: "echo hi"}}
+	for _, j := range []*job{quiet, loud, unset} {
+		if err := j.RunContext(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 1 || !strings.HasPrefix(got[0], "a@x,b@x\n") || !strings.HasSuffix(got[0], "\r\n\r\nnobody\n") {
+		t.Errorf("expected one mail of the loud job's output, got %q", got)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("CRON_DIR", "/etc/cron.custom")
+	t.Setenv("CRON_SYSLOG", "true")
+	if got := env("CRON_DIR", "/etc/cron.d"); got != "/etc/cron.custom" {
+		t.Errorf("env = %q", got)
+	}
+	if got := env("CRON_CRONTAB", "/etc/crontab"); got != "/etc/crontab" {
+		t.Errorf("env of an unset variable = %q", got)
+	}
+	if !envBool("CRON_SYSLOG") || envBool("CRON_UNSET") {
+		t.Error("unexpected envBool")
+	}
+}
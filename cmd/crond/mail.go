@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// mailer sends the output of commands by SMTP.
+type mailer struct {
+	addr string
+	from string
+
+	// sendMail is smtp.SendMail, replaced in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// send mails the body to the comma-separated addresses in mailto.
+func (m *mailer) send(mailto, subject, body string) error {
+	var to []string
+	for _, addr := range strings.Split(mailto, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(body)
+
+	send := m.sendMail
+	if send == nil {
+		send = smtp.SendMail
+	}
+	return send(m.addr, nil, m.from, to, msg.Bytes())
+}
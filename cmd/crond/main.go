@@ -0,0 +1,182 @@
+// Crond is a lightweight replacement for the cron daemon, for containers. It
+// runs the commands of /etc/crontab and the files in /etc/cron.d, in the same
+// format, through the shell, and mails their output to MAILTO if it is set.
+//
+// Usage:
+//
+//	crond [-crontab /etc/crontab] [-dir /etc/cron.d] [-syslog] [-smtp localhost:25] [-from addr] [-metrics addr]
+//
+// Commands run as the user given on their line when crond runs as root, and
+// as crond's own user otherwise, in the user's home directory if it exists.
+// They get a minimal environment of SHELL, PATH, HOME and LOGNAME, plus the
+// variables set in their file, and always run with /bin/sh. "@reboot"
+// commands run once, when crond starts.
+//
+// Crond logs to standard output, or to syslog with -syslog. With -metrics, it
+// serves Prometheus metrics of its entries at /metrics on the given address.
+// It stops on SIGINT or SIGTERM, waiting for running commands to finish.
+//
+// So that containers need no configuration files beyond their crontabs, each
+// flag may instead be set by an environment variable, which the flag
+// overrides: CRON_CRONTAB, CRON_DIR, CRON_SYSLOG (true or false), CRON_SMTP,
+// CRON_FROM and CRON_METRICS. The variables that cron.OptionsFromEnv reads
+// set the default time zone, the concurrency limit and the history kept:
+//
+//	CRON_TZ=Europe/Berlin CRON_CONCURRENCY=4 CRON_METRICS=:9100 crond
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/robfig/cron/v3"
+	"github.com/robfig/cron/v3/cronprom"
+)
+
+func main() {
+	var (
+		crontab   = flag.String("crontab", env("CRON_CRONTAB", "/etc/crontab"), "system crontab, or empty for none")
+		dir       = flag.String("dir", env("CRON_DIR", "/etc/cron.d"), "directory of crontab files, or empty for none")
+		useSyslog = flag.Bool("syslog", envBool("CRON_SYSLOG"), "log to syslog instead of standard output")
+		smtpAddr  = flag.String("smtp", env("CRON_SMTP", "localhost:25"), "host:port of the SMTP server for MAILTO")
+		from      = flag.String("from", env("CRON_FROM", ""), "sender of mails (default cron@<hostname>)")
+		metrics   = flag.String("metrics", env("CRON_METRICS", ""), "address to serve metrics on, or empty for none")
+	)
+	flag.Parse()
+
+	logger := cron.PrintfLogger(log.New(os.Stdout, "crond: ", log.LstdFlags))
+	if *useSyslog {
+		var err error
+		if logger, err = syslogLogger(); err != nil {
+			log.Fatalf("crond: %v", err)
+		}
+	}
+	if *from == "" {
+		host, _ := os.Hostname()
+		*from = "cron@" + host
+	}
+
+	entries, err := loadCrontabs(*crontab, *dir)
+	if err != nil {
+		logger.Error(err, "load crontabs")
+		os.Exit(1)
+	}
+	opts, err := cron.OptionsFromEnv()
+	if err != nil {
+		logger.Error(err, "environment")
+		os.Exit(1)
+	}
+	c := cron.New(append(opts,
+		cron.WithLogger(logger),
+		cron.WithChain(cron.Recover(logger)),
+		cron.WithSystemd(),
+		cron.WithEventListener(func(ev cron.Event) {
+			if ev.Type == cron.EventFailed {
+				logger.Error(ev.Err, "command failed", "entry", ev.Name)
+			}
+		}))...)
+	mailer := &mailer{addr: *smtpAddr, from: *from}
+	var reboot []*job
+	for _, e := range entries {
+		j := &job{entry: e, mailer: mailer, logger: logger}
+		if e.spec == "@reboot" {
+			reboot = append(reboot, j)
+			continue
+		}
+		if _, err := c.AddJob(e.spec, j, cron.WithName(e.name())); err != nil {
+			logger.Error(err, "bad schedule", "entry", e.name())
+			os.Exit(1)
+		}
+	}
+	logger.Info("loaded", "entries", len(entries))
+
+	for _, j := range reboot {
+		go j.Run()
+	}
+	c.Start()
+	if *metrics != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", cronprom.Handler(c))
+		go func() {
+			if err := http.ListenAndServe(*metrics, mux); err != nil {
+				logger.Error(err, "serve metrics", "addr", *metrics)
+			}
+		}()
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	<-c.Stop().Done()
+}
+
+// env returns the value of the environment variable, or def if it is unset
+// or empty.
+func env(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// envBool returns the boolean value of the environment variable, or false if
+// it is unset or not a boolean.
+func envBool(name string) bool {
+	b, _ := strconv.ParseBool(os.Getenv(name))
+	return b
+}
+
+// job runs the command of a crontab entry, mailing its output.
+type job struct {
+	entry  crontabEntry
+	mailer *mailer
+	logger cron.Logger
+}
+
+func (j *job) Run() {
+	if err := j.RunContext(context.Background()); err != nil {
+		j.logger.Error(err, "command failed", "entry", j.entry.name())
+	}
+}
+
+func (j *job) RunContext(ctx context.Context) error {
+	e := j.entry
+	home, dir := "/", "/"
+	if u, err := user.Lookup(e.user); err == nil {
+		home = u.HomeDir
+		if _, err := os.Stat(home); err == nil {
+			dir = home
+		}
+	}
+	cmd := &cron.ShellCommandJob{
+		Command: e.command,
+		Dir:     dir,
+		Env:     append([]string{"SHELL=/bin/sh", "PATH=/usr/bin:/bin", "HOME=" + home, "LOGNAME=" + e.user}, e.env...),
+	}
+	if os.Geteuid() == 0 {
+		cmd.User = e.user
+	}
+	var output bytes.Buffer
+	cmd.Output = &output
+	err := cmd.RunContext(ctx)
+	if mailto, _ := e.getenv("MAILTO"); mailto != "" && output.Len() > 0 {
+		subject := fmt.Sprintf("Cron <%s@%s> %s", e.user, hostname(), e.command)
+		if merr := j.mailer.send(mailto, subject, output.String()); merr != nil {
+			j.logger.Error(merr, "mail output", "entry", e.name(), "to", mailto)
+		}
+	}
+	return err
+}
+
+func hostname() string {
+	host, _ := os.Hostname()
+	return host
+}
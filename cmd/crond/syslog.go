@@ -0,0 +1,20 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"log"
+	"log/syslog"
+
+	"github.com/robfig/cron/v3"
+)
+
+// syslogLogger returns a logger writing to the system log.
+func syslogLogger() (cron.Logger, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_CRON, "crond")
+	if err != nil {
+		return nil, err
+	}
+	return cron.PrintfLogger(log.New(w, "", 0)), nil
+}
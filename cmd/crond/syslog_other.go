@@ -0,0 +1,15 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package main
+
+import (
+	"errors"
+
+	"github.com/robfig/cron/v3"
+)
+
+// syslogLogger fails, as there is no system log.
+func syslogLogger() (cron.Logger, error) {
+	return nil, errors.New("syslog is not supported on this system")
+}
@@ -0,0 +1,42 @@
+//go:build js
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// serve defines the global cron object, and keeps the program alive to
+// answer its calls.
+func serve() {
+	js.Global().Set("cron", js.ValueOf(map[string]interface{}{
+		"validate": function("validate"),
+		"next":     function("next"),
+	}))
+	select {}
+}
+
+// function returns a JavaScript function that answers a request for op,
+// given as an object.
+func function(op string) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		JSON := js.Global().Get("JSON")
+		req := request{}
+		if len(args) > 0 {
+			if err := json.Unmarshal([]byte(JSON.Call("stringify", args[0]).String()), &req); err != nil {
+				return JSON.Call("parse", mustMarshal(response{Error: "invalid request: " + err.Error()}))
+			}
+		}
+		req.Op = op
+		return JSON.Call("parse", mustMarshal(handle(req)))
+	})
+}
+
+func mustMarshal(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,117 @@
+// Cronwasm exposes the parser of package cron to JavaScript and WASI hosts,
+// so that browsers validate specs and preview their times with the same code
+// as servers.
+//
+// Built for browsers or Node.js, with
+//
+//	GOOS=js GOARCH=wasm go build -o cron.wasm ./cmd/cronwasm
+//
+// and loaded with Go's wasm_exec.js, it defines a global "cron" object whose
+// functions take and return plain objects:
+//
+//	cron.validate({spec: "*/5 * * * *"})
+//	// {valid: true}
+//	cron.next({spec: "0 9 * * MON-FRI", tz: "Europe/Paris", count: 3})
+//	// {valid: true, times: ["2024-03-04T09:00:00+01:00", ...]}
+//
+// Built for WASI, with GOOS=wasip1, or natively, it reads one such request
+// per line of standard input, with an "op" of "validate" or "next", and
+// writes one response per line of standard output.
+//
+// Requests may set "seconds" to parse specs with a seconds field, "lang" for
+// the language of messages, and, for "next", "after" as an RFC 3339 time and
+// "count", which default to now and 5. Times are in the time zone "tz", or
+// UTC, never the host's: browsers give Go a fixed offset in place of their
+// zone, which would be wrong across daylight saving changes. The zone
+// database is embedded, as browsers and WASI hosts have none; build with
+// -tags cron_notzdata to leave it out, at the cost of zones other than UTC.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func main() {
+	serve()
+}
+
+// maxCount is the most times a "next" request may ask for.
+const maxCount = 100
+
+var (
+	standardParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	secondsParser  = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+type request struct {
+	Op      string    `json:"op"`
+	Spec    string    `json:"spec"`
+	Seconds bool      `json:"seconds"`
+	Lang    string    `json:"lang"`
+	TZ      string    `json:"tz"`
+	After   time.Time `json:"after"`
+	Count   int       `json:"count"`
+}
+
+type response struct {
+	Valid      bool     `json:"valid"`
+	Error      string   `json:"error,omitempty"`
+	Suggestion string   `json:"suggestion,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+	Times      []string `json:"times,omitempty"`
+}
+
+// handle answers a request.
+func handle(req request) response {
+	p := standardParser
+	if req.Seconds {
+		p = secondsParser
+	}
+	schedule, err := p.Parse(req.Spec)
+	if err != nil {
+		resp := response{Error: cron.Localize(err, req.Lang)}
+		if pe, ok := err.(*cron.ParseError); ok {
+			resp.Suggestion = pe.Suggestion
+		}
+		return resp
+	}
+	resp := response{Valid: true, Warnings: []string{}}
+	for _, w := range p.Lint(req.Spec) {
+		resp.Warnings = append(resp.Warnings, w.Localize(req.Lang))
+	}
+
+	switch req.Op {
+	case "validate", "":
+	case "next":
+		loc := time.UTC
+		if req.TZ != "" {
+			if loc, err = time.LoadLocation(req.TZ); err != nil {
+				return response{Error: fmt.Sprintf("unknown time zone %s: %v", req.TZ, err)}
+			}
+		}
+		t := req.After
+		if t.IsZero() {
+			t = time.Now()
+		}
+		count := req.Count
+		if count <= 0 {
+			count = 5
+		}
+		if count > maxCount {
+			count = maxCount
+		}
+		resp.Times = []string{}
+		for t = t.In(loc); len(resp.Times) < count; {
+			if t = schedule.Next(t); t.IsZero() {
+				break
+			}
+			resp.Times = append(resp.Times, t.Format(time.RFC3339))
+		}
+	default:
+		return response{Error: "unknown op: " + req.Op}
+	}
+	return resp
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandle(t *testing.T) {
+	resp := handle(request{Op: "validate", Spec: "*/1 * * * *"})
+	if !resp.Valid || len(resp.Warnings) != 1 {
+		t.Errorf("expected a valid spec with a warning, got %+v", resp)
+	}
+	resp = handle(request{Op: "validate", Spec: "@dialy", Lang: "zh"})
+	if resp.Valid || resp.Suggestion != "@daily" || !strings.Contains(resp.Error, "@dialy") {
+		t.Errorf("expected an error suggesting @daily, got %+v", resp)
+	}
+
+	after := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	resp = handle(request{Op: "next", Spec: "0 9 * * MON-FRI", TZ: "Europe/Paris", After: after, Count: 2})
+	if want := []string{"2024-03-04T09:00:00+01:00", "2024-03-05T09:00:00+01:00"}; strings.Join(resp.Times, " ") != strings.Join(want, " ") {
+		t.Errorf("expected %v, got %+v", want, resp)
+	}
+	resp = handle(request{Op: "next", Spec: "0 0 0 * * *", Seconds: true, After: after, Count: 1})
+	if len(resp.Times) != 1 || resp.Times[0] != "2024-03-02T00:00:00Z" {
+		t.Errorf("expected midnight in UTC, got %+v", resp)
+	}
+}
+
+func TestServeLines(t *testing.T) {
+	in := strings.NewReader(`{"op": "validate", "spec": "0 0 * * *"}
+not json
+{"op": "next", "spec": "0 0 1 1 *", "after": "2024-03-01T00:00:00Z", "count": 1}
+`)
+	var out bytes.Buffer
+	if err := serveLines(in, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`{"valid":true}`,
+		`{"valid":false,"error":"invalid request: invalid character 'o' in literal null (expecting 'u')"}`,
+		`{"valid":true,"times":["2025-01-01T00:00:00Z"]}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(want, "\n"), out.String())
+	}
+}
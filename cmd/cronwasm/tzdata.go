@@ -0,0 +1,6 @@
+//go:build !cron_notzdata
+
+package main
+
+// Browsers and WASI hosts have no zone database for time.LoadLocation.
+import _ "time/tzdata"
@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ShellCommandJob is a Job that runs a command line through the shell, as
+// classic cron does.
+//
+// The zero values of the sandboxing fields leave the command as free as this
+// process. Set them to confine commands from semi-trusted users.
+type ShellCommandJob struct {
+	// Command is the command line, passed to "/bin/sh -c".
+	Command string
+
+	// Dir is the working directory of the command, or this process's if
+	// empty.
+	Dir string
+
+	// User and Group run the command as another user and group, given by
+	// name or numeric ID, which requires privileges. If only User is set, the
+	// command runs with the user's primary group. They are supported on Unix
+	// only.
+	User  string
+	Group string
+
+	// EnvAllow names the variables of this process's environment that are
+	// passed to the command, and Env adds more, as "KEY=value". If both are
+	// nil, the command inherits the whole environment.
+	EnvAllow []string
+	Env      []string
+
+	// CPUTime limits the CPU time of the command, and Memory limits its
+	// address space in bytes, with the shell's ulimit. Zero is no limit.
+	CPUTime time.Duration
+	Memory  uint64
+
+	// MaxOutput is how many bytes of output are kept for the error of a
+	// failed command. The rest is discarded. Zero keeps all of it.
+	MaxOutput int
+
+	// Output, if set, receives all of the command's output as well, whether
+	// it fails or not, as classic cron mails it. Runs of the job share it.
+	Output io.Writer
+}
+
+// NewShellCommandJob returns a ShellCommandJob for the given command line.
+func NewShellCommandJob(command string) *ShellCommandJob {
+	return &ShellCommandJob{Command: command}
+}
+
+// Run runs the command, discarding any error.
+func (j *ShellCommandJob) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext runs the command, killing it if ctx is done. A command that
+// fails or exits with a non-zero status returns an error that includes its
+// output.
+func (j *ShellCommandJob) RunContext(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", j.limits()+j.Command)
+	cmd.Dir = j.Dir
+	cmd.Env = j.environ()
+	if j.User != "" || j.Group != "" {
+		if err := setCredential(cmd, j.User, j.Group); err != nil {
+			return fmt.Errorf("%s: %v", j.Command, err)
+		}
+	}
+	out := &cappedBuffer{max: j.MaxOutput}
+	cmd.Stdout = out
+	if j.Output != nil {
+		cmd.Stdout = io.MultiWriter(out, j.Output)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Run(); err != nil {
+		if output := strings.TrimSpace(out.String()); output != "" {
+			return fmt.Errorf("%s: %v: %s", j.Command, err, output)
+		}
+		return fmt.Errorf("%s: %v", j.Command, err)
+	}
+	return nil
+}
+
+// limits returns the ulimit commands that set the job's resource limits.
+func (j *ShellCommandJob) limits() string {
+	var s string
+	if j.CPUTime > 0 {
+		secs := (j.CPUTime + time.Second - 1) / time.Second
+		s += fmt.Sprintf("ulimit -t %d || exit 126; ", secs)
+	}
+	if j.Memory > 0 {
+		s += fmt.Sprintf("ulimit -v %d || exit 126; ", (j.Memory+1023)/1024)
+	}
+	return s
+}
+
+// environ returns the environment of the command, or nil to inherit this
+// process's.
+func (j *ShellCommandJob) environ() []string {
+	if j.EnvAllow == nil && j.Env == nil {
+		return nil
+	}
+	env := []string{}
+	for _, name := range j.EnvAllow {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return append(env, j.Env...)
+}
+
+// cappedBuffer keeps up to max bytes written to it, or all if max is zero,
+// and discards the rest.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.max > 0 {
+		if room := b.max - b.buf.Len(); room < len(p) {
+			p = p[:room]
+			b.truncated = true
+		}
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *cappedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "... (truncated)"
+	}
+	return b.buf.String()
+}
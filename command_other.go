@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package cron
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// setCredential is not supported on this platform.
+func setCredential(cmd *exec.Cmd, user, group string) error {
+	return errors.New("running as another user is not supported on this platform")
+}
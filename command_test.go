@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShellCommandJob(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	if err := NewShellCommandJob("echo hello > " + out).RunContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(out)
+	if err != nil || string(data) != "hello\n" {
+		t.Errorf("expected the command to run, got %q, %v", data, err)
+	}
+
+	err = NewShellCommandJob("echo oops >&2; exit 3").RunContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("expected the exit status and output, got %v", err)
+	}
+
+	var output bytes.Buffer
+	job := &ShellCommandJob{Command: "echo out; echo err >&2", Output: &output}
+	if err := job.RunContext(context.Background()); err != nil || output.String() != "out\nerr\n" {
+		t.Errorf("expected the output copied, got %q, %v", output.String(), err)
+	}
+}
+
+func TestShellCommandJobSandbox(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires /bin/sh")
+	}
+	dir := t.TempDir()
+	os.Setenv("CRON_TEST_ALLOWED", "yes")
+	os.Setenv("CRON_TEST_DENIED", "yes")
+	defer os.Unsetenv("CRON_TEST_ALLOWED")
+	defer os.Unsetenv("CRON_TEST_DENIED")
+
+	j := &ShellCommandJob{
+		Command:  `echo "$(pwd) $CRON_TEST_ALLOWED-$CRON_TEST_DENIED-$EXTRA $(ulimit -t) $(ulimit -v)" > out`,
+		Dir:      dir,
+		EnvAllow: []string{"CRON_TEST_ALLOWED"},
+		Env:      []string{"EXTRA=1"},
+		CPUTime:  1500 * time.Millisecond,
+		Memory:   1 << 30,
+	}
+	if err := j.RunContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "out"))
+	if want := dir + " yes--1 2 1048576\n"; string(data) != want {
+		t.Errorf("expected %q, got %q", want, data)
+	}
+
+	j = &ShellCommandJob{Command: "yes | head -c 10000; exit 1", MaxOutput: 100}
+	err := j.RunContext(context.Background())
+	if err == nil || !strings.HasSuffix(err.Error(), "... (truncated)") || len(err.Error()) > 200 {
+		t.Errorf("expected the output truncated, got %d bytes", len(err.Error()))
+	}
+}
+
+func TestShellCommandJobUser(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() != 0 {
+		t.Skip("requires root")
+	}
+	j := &ShellCommandJob{Command: "test $(id -u) != 0", Dir: "/", User: "nobody"}
+	if err := j.RunContext(context.Background()); err != nil {
+		t.Errorf("expected the command to run as nobody, got %v", err)
+	}
+
+	j = &ShellCommandJob{Command: "true", User: "no-such-user-here"}
+	if err := j.RunContext(context.Background()); err == nil {
+		t.Error("expected an unknown user to fail")
+	}
+}
@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// runInfo describes the run that a job's context belongs to.
+type runInfo struct {
+	lastBeat  int64 // UnixNano, accessed atomically
+	stuck     int32 // accessed atomically
+	id        string
+	entry     Entry
+	scheduled time.Time
+	start     time.Time
+	token     uint64
+	costMu    sync.Mutex
+	costs     map[string]float64
+	cancel    context.CancelFunc
+	walStop   func() // stops renewing the run's write-ahead log record
+	cron      *Cron
+}
+
+type contextKey int
+
+const (
+	runKey contextKey = iota
+	scheduledKey
+)
+
+// runFromContext returns the run information carried by ctx, if any.
+func runFromContext(ctx context.Context) (*runInfo, bool) {
+	ri, ok := ctx.Value(runKey).(*runInfo)
+	return ri, ok
+}
+
+// emit sends an event about the run to its Cron's listeners.
+func (ri *runInfo) emit(typ EventType, d time.Duration) {
+	if ri.cron == nil {
+		return
+	}
+	ri.cron.emit(Event{Type: typ, Entry: ri.entry.ID, Name: ri.entry.Name, RunID: ri.id,
+		Scheduled: ri.scheduled, Time: ri.cron.now(), Duration: d})
+}
+
+// clock returns the Clock of the run's Cron, or the system clock for runs
+// outside a Cron.
+func (ri *runInfo) clock() Clock {
+	if ri == nil || ri.cron == nil {
+		return realClock{}
+	}
+	return ri.cron.clock
+}
+
+// clockFromContext returns the Clock of the Cron running ctx, or the system
+// clock if ctx did not come from a Cron.
+func clockFromContext(ctx context.Context) Clock {
+	ri, _ := runFromContext(ctx)
+	return ri.clock()
+}
+
+// RunIDFromContext returns the unique ID of the run that ctx was passed to.
+// It returns false if ctx did not come from a Cron.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	ri, ok := runFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return ri.id, true
+}
+
+// EntryFromContext returns a snapshot of the entry whose run ctx was passed
+// to, as of the start of the run. It returns false if ctx did not come from a
+// Cron.
+func EntryFromContext(ctx context.Context) (Entry, bool) {
+	ri, ok := runFromContext(ctx)
+	if !ok {
+		return Entry{}, false
+	}
+	return ri.entry, true
+}
+
+// ScheduledTimeFromContext returns the time the run that ctx was passed to
+// was scheduled for, which may be well before it started, for example if it
+// was deferred or waited for a concurrency limit. Jobs should use it rather
+// than the current time to decide what period to process. It returns false
+// if ctx did not come from a Cron or ContextWithScheduledTime.
+func ScheduledTimeFromContext(ctx context.Context) (time.Time, bool) {
+	if t, ok := ctx.Value(scheduledKey).(time.Time); ok {
+		return t, true
+	}
+	ri, ok := runFromContext(ctx)
+	if !ok {
+		return time.Time{}, false
+	}
+	return ri.scheduled, true
+}
+
+// ContextWithScheduledTime returns a copy of ctx that carries the scheduled
+// time of a run, for running jobs outside of a Cron, such as on a remote
+// worker or in tests.
+func ContextWithScheduledTime(ctx context.Context, scheduled time.Time) context.Context {
+	return context.WithValue(ctx, scheduledKey, scheduled)
+}
+
+// newRunID returns a random 128-bit identifier in hex.
+func newRunID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestContextAccessors(t *testing.T) {
+	type seen struct {
+		id        string
+		entry     Entry
+		scheduled time.Time
+	}
+	runs := make(chan seen, 2)
+	cron := newWithSeconds()
+	cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		var s seen
+		s.id, _ = RunIDFromContext(ctx)
+		s.entry, _ = EntryFromContext(ctx)
+		s.scheduled, _ = ScheduledTimeFromContext(ctx)
+		runs <- s
+		return nil
+	}, WithName("sync"))
+	cron.Start()
+	defer cron.Stop()
+
+	var got []seen
+	for len(got) < 2 {
+		select {
+		case s := <-runs:
+			got = append(got, s)
+		case <-time.After(2 * OneSecond):
+			t.Fatalf("expected 2 runs, got %d", len(got))
+		}
+	}
+	for _, s := range got {
+		if len(s.id) != 32 || s.entry.Name != "sync" || s.scheduled.IsZero() || s.scheduled.Nanosecond() != 0 {
+			t.Errorf("unexpected run metadata: %+v", s)
+		}
+	}
+	if got[0].id == got[1].id {
+		t.Error("expected distinct run IDs")
+	}
+	if !got[1].scheduled.After(got[0].scheduled) {
+		t.Error("expected increasing scheduled times")
+	}
+}
+
+func TestContextAccessorsOutsideCron(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := RunIDFromContext(ctx); ok {
+		t.Error("expected no run ID")
+	}
+	if _, ok := EntryFromContext(ctx); ok {
+		t.Error("expected no entry")
+	}
+	if _, ok := ScheduledTimeFromContext(ctx); ok {
+		t.Error("expected no scheduled time")
+	}
+}
+
+func TestContextWithScheduledTime(t *testing.T) {
+	scheduled := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	ctx := ContextWithScheduledTime(context.Background(), scheduled)
+	if s, ok := ScheduledTimeFromContext(ctx); !ok || !s.Equal(scheduled) {
+		t.Errorf("expected %v, got %v", scheduled, s)
+	}
+}
+
+// A late run is given the time it was scheduled for, not the time it starts.
+func TestAddScheduledFunc(t *testing.T) {
+	c := New()
+	var got time.Time
+	id, _ := c.AddScheduledFunc("0 2 * * *", func(scheduled time.Time) error {
+		got = scheduled
+		return nil
+	})
+	scheduled := time.Now().Add(-3 * time.Hour).Truncate(time.Hour)
+	c.runEntry(c.Entry(id), scheduled)
+	if !got.Equal(scheduled) {
+		t.Errorf("expected %v, got %v", scheduled, got)
+	}
+}
@@ -0,0 +1,178 @@
+package cron
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AddCost adds amount to the named cost of the run that ctx was passed to,
+// such as "cpu_seconds" or "api_calls". Jobs call it to annotate their runs
+// for cost accounting. It does nothing if ctx did not come from a Cron.
+func AddCost(ctx context.Context, name string, amount float64) {
+	ri, ok := runFromContext(ctx)
+	if !ok {
+		return
+	}
+	ri.costMu.Lock()
+	defer ri.costMu.Unlock()
+	if ri.costs == nil {
+		ri.costs = make(map[string]float64)
+	}
+	ri.costs[name] += amount
+}
+
+// Usage is the resource usage of a completed run.
+type Usage struct {
+	Entry EntryID
+	Name  string
+	Tags  []string
+	RunID string
+
+	// Duration is how long the run took.
+	Duration time.Duration
+
+	// Costs are the annotations the job made with AddCost.
+	Costs map[string]float64
+}
+
+// CostHook receives the usage of each completed run.
+type CostHook func(Usage)
+
+// WithCostHook calls h with the usage of every run once it completes, for
+// billing tenants or attributing infrastructure spend to jobs. Hooks are
+// called synchronously, in the order they were added. See CostLedger for
+// one that aggregates usage per entry and tag.
+func WithCostHook(h CostHook) Option {
+	return func(c *Cron) {
+		c.costHooks = append(c.costHooks, h)
+	}
+}
+
+// reportUsage calls the cost hooks with the run's usage.
+func (c *Cron) reportUsage(ri *runInfo, d time.Duration) {
+	if len(c.costHooks) == 0 {
+		return
+	}
+	ri.costMu.Lock()
+	costs := make(map[string]float64, len(ri.costs))
+	for k, v := range ri.costs {
+		costs[k] = v
+	}
+	ri.costMu.Unlock()
+	u := Usage{Entry: ri.entry.ID, Name: ri.entry.Name, Tags: ri.entry.Tags, RunID: ri.id, Duration: d, Costs: costs}
+	for _, h := range c.costHooks {
+		h(u)
+	}
+}
+
+// CostTotal is the usage of a group of runs.
+type CostTotal struct {
+	Runs     int                `json:"runs"`
+	Duration time.Duration      `json:"duration"`
+	Costs    map[string]float64 `json:"costs"`
+}
+
+func (t *CostTotal) add(u Usage) {
+	t.Runs++
+	t.Duration += u.Duration
+	if t.Costs == nil {
+		t.Costs = make(map[string]float64)
+	}
+	for k, v := range u.Costs {
+		t.Costs[k] += v
+	}
+}
+
+// CostLedger totals usage per entry and per tag. Its Record method is a
+// CostHook:
+//
+//	ledger := cron.NewCostLedger()
+//	c := cron.New(cron.WithCostHook(ledger.Record))
+type CostLedger struct {
+	mu      sync.Mutex
+	entries map[string]*CostTotal
+	tags    map[string]*CostTotal
+}
+
+// NewCostLedger returns an empty CostLedger.
+func NewCostLedger() *CostLedger {
+	return &CostLedger{
+		entries: make(map[string]*CostTotal),
+		tags:    make(map[string]*CostTotal),
+	}
+}
+
+// Record adds the run's usage to the totals of its entry and each of its tags.
+// Entries are keyed by name, or by "#" and their ID if unnamed.
+func (l *CostLedger) Record(u Usage) {
+	key := u.Name
+	if key == "" {
+		key = "#" + strconv.Itoa(int(u.Entry))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	total(l.entries, key).add(u)
+	for _, tag := range uniq(u.Tags) {
+		total(l.tags, tag).add(u)
+	}
+}
+
+// ByEntry returns the totals per entry.
+func (l *CostLedger) ByEntry() map[string]CostTotal {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return copyTotals(l.entries)
+}
+
+// ByTag returns the totals per tag. A run counts towards each of its tags.
+func (l *CostLedger) ByTag() map[string]CostTotal {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return copyTotals(l.tags)
+}
+
+// Reset clears the totals, for example at the end of a billing period.
+func (l *CostLedger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = make(map[string]*CostTotal)
+	l.tags = make(map[string]*CostTotal)
+}
+
+func total(m map[string]*CostTotal, key string) *CostTotal {
+	t, ok := m[key]
+	if !ok {
+		t = &CostTotal{}
+		m[key] = t
+	}
+	return t
+}
+
+func copyTotals(m map[string]*CostTotal) map[string]CostTotal {
+	out := make(map[string]CostTotal, len(m))
+	for k, t := range m {
+		c := *t
+		c.Costs = make(map[string]float64, len(t.Costs))
+		for name, v := range t.Costs {
+			c.Costs[name] = v
+		}
+		out[k] = c
+	}
+	return out
+}
+
+// uniq returns the distinct strings in s, sorted.
+func uniq(s []string) []string {
+	s = append([]string(nil), s...)
+	sort.Strings(s)
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCostHooks(t *testing.T) {
+	ledger := NewCostLedger()
+	var usages []Usage
+	c := New(WithCostHook(ledger.Record), WithCostHook(func(u Usage) { usages = append(usages, u) }))
+	report, _ := c.AddContextFunc("@every 1h", func(ctx context.Context) error {
+		AddCost(ctx, "api_calls", 3)
+		AddCost(ctx, "api_calls", 2)
+		AddCost(ctx, "usd", 0.25)
+		return nil
+	}, WithName("report"), WithTags("team-a", "billing", "team-a"))
+	sync, _ := c.AddFunc("@every 1h", func() {}, WithTags("team-a"))
+
+	c.runEntry(c.Entry(report), c.now())
+	c.runEntry(c.Entry(report), c.now())
+	c.runEntry(c.Entry(sync), c.now())
+
+	if len(usages) != 3 || usages[0].Costs["api_calls"] != 5 || usages[0].Name != "report" {
+		t.Fatalf("unexpected usages: %+v", usages)
+	}
+	byEntry := ledger.ByEntry()
+	if tot := byEntry["report"]; tot.Runs != 2 || tot.Costs["api_calls"] != 10 || tot.Costs["usd"] != 0.5 {
+		t.Errorf("unexpected report total: %+v", tot)
+	}
+	if tot := byEntry["#2"]; tot.Runs != 1 || len(tot.Costs) != 0 {
+		t.Errorf("unexpected unnamed entry total: %+v", tot)
+	}
+	byTag := ledger.ByTag()
+	if tot := byTag["team-a"]; tot.Runs != 3 || tot.Costs["usd"] != 0.5 {
+		t.Errorf("unexpected team-a total: %+v", tot)
+	}
+	if tot := byTag["billing"]; tot.Runs != 2 {
+		t.Errorf("unexpected billing total: %+v", tot)
+	}
+
+	ledger.Reset()
+	if len(ledger.ByEntry()) != 0 || len(ledger.ByTag()) != 0 {
+		t.Error("expected the ledger reset")
+	}
+	AddCost(context.Background(), "usd", 1) // no run; ignored
+}
@@ -2,8 +2,9 @@ package cron
 
 import (
 	"context"
-	"sort"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,19 +12,46 @@ import (
 // specified by the schedule. It may be started, stopped, and the entries may
 // be inspected while running.
 type Cron struct {
-	entries   []*Entry
-	chain     Chain
-	stop      chan struct{}
-	add       chan *Entry
-	remove    chan EntryID
-	snapshot  chan chan []Entry
-	running   bool
-	logger    Logger
-	runningMu sync.Mutex
-	location  *time.Location
-	parser    ScheduleParser
-	nextID    EntryID
-	jobWaiter sync.WaitGroup
+	entries    *entryTable
+	chain      Chain
+	stop       chan struct{}
+	poke       chan struct{}
+	snapshot   chan chan []Entry
+	running    bool
+	logger     Logger
+	runningMu  sync.RWMutex
+	location   atomic.Value // *time.Location
+	parser     ScheduleParser
+	jobWaiter  jobGroup
+	singleton  *fileLock
+	dispatcher Dispatcher
+	listeners  []EventListener
+	stats      runStats
+	slowFactor float64
+	overrun    float64
+	active     activeRuns
+	inflight   inflightRuns
+	pending    pendingRuns
+	limiter    *limiter
+	bulkheads  map[string]*limiter
+	elector    Elector
+	wal        *writeAheadLog
+	fencing    Store
+	dedup      *deduper
+	lastRuns   Store
+	blackouts  blackouts
+	budgets    budgetUsage
+	backoffs   failureBackoffs
+	migration  Migration
+	costHooks  []CostHook
+	clock      Clock
+	sharder    Sharder
+	lead       time.Duration
+	queueing   queueing
+	threads    osThreads
+	restored   map[string]EntrySnapshot
+	tieBreak   TieBreak
+	systemd    *sdNotifier
 }
 
 // ScheduleParser is an interface for schedule spec parsers that return a Schedule
@@ -36,6 +64,25 @@ type Job interface {
 	Run()
 }
 
+// ContextJob is implemented by jobs that accept the context of each run and
+// report failure by returning an error. Cron runs such jobs through
+// RunContext instead of Run.
+type ContextJob interface {
+	Job
+	RunContext(ctx context.Context) error
+}
+
+// RunJob runs the given job with ctx, through RunContext if it is a
+// ContextJob. JobWrappers should use it to invoke the job they wrap, so that
+// the context and error are passed along.
+func RunJob(ctx context.Context, j Job) error {
+	if cj, ok := j.(ContextJob); ok {
+		return cj.RunContext(ctx)
+	}
+	j.Run()
+	return nil
+}
+
 // Schedule describes a job's duty cycle.
 type Schedule interface {
 	// Next returns the next activation time, later than the given time.
@@ -43,6 +90,26 @@ type Schedule interface {
 	Next(time.Time) time.Time
 }
 
+// PrevSchedule is implemented by schedules that can also tell when they last
+// activated, such as SpecSchedule, for catching up on missed runs and for
+// showing when a job was last due.
+type PrevSchedule interface {
+	Schedule
+
+	// Prev returns the latest activation time earlier than the given time,
+	// or the zero time if there is none.
+	Prev(time.Time) time.Time
+}
+
+// Prev returns the activation time of the schedule before t, and true, if
+// the schedule is a PrevSchedule, and false otherwise.
+func Prev(s Schedule, t time.Time) (time.Time, bool) {
+	if ps, ok := s.(PrevSchedule); ok {
+		return ps.Prev(t), true
+	}
+	return time.Time{}, false
+}
+
 // EntryID identifies an entry within a Cron instance
 type EntryID int
 
@@ -52,6 +119,22 @@ type Entry struct {
 	// snapshot or remove it.
 	ID EntryID
 
+	// Name is an optional caller-assigned name for this entry, set with
+	// WithName. Unlike ID, it is stable across processes and restarts.
+	Name string
+
+	// Tags are optional caller-assigned labels for this entry, set with
+	// WithTags, used to configure groups of entries together.
+	Tags []string
+
+	// Priority ranks the entry against others, set with WithPriority. Higher
+	// values are more important. The default is 0.
+	Priority int
+
+	// Spec is the spec string the entry was added with, if it was added by
+	// AddFunc or AddJob.
+	Spec string
+
 	// Schedule on which this job should be run.
 	Schedule Schedule
 
@@ -69,6 +152,45 @@ type Entry struct {
 	// It is kept around so that user code that needs to get at the job later,
 	// e.g. via Entries() can do so.
 	Job Job
+
+	// HeartbeatTimeout is how long a run may go without calling Heartbeat
+	// before it is considered stuck, or zero if runs are not watched. It is
+	// set with WithHeartbeatTimeout, along with CancelStuck.
+	HeartbeatTimeout time.Duration
+
+	// CancelStuck is whether the context of a stuck run is cancelled.
+	CancelStuck bool
+
+	// DailyBudget is how much run time the entry may use per day before its
+	// firings are skipped for the rest of the day, or zero for no limit. It
+	// is set with WithDailyBudget.
+	DailyBudget time.Duration
+
+	// LockOSThread is whether the entry's jobs run on an OS thread of their
+	// own. It is set with WithLockOSThread.
+	LockOSThread bool
+
+	// FailureBackoff is how long the entry's firings are skipped after a
+	// failed run, doubling with each consecutive failure up to
+	// MaxFailureBackoff, or zero to never skip them. They are set with
+	// WithFailureBackoff.
+	FailureBackoff    time.Duration
+	MaxFailureBackoff time.Duration
+
+	// Sampler, if set, chooses which of the entry's firings run, and the
+	// others are skipped. It is set with WithProbability.
+	Sampler *Sampler
+
+	// StartDelay is how long after the Cron starts, or the entry is added to
+	// a running Cron, the entry may first fire. It is set with
+	// WithStartDelay.
+	StartDelay time.Duration
+
+	// Overrun is how many runs of the entry were still running, or waiting
+	// to, when it last fired: the depth of its pile-up. An entry with a
+	// nonzero Overrun is overrunning, its runs taking longer than its
+	// interval. Runs handed to a Dispatcher are not counted.
+	Overrun int
 }
 
 // Valid returns true if this is not the zero entry.
@@ -90,6 +212,9 @@ func (s byTime) Less(i, j int) bool {
 	if s[j].Next.IsZero() {
 		return true
 	}
+	if s[i].Next.Equal(s[j].Next) {
+		return s[i].ID < s[j].ID
+	}
 	return s[i].Next.Before(s[j].Next)
 }
 
@@ -112,18 +237,18 @@ func (s byTime) Less(i, j int) bool {
 // See "cron.With*" to modify the default behavior.
 func New(opts ...Option) *Cron {
 	c := &Cron{
-		entries:   nil,
+		entries:   newEntryTable(0),
 		chain:     NewChain(),
-		add:       make(chan *Entry),
 		stop:      make(chan struct{}),
+		poke:      make(chan struct{}, 1),
 		snapshot:  make(chan chan []Entry),
-		remove:    make(chan EntryID),
 		running:   false,
-		runningMu: sync.Mutex{},
+		runningMu: sync.RWMutex{},
 		logger:    DefaultLogger,
-		location:  time.Local,
 		parser:    standardParser,
+		clock:     realClock{},
 	}
+	c.location.Store(time.Local)
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -135,80 +260,210 @@ type FuncJob func()
 
 func (f FuncJob) Run() { f() }
 
+// FuncContextJob is a wrapper that turns a func(context.Context) error into a
+// cron.ContextJob
+type FuncContextJob func(ctx context.Context) error
+
+func (f FuncContextJob) Run() { f(context.Background()) }
+
+func (f FuncContextJob) RunContext(ctx context.Context) error { return f(ctx) }
+
 // AddFunc adds a func to the Cron to be run on the given schedule.
 // The spec is parsed using the time zone of this Cron instance as the default.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddFunc(spec string, cmd func()) (EntryID, error) {
-	return c.AddJob(spec, FuncJob(cmd))
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddContextFunc adds a func that accepts the run's context and returns an
+// error to the Cron to be run on the given schedule.
+func (c *Cron) AddContextFunc(spec string, cmd func(ctx context.Context) error, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncContextJob(cmd), opts...)
+}
+
+// AddScheduledFunc adds a func that is given the time each run was scheduled
+// for to the Cron to be run on the given schedule. A run that starts late,
+// such as one deferred by a blackout, still gets the time it was meant for.
+func (c *Cron) AddScheduledFunc(spec string, cmd func(scheduled time.Time) error, opts ...EntryOption) (EntryID, error) {
+	return c.AddContextFunc(spec, func(ctx context.Context) error {
+		scheduled, ok := ScheduledTimeFromContext(ctx)
+		if !ok {
+			scheduled = c.now()
+		}
+		return cmd(scheduled)
+	}, opts...)
 }
 
 // AddJob adds a Job to the Cron to be run on the given schedule.
-// The spec is parsed using the time zone of this Cron instance as the default.
+// The spec is parsed using the time zone of this Cron instance as the default,
+// and the entry's name, if WithName gives it one, as the seed of its H.
 // An opaque ID is returned that can be used to later remove it.
-func (c *Cron) AddJob(spec string, cmd Job) (EntryID, error) {
-	schedule, err := c.parser.Parse(spec)
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := parseEntry(c.parser, spec, "", opts)
 	if err != nil {
 		return 0, err
 	}
-	return c.Schedule(schedule, cmd), nil
+	opts = append([]EntryOption{func(e *Entry) { e.Spec = spec }}, opts...)
+	return c.Schedule(schedule, cmd, opts...), nil
 }
 
 // Schedule adds a Job to the Cron to be run on the given schedule.
 // The job is wrapped with the configured Chain.
-func (c *Cron) Schedule(schedule Schedule, cmd Job) EntryID {
-	c.runningMu.Lock()
-	defer c.runningMu.Unlock()
-	c.nextID++
-	entry := &Entry{
-		ID:         c.nextID,
-		Schedule:   schedule,
-		WrappedJob: c.chain.Then(cmd),
-		Job:        cmd,
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	entry := Entry{Schedule: schedule, Job: cmd}
+	for _, opt := range opts {
+		opt(&entry)
 	}
-	if !c.running {
-		c.entries = append(c.entries, entry)
-	} else {
-		c.add <- entry
+	return c.ScheduleEntry(entry)
+}
+
+// ScheduleEntry adds a copy of the given entry, such as one built with
+// NewEntry, to the Cron. Its ID and activation times are assigned by the Cron,
+// and its WrappedJob, or Job if that is nil, is wrapped with the configured
+// Chain.
+func (c *Cron) ScheduleEntry(e Entry) EntryID {
+	entry := &e
+	entry.ID = c.entries.newID()
+	entry.Next, entry.Prev = time.Time{}, time.Time{}
+	if entry.WrappedJob == nil {
+		entry.WrappedJob = entry.Job
+	}
+	entry.WrappedJob = c.chain.Then(entry.WrappedJob)
+	c.runningMu.RLock()
+	defer c.runningMu.RUnlock()
+	if c.running {
+		now := c.now()
+		c.restoreCheckpoint(entry)
+		entry.Next = firstNext(entry, now)
+		c.logger.Info("added", "now", now, "entry", entry.ID, "next", entry.Next)
+		c.emit(Event{Type: EventScheduled, Entry: entry.ID, Name: entry.Name, Scheduled: entry.Next, Time: now})
+	}
+	next := entry.Next // the scheduler owns the entry once inserted
+	c.entries.insert(entry)
+	if c.running && c.entries.sooner(next) {
+		// Wake the scheduler to sleep until the new entry instead.
+		select {
+		case c.poke <- struct{}{}:
+		default:
+		}
 	}
 	return entry.ID
 }
 
-// Entries returns a snapshot of the cron entries.
+// Entries returns a snapshot of the cron entries. It reads the copy published
+// after each change, so it never waits for the scheduler.
 func (c *Cron) Entries() []Entry {
+	return c.entries.snapshot()
+}
+
+// Location gets the time zone location
+func (c *Cron) Location() *time.Location {
+	return c.location.Load().(*time.Location)
+}
+
+// SetLocation changes the time zone of the Cron, for services that learn
+// theirs after startup. If the Cron is running, the next activation times of
+// entries whose schedules use its time zone are recomputed in the new one at
+// once, keeping the start delay of those that have yet to run; entries with
+// their own time zone, set with CRON_TZ, and "@every" entries are left as
+// they are.
+func (c *Cron) SetLocation(loc *time.Location) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
-	if c.running {
-		replyChan := make(chan []Entry, 1)
-		c.snapshot <- replyChan
-		return <-replyChan
+	c.location.Store(loc)
+	c.logger.Info("location", "location", loc)
+	if !c.running {
+		return
+	}
+	now := c.now()
+	c.entries.update(func(entry *Entry) {
+		if !usesLocation(entry.Schedule) {
+			return
+		}
+		entry.Next = rescheduledNext(entry, now)
+		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+		c.emit(Event{Type: EventScheduled, Entry: entry.ID, Name: entry.Name, Scheduled: entry.Next, Time: now})
+	})
+	// Wake the scheduler to sleep until the new earliest time.
+	select {
+	case c.poke <- struct{}{}:
+	default:
 	}
-	return c.entrySnapshot()
 }
 
-// Location gets the time zone location
-func (c *Cron) Location() *time.Location {
-	return c.location
+// usesLocation reports whether the schedule activates at times that depend
+// on the time zone of the times it is given.
+func usesLocation(s Schedule) bool {
+	switch s := s.(type) {
+	case *SpecSchedule:
+		return s.Location == time.Local
+	case ConstantDelaySchedule:
+		return false
+	case *CalendarDelaySchedule:
+		return false
+	case AtSchedule:
+		return false
+	case OffsetSchedule:
+		return usesLocation(s.Schedule)
+	case MissingDaySchedule:
+		return usesLocation(s.Schedule)
+	case YearSchedule:
+		return usesLocation(s.Schedule)
+	case *NthSchedule:
+		return usesLocation(s.Schedule)
+	}
+	return true
 }
 
 // Entry returns a snapshot of the given entry, or nil if it couldn't be found.
 func (c *Cron) Entry(id EntryID) Entry {
-	for _, entry := range c.Entries() {
-		if id == entry.ID {
-			return entry
-		}
-	}
-	return Entry{}
+	entry, _ := c.entries.get(id)
+	return entry
 }
 
 // Remove an entry from being run in the future.
 func (c *Cron) Remove(id EntryID) {
-	c.runningMu.Lock()
-	defer c.runningMu.Unlock()
-	if c.running {
-		c.remove <- id
-	} else {
-		c.removeEntry(id)
+	if c.entries.remove(id) {
+		c.logger.Info("removed", "entry", id)
 	}
+	c.stats.remove(id)
+	c.budgets.remove(id)
+	c.backoffs.remove(id)
+	c.threads.remove(id)
+}
+
+var (
+	// ErrNoEntry is returned by Trigger for entries that do not exist.
+	ErrNoEntry = errors.New("cron: no such entry")
+
+	// ErrNotFiring is returned by Trigger when the Cron may not fire jobs,
+	// because it is not the leader of an Elector or another process holds
+	// its singleton lock.
+	ErrNotFiring = errors.New("cron: not firing jobs")
+)
+
+// Trigger starts a run of the entry now, outside its schedule. The run is
+// scheduled for now, and otherwise goes as the entry's scheduled runs do,
+// through the Dispatcher if there is one. The entry's next scheduled run is
+// unaffected. It returns ErrNoEntry if the entry does not exist, and
+// ErrNotFiring if the Cron may not fire jobs, as its scheduled runs would be
+// skipped then. A stopped Cron does not take its singleton lock, and so
+// refuses to trigger entries while it is configured with one.
+func (c *Cron) Trigger(id EntryID) error {
+	e, ok := c.entries.get(id)
+	if !ok {
+		return ErrNoEntry
+	}
+	c.runningMu.RLock()
+	fire, reason := c.mayFire(c.running)
+	c.runningMu.RUnlock()
+	if !fire {
+		c.logger.Info("skip", "entry", id, "reason", reason)
+		return ErrNotFiring
+	}
+	c.logger.Info("trigger", "entry", id)
+	c.startJob(&e, c.now())
+	return nil
 }
 
 // Start the cron scheduler in its own goroutine, or no-op if already started.
@@ -219,7 +474,10 @@ func (c *Cron) Start() {
 		return
 	}
 	c.running = true
-	go c.run()
+	go c.run(c.startup())
+	if c.systemd != nil {
+		c.sdStarted()
+	}
 }
 
 // Run the cron scheduler, or no-op if already running.
@@ -230,73 +488,63 @@ func (c *Cron) Run() {
 		return
 	}
 	c.running = true
+	now := c.startup()
+	if c.systemd != nil {
+		c.sdStarted()
+	}
 	c.runningMu.Unlock()
-	c.run()
+	c.run(now)
 }
 
 // run the scheduler.. this is private just due to the need to synchronize
 // access to the 'running' state variable.
-func (c *Cron) run() {
-	c.logger.Info("start")
-
-	// Figure out the next activation times for each entry.
-	now := c.now()
-	for _, entry := range c.entries {
-		entry.Next = entry.Schedule.Next(now)
-		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+func (c *Cron) run(now time.Time) {
+	if c.wal != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go c.watchWAL(stop)
 	}
-
+	var timer Timer
 	for {
-		// Determine the next entry to run.
-		sort.Sort(byTime(c.entries))
-
-		var timer *time.Timer
-		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
-			// If there are no entries yet, just sleep - it still handles new entries
-			// and stop requests.
-			timer = time.NewTimer(100000 * time.Hour)
+		// Determine the next entry to run. If there are no entries yet, just
+		// sleep - it still handles new entries and stop requests.
+		d := 100000 * time.Hour
+		next, ok := c.entries.earliest()
+		if ok {
+			d = next.Sub(now) - c.lead
+		}
+		if timer == nil {
+			timer = c.clock.NewTimer(d)
 		} else {
-			timer = time.NewTimer(c.entries[0].Next.Sub(now))
+			// The timer has either fired and been received, or been stopped.
+			timer.Reset(d)
 		}
 
 		for {
 			select {
-			case now = <-timer.C:
-				now = now.In(c.location)
-				c.logger.Info("wake", "now", now)
-
-				// Run every entry whose next time was less than now
-				for _, e := range c.entries {
-					if e.Next.After(now) || e.Next.IsZero() {
-						break
-					}
-					c.startJob(e.WrappedJob)
-					e.Prev = e.Next
-					e.Next = e.Schedule.Next(now)
-					c.logger.Info("run", "now", now, "entry", e.ID, "next", e.Next)
+			case now = <-timer.C():
+				now = now.In(c.Location())
+				if c.lead > 0 && ok {
+					now = c.spinUntil(next, now)
 				}
+				c.wake(now)
 
-			case newEntry := <-c.add:
-				timer.Stop()
+			case <-c.poke:
+				// An entry was added that is due before the timer fires.
+				stopTimer(timer)
 				now = c.now()
-				newEntry.Next = newEntry.Schedule.Next(now)
-				c.entries = append(c.entries, newEntry)
-				c.logger.Info("added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
 
 			case replyChan := <-c.snapshot:
-				replyChan <- c.entrySnapshot()
+				replyChan <- c.Entries()
 				continue
 
 			case <-c.stop:
 				timer.Stop()
+				if c.singleton != nil {
+					c.singleton.unlock()
+				}
 				c.logger.Info("stop")
 				return
-
-			case id := <-c.remove:
-				timer.Stop()
-				now = c.now()
-				c.removeEntry(id)
-				c.logger.Info("removed", "entry", id)
 			}
 
 			break
@@ -304,18 +552,163 @@ func (c *Cron) run() {
 	}
 }
 
-// startJob runs the given job in a new goroutine.
-func (c *Cron) startJob(j Job) {
+// startJob runs the entry's job in a new goroutine, or hands it to the
+// configured Dispatcher.
+func (c *Cron) startJob(e *Entry, scheduled time.Time) {
+	if c.dispatcher != nil {
+		c.dispatch(e, scheduled)
+		return
+	}
+	entry := *e
 	c.jobWaiter.Add(1)
+	c.inflight.add(entry.ID, 1)
 	go func() {
 		defer c.jobWaiter.Done()
-		j.Run()
+		defer c.inflight.add(entry.ID, -1)
+		c.runEntry(entry, scheduled)
 	}()
 }
 
+// runEntry runs the entry's wrapped job, emitting events for its start and
+// completion.
+func (c *Cron) runEntry(e Entry, scheduled time.Time) {
+	c.runEntryID(e, scheduled, newRunID())
+}
+
+// runEntryID is runEntry with the given run ID. It returns the job's error,
+// or why the job was not run.
+func (c *Cron) runEntryID(e Entry, scheduled time.Time, id string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ri := &runInfo{id: id, entry: e, scheduled: scheduled, cancel: cancel, cron: c}
+	ctx = context.WithValue(ctx, runKey, ri)
+	logged := c.logIntent(ri)
+	if e.Sampler != nil && !e.Sampler.Sample() {
+		c.logger.Info("skip", "entry", e.ID, "run", ri.id, "reason", "not sampled")
+		if logged {
+			c.logDone(ri)
+		}
+		return nil
+	}
+	if e.FailureBackoff > 0 && c.backingOff(ri) {
+		if logged {
+			c.logDone(ri)
+		}
+		return nil
+	}
+	if e.DailyBudget > 0 && c.overBudget(ri) {
+		if logged {
+			c.logDone(ri)
+		}
+		return nil
+	}
+	if c.dedup != nil && !c.claim(ctx, ri) {
+		if logged {
+			c.logDone(ri)
+		}
+		return nil
+	}
+	if l := c.limiterFor(e); l != nil {
+		if err := l.acquire(ctx, ri); err != nil {
+			c.logger.Info("abandoned", "entry", e.ID, "run", ri.id, "reason", err)
+			if logged {
+				c.logDone(ri)
+			}
+			return err
+		}
+		defer l.release()
+	}
+	if c.fencing != nil {
+		token, err := c.nextFencingToken(ctx)
+		if err != nil {
+			c.logger.Error(err, "fencing token", "entry", e.ID, "run", ri.id)
+			if logged {
+				c.logDone(ri)
+			}
+			return err
+		}
+		ri.token = token
+	}
+	start := c.now()
+	ri.start = start
+	c.stats.addStart(e.ID, scheduled, start)
+	c.active.add(ri)
+	defer c.active.remove(ri)
+	c.emit(Event{Type: EventStarted, Entry: e.ID, Name: e.Name, RunID: ri.id, Scheduled: scheduled, Time: start})
+	done := make(chan struct{})
+	if e.HeartbeatTimeout > 0 {
+		ri.beat(start)
+		go c.watchHeartbeat(ri, c.clock.NewTimer(e.HeartbeatTimeout/4), done)
+	}
+	if limit, ok := c.slowLimit(e.ID); ok {
+		stop := afterFunc(c.clock, limit, func() { c.markStuck(ri, limit) })
+		defer stop()
+	}
+	var err error
+	if e.LockOSThread {
+		err = c.threads.run(e.ID, func() error { return RunJob(ctx, e.WrappedJob) })
+	} else {
+		err = RunJob(ctx, e.WrappedJob)
+	}
+	close(done)
+	if logged {
+		c.logDone(ri)
+	}
+	end := c.now()
+	c.stats.add(e.ID, end.Sub(start), err)
+	if c.overrun > 0 {
+		c.checkOverrun(e, scheduled, ri.id)
+	}
+	if e.DailyBudget > 0 {
+		c.budgets.add(e.ID, start, end)
+	}
+	if e.FailureBackoff > 0 {
+		c.backoffs.record(e, end, err)
+	}
+	c.reportUsage(ri, end.Sub(start))
+	if err == nil {
+		c.saveCheckpoint(ri)
+	}
+	ev := Event{Type: EventFinished, Entry: e.ID, Name: e.Name, RunID: ri.id, Scheduled: scheduled, Time: end, Duration: end.Sub(start)}
+	if err != nil {
+		ev.Type, ev.Err = EventFailed, err
+	}
+	c.emit(ev)
+	return err
+}
+
+// mayFire reports whether this Cron may fire jobs now, or why not. It takes
+// the singleton lock if lock is set; otherwise it only checks that the lock is
+// held, since only the scheduler releases it.
+func (c *Cron) mayFire(lock bool) (bool, string) {
+	switch {
+	case !lock && c.singleton != nil && !c.singleton.held():
+		return false, "singleton lock not held"
+	case lock && !c.lockSingleton():
+		return false, "singleton lock held elsewhere"
+	}
+	if c.elector != nil && !c.elector.IsLeader() {
+		return false, "not the leader"
+	}
+	return true, ""
+}
+
+// lockSingleton reports whether this Cron may fire jobs, taking the singleton
+// lock first if one is configured.
+func (c *Cron) lockSingleton() bool {
+	if c.singleton == nil {
+		return true
+	}
+	ok, err := c.singleton.tryLock()
+	if err != nil {
+		c.logger.Error(err, "singleton lock", "path", c.singleton.path)
+	}
+	return ok
+}
+
 // now returns current time in c location
 func (c *Cron) now() time.Time {
-	return time.Now().In(c.location)
+	return c.clock.Now().In(c.Location())
 }
 
 // Stop stops the cron scheduler if it is running; otherwise it does nothing.
@@ -326,6 +719,9 @@ func (c *Cron) Stop() context.Context {
 	if c.running {
 		c.stop <- struct{}{}
 		c.running = false
+		if c.systemd != nil {
+			c.sdStopping()
+		}
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -335,21 +731,59 @@ func (c *Cron) Stop() context.Context {
 	return ctx
 }
 
-// entrySnapshot returns a copy of the current cron entry list.
-func (c *Cron) entrySnapshot() []Entry {
-	var entries = make([]Entry, len(c.entries))
-	for i, e := range c.entries {
-		entries[i] = *e
+// wake fires every entry whose next time is not after now, and computes its
+// next activation time.
+func (c *Cron) wake(now time.Time) {
+	c.logger.Info("wake", "now", now)
+	fire, reason := c.mayFire(true)
+	policy, until, blackout := c.blackedOut(now)
+
+	// Run every entry whose next time was less than now. The entries stay
+	// locked until they are started, so that none starts after Remove.
+	due := c.entries.lockDue(now, c.firingOrder())
+	for _, e := range due {
+		switch {
+		case !fire:
+			c.logger.Info("skip", "entry", e.ID, "reason", reason)
+		case !c.owns(e):
+			c.logger.Info("skip", "entry", e.ID, "reason", "owned by another instance")
+		case blackout && policy == BlackoutDefer:
+			c.deferJob(e, e.Next, until)
+		case blackout:
+			c.logger.Info("skip", "entry", e.ID, "reason", "blackout")
+		default:
+			c.checkSlot(e)
+			c.startJob(e, e.Next)
+		}
+		e.Prev = e.Next
+		e.Next = e.Schedule.Next(now)
+	}
+	c.entries.unlockDue()
+	for _, e := range due {
+		c.logger.Info("run", "now", now, "entry", e.ID, "next", e.Next)
+		c.emit(Event{Type: EventScheduled, Entry: e.ID, Name: e.Name, Scheduled: e.Next, Time: now})
 	}
-	return entries
 }
 
-func (c *Cron) removeEntry(id EntryID) {
-	var entries []*Entry
-	for _, e := range c.entries {
-		if e.ID != id {
-			entries = append(entries, e)
-		}
+// startup computes the first activation times of the entries and publishes
+// them, before the scheduler starts running. It returns the current time.
+func (c *Cron) startup() time.Time {
+	c.logger.Info("start")
+
+	// Figure out the next activation times for each entry.
+	now := c.now()
+	c.entries.update(func(entry *Entry) {
+		c.restoreCheckpoint(entry)
+		entry.Next = firstNext(entry, now)
+		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+		c.emit(Event{Type: EventScheduled, Entry: entry.ID, Name: entry.Name, Scheduled: entry.Next, Time: now})
+	})
+	if c.restored != nil {
+		c.restore(c.restored, now)
+		c.restored = nil
+	}
+	if c.wal != nil {
+		c.replayWAL()
 	}
-	c.entries = entries
+	return now
 }
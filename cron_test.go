@@ -700,3 +700,143 @@ func stop(cron *Cron) chan bool {
 func newWithSeconds() *Cron {
 	return New(WithParser(secondParser), WithChain())
 }
+
+// Entries should not wait for a busy scheduler.
+func TestTrigger(t *testing.T) {
+	c := New()
+	ran := make(chan struct{}, 1)
+	id, _ := c.AddFunc("@yearly", func() { ran <- struct{}{} })
+	if err := c.Trigger(id); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(OneSecond):
+		t.Error("expected the entry to run")
+	}
+	if err := c.Trigger(id + 1); err != ErrNoEntry {
+		t.Errorf("expected no entry to trigger, got %v", err)
+	}
+}
+
+func TestSetLocation(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	cron := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	daily, _ := cron.AddFunc("0 9 * * *", func() {})
+	utc, _ := cron.AddFunc("CRON_TZ=UTC 0 9 * * *", func() {})
+	every, _ := cron.AddFunc("@every 1h", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	cron.SetLocation(tokyo)
+	if cron.Location() != tokyo {
+		t.Errorf("expected %v, got %v", tokyo, cron.Location())
+	}
+	// It is 17:30 in Tokyo, so the next 09:00 there is tomorrow.
+	if next := cron.Entry(daily).Next; !next.Equal(time.Date(2024, 3, 2, 9, 0, 0, 0, tokyo)) || next.Location() != tokyo {
+		t.Errorf("expected the entry rescheduled in Tokyo, got %v", next)
+	}
+	if next := cron.Entry(utc).Next; !next.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the entry with its own time zone left alone, got %v", next)
+	}
+	if next := cron.Entry(every).Next; !next.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the interval entry left alone, got %v", next)
+	}
+}
+
+func TestEntriesDoesNotBlock(t *testing.T) {
+	block := make(chan struct{})
+	var blocking int32
+	cron := New(WithEventListener(func(e Event) {
+		if e.Type == EventScheduled && atomic.LoadInt32(&blocking) == 1 {
+			<-block
+		}
+	}))
+	cron.AddFunc("@every 1h", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	atomic.StoreInt32(&blocking, 1)
+	added := make(chan struct{})
+	go func() {
+		cron.AddFunc("@every 1h", func() {})
+		close(added)
+	}()
+
+	done := make(chan []Entry)
+	go func() { done <- cron.Entries() }()
+	select {
+	case entries := <-done:
+		if len(entries) != 1 {
+			t.Errorf("expected the published entry, got %d", len(entries))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Entries not to wait for the scheduler")
+	}
+
+	atomic.StoreInt32(&blocking, 0)
+	close(block)
+	<-added
+	if n := len(cron.Entries()); n != 2 {
+		t.Errorf("expected the added entry once AddFunc returns, got %d", n)
+	}
+}
+
+// newBenchCron returns a Cron with n entries due every second, firing through
+// a dispatcher that does nothing.
+func newBenchCron(n int) *Cron {
+	c := New(WithDispatcher(dispatchFunc(func(Dispatch) error { return nil })), WithLogger(DiscardLogger))
+	for i := 0; i < n; i++ {
+		c.Schedule(Every(time.Duration(i%60+1)*time.Second), FuncJob(func() {}))
+	}
+	return c
+}
+
+func BenchmarkWake(b *testing.B) {
+	c := newBenchCron(1000)
+	now := c.startup()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		now = now.Add(time.Second)
+		c.wake(now)
+	}
+	b.StopTimer()
+	c.jobWaiter.Wait()
+}
+
+func BenchmarkEntries(b *testing.B) {
+	c := newBenchCron(1000)
+	c.Start()
+	defer c.Stop()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Entries()
+	}
+}
+
+func BenchmarkAddRemove(b *testing.B) {
+	c := newBenchCron(1000)
+	c.Start()
+	defer c.Stop()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Remove(c.Schedule(Every(time.Hour), FuncJob(func() {})))
+	}
+}
+
+func BenchmarkAddRemoveParallel(b *testing.B) {
+	c := newBenchCron(1000)
+	c.Start()
+	defer c.Stop()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Remove(c.Schedule(Every(time.Hour), FuncJob(func() {})))
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// Package cronclient is a Go client of the admin API served by
+// cronhttp.Admin, for control planes and scripts that manage remote
+// schedulers. Its methods are the operations of cronhttp.OpenAPI, named
+// after their operation IDs:
+//
+//	client := cronclient.New("http://scheduler:8080/admin", os.Getenv("CRON_TOKEN"))
+//	entries, err := client.ListEntries(ctx)
+//	...
+//	err = client.TriggerEntry(ctx, entries[0].Entry)
+package cronclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Client calls the admin API of a scheduler.
+type Client struct {
+	// BaseURL is the URL the API is served under, such as
+	// "http://scheduler:8080/admin".
+	BaseURL string
+
+	// Token is the bearer token sent with each request.
+	Token string
+
+	// HTTP is the client requests are made with, or http.DefaultClient if
+	// nil.
+	HTTP *http.Client
+}
+
+// New returns a Client of the API at baseURL, authenticating with token.
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token}
+}
+
+// Error is returned for responses with an unexpected status, such as 403 if
+// the token lacks the operation's scope, or 404 for an unknown entry or run.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("cronclient: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is an Error for an unknown entry or run.
+func IsNotFound(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// ListEntries returns the scheduler's entries with their run statistics. It
+// requires the read scope.
+func (c *Client) ListEntries(ctx context.Context) ([]cron.EntryStats, error) {
+	var entries []cron.EntryStats
+	err := c.do(ctx, http.MethodGet, "/entries", http.StatusOK, &entries)
+	return entries, err
+}
+
+// RemoveEntry removes the entry. It requires the mutate scope.
+func (c *Client) RemoveEntry(ctx context.Context, id cron.EntryID) error {
+	return c.do(ctx, http.MethodDelete, "/entries/"+strconv.Itoa(int(id)), http.StatusNoContent, nil)
+}
+
+// TriggerEntry starts a run of the entry now, outside its schedule. It
+// requires the trigger scope, and fails with status 409 if the scheduler may
+// not fire jobs, e.g. because it is not the leader.
+func (c *Client) TriggerEntry(ctx context.Context, id cron.EntryID) error {
+	return c.do(ctx, http.MethodPost, "/entries/"+strconv.Itoa(int(id))+"/trigger", http.StatusNoContent, nil)
+}
+
+// CancelRun cancels the run. It requires the mutate scope.
+func (c *Client) CancelRun(ctx context.Context, runID string) error {
+	return c.do(ctx, http.MethodPost, "/runs/"+url.PathEscape(runID)+"/cancel", http.StatusNoContent, nil)
+}
+
+// do makes a request to the path, expecting the status, and decodes the JSON
+// response into v if it is not nil.
+func (c *Client) do(ctx context.Context, method, path string, status int, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,76 @@
+package cronclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+	"github.com/robfig/cron/v3/cronhttp"
+)
+
+func TestClient(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddFunc("@yearly", func() {}, cron.WithName("report"))
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin", cronhttp.Admin(c, cronhttp.Tokens{
+		"reader":   {cronhttp.ScopeRead},
+		"operator": {cronhttp.ScopeRead, cronhttp.ScopeTrigger, cronhttp.ScopeMutate},
+	})))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	ctx := context.Background()
+
+	entries, err := New(srv.URL+"/admin", "reader").ListEntries(ctx)
+	if err != nil || len(entries) != 1 || entries[0].Name != "report" {
+		t.Fatalf("unexpected entries %+v, %v", entries, err)
+	}
+	err = New(srv.URL+"/admin", "reader").RemoveEntry(ctx, id)
+	if e, ok := err.(*Error); !ok || e.StatusCode != http.StatusForbidden {
+		t.Errorf("expected a 403 Error, got %v", err)
+	}
+
+	operator := New(srv.URL+"/admin/", "operator")
+	if err := operator.TriggerEntry(ctx, id); err != nil {
+		t.Error(err)
+	}
+	if err := operator.CancelRun(ctx, "no such run"); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if err := operator.RemoveEntry(ctx, id); err != nil {
+		t.Error(err)
+	}
+	if err := operator.RemoveEntry(ctx, id); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+// Every operation of the OpenAPI description has a method.
+func TestClientCoversOpenAPI(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(cronhttp.OpenAPI, &spec); err != nil {
+		t.Fatal(err)
+	}
+	client := reflect.TypeOf(&Client{})
+	n := 0
+	for path, ops := range spec.Paths {
+		for method, op := range ops {
+			n++
+			name := strings.ToUpper(op.OperationID[:1]) + op.OperationID[1:]
+			if _, ok := client.MethodByName(name); !ok {
+				t.Errorf("%s %s: no method %s", strings.ToUpper(method), path, name)
+			}
+		}
+	}
+	if n != 4 {
+		t.Errorf("expected 4 operations, found %d", n)
+	}
+}
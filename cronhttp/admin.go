@@ -0,0 +1,92 @@
+package cronhttp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Admin returns a handler for managing c remotely, with each request
+// authorized by auth for the scope its route requires. Mount it with
+// http.StripPrefix to serve it under a path. It serves:
+//
+//	GET    /entries               read     the entries, as []cron.EntryStats
+//	POST   /entries/{id}/trigger  trigger  start a run of the entry now
+//	DELETE /entries/{id}          mutate   remove the entry
+//	POST   /runs/{run}/cancel     mutate   cancel the run
+//
+// Successful changes respond 204, and requests about unknown entries or runs
+// respond 404. Triggers respond 409 if c may not fire jobs, e.g. because it
+// is not the leader. OpenAPI describes the API, and the cronclient package is its
+// Go client.
+func Admin(c *cron.Cron, auth Authenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) == 1 && parts[0] == "entries":
+			if allow(w, r, http.MethodGet) && authorize(w, r, auth, ScopeRead) {
+				writeJSON(w, http.StatusOK, c.Stats())
+			}
+
+		case len(parts) == 3 && parts[0] == "entries" && parts[2] == "trigger":
+			if allow(w, r, http.MethodPost) && authorize(w, r, auth, ScopeTrigger) {
+				if id, ok := entryID(w, parts[1]); ok {
+					switch err := c.Trigger(id); err {
+					case nil, cron.ErrNoEntry:
+						found(w, err == nil)
+					default:
+						http.Error(w, err.Error(), http.StatusConflict)
+					}
+				}
+			}
+
+		case len(parts) == 2 && parts[0] == "entries":
+			if allow(w, r, http.MethodDelete) && authorize(w, r, auth, ScopeMutate) {
+				if id, ok := entryID(w, parts[1]); ok {
+					exists := c.Entry(id).Valid()
+					c.Remove(id)
+					found(w, exists)
+				}
+			}
+
+		case len(parts) == 3 && parts[0] == "runs" && parts[2] == "cancel":
+			if allow(w, r, http.MethodPost) && authorize(w, r, auth, ScopeMutate) {
+				found(w, c.CancelRun(parts[1]))
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// allow reports whether the request has the method, responding 405 if not.
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+// entryID parses an entry ID from the path, responding 404 if it is not one.
+func entryID(w http.ResponseWriter, s string) (cron.EntryID, bool) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		http.Error(w, "no such entry: "+s, http.StatusNotFound)
+		return 0, false
+	}
+	return cron.EntryID(id), true
+}
+
+// found responds 204 if ok, and 404 otherwise.
+func found(w http.ResponseWriter, ok bool) {
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,119 @@
+package cronhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var testTokens = Tokens{
+	"reader":   {ScopeRead},
+	"operator": {ScopeRead, ScopeTrigger, ScopeMutate},
+}
+
+func request(h http.Handler, method, path, token string) int {
+	r := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Code
+}
+
+func TestAdmin(t *testing.T) {
+	c := cron.New()
+	ran := make(chan struct{}, 1)
+	id, _ := c.AddFunc("@yearly", func() { ran <- struct{}{} })
+	h := Admin(c, testTokens)
+	entry := "/entries/" + itoa(id)
+
+	tests := []struct {
+		method, path, token string
+		code                int
+	}{
+		{http.MethodGet, "/entries", "", http.StatusUnauthorized},
+		{http.MethodGet, "/entries", "wrong", http.StatusUnauthorized},
+		{http.MethodGet, "/entries", "reader", http.StatusOK},
+		{http.MethodPost, "/entries", "reader", http.StatusMethodNotAllowed},
+		{http.MethodPost, entry + "/trigger", "reader", http.StatusForbidden},
+		{http.MethodDelete, entry, "reader", http.StatusForbidden},
+		{http.MethodPost, entry + "/trigger", "operator", http.StatusNoContent},
+		{http.MethodPost, "/entries/99/trigger", "operator", http.StatusNotFound},
+		{http.MethodPost, "/runs/nope/cancel", "operator", http.StatusNotFound},
+		{http.MethodDelete, entry, "operator", http.StatusNoContent},
+		{http.MethodDelete, entry, "operator", http.StatusNotFound},
+		{http.MethodGet, "/other", "operator", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		if code := request(h, test.method, test.path, test.token); code != test.code {
+			t.Errorf("%s %s as %q: expected %d, got %d", test.method, test.path, test.token, test.code, code)
+		}
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("expected the triggered entry to run")
+	}
+	if len(c.Entries()) != 0 {
+		t.Error("expected the entry to be removed")
+	}
+}
+
+// Triggers are refused with 409 by a Cron that is not the leader.
+func TestAdminTriggerFollower(t *testing.T) {
+	c := cron.New(cron.WithElector(follower{}))
+	id, _ := c.AddFunc("@yearly", func() {})
+	if code := request(Admin(c, testTokens), http.MethodPost, "/entries/"+itoa(id)+"/trigger", "operator"); code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", code)
+	}
+}
+
+type follower struct{}
+
+func (follower) IsLeader() bool { return false }
+
+func TestRequireScope(t *testing.T) {
+	h := RequireScope(testTokens, ScopeMutate, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if code := request(h, http.MethodGet, "/", "reader"); code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", code)
+	}
+	if code := request(h, http.MethodGet, "/", "operator"); code != http.StatusOK {
+		t.Errorf("expected 200, got %d", code)
+	}
+}
+
+func itoa(id cron.EntryID) string {
+	return strconv.Itoa(int(id))
+}
+
+// The published schema describes every field the server returns.
+func TestOpenAPIEntryStats(t *testing.T) {
+	var doc struct {
+		Components struct {
+			Schemas struct {
+				EntryStats struct {
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"EntryStats"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(OpenAPI, &doc); err != nil {
+		t.Fatal(err)
+	}
+	props := doc.Components.Schemas.EntryStats.Properties
+	typ := reflect.TypeOf(cron.EntryStats{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := props[name]; !ok {
+			t.Errorf("EntryStats field %s is missing from the schema", name)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cronhttp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Scope is a permission granted to the callers of the management handlers.
+type Scope string
+
+const (
+	// ScopeRead allows inspecting the Cron, such as its entries.
+	ScopeRead Scope = "read"
+
+	// ScopeTrigger allows running entries outside their schedules.
+	ScopeTrigger Scope = "trigger"
+
+	// ScopeMutate allows changing the Cron, such as removing entries and
+	// cancelling runs.
+	ScopeMutate Scope = "mutate"
+)
+
+// Authenticator identifies the caller of a request and returns the scopes it
+// is granted, or false if the request is not authenticated.
+type Authenticator interface {
+	Scopes(r *http.Request) ([]Scope, bool)
+}
+
+// Tokens is an Authenticator of bearer tokens, given in the Authorization
+// header as "Bearer <token>", mapped to the scopes they grant. Scopes do not
+// imply each other: a token that may mutate but not read is granted only
+// ScopeMutate.
+//
+//	auth := cronhttp.Tokens{
+//		os.Getenv("DASHBOARD_TOKEN"): {cronhttp.ScopeRead},
+//		os.Getenv("OPS_TOKEN"):       {cronhttp.ScopeRead, cronhttp.ScopeTrigger, cronhttp.ScopeMutate},
+//	}
+type Tokens map[string][]Scope
+
+// Scopes returns the scopes of the request's bearer token.
+func (t Tokens) Scopes(r *http.Request) ([]Scope, bool) {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return nil, false
+	}
+	token := []byte(h[len(prefix):])
+	var scopes []Scope
+	found := false
+	// Compare with every token in constant time, so that timing does not
+	// reveal how much of one matched.
+	for candidate, s := range t {
+		if candidate != "" && subtle.ConstantTimeCompare([]byte(candidate), token) == 1 {
+			scopes, found = s, true
+		}
+	}
+	return scopes, found
+}
+
+// RequireScope returns a handler that serves requests with h only if auth
+// grants them scope, and otherwise responds 401 if they are not
+// authenticated, or 403. It protects the other handlers of this package:
+//
+//	http.Handle("/stats", cronhttp.RequireScope(auth, cronhttp.ScopeRead, cronhttp.Stats(c)))
+func RequireScope(auth Authenticator, scope Scope, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorize(w, r, auth, scope) {
+			h.ServeHTTP(w, r)
+		}
+	})
+}
+
+// authorize reports whether auth grants the request scope, responding with
+// an error if not.
+func authorize(w http.ResponseWriter, r *http.Request, auth Authenticator, scope Scope) bool {
+	scopes, ok := auth.Scopes(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="cron"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	http.Error(w, "forbidden: requires scope "+string(scope), http.StatusForbidden)
+	return false
+}
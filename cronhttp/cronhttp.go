@@ -0,0 +1,27 @@
+// Package cronhttp provides HTTP handlers for inspecting a running Cron, and
+// for validating specs without one.
+package cronhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Stats returns a handler that responds with the JSON encoding of c.Stats(),
+// for lightweight scraping by existing tooling.
+func Stats(c *cron.Cron) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, c.Stats())
+	})
+}
+
+// writeJSON writes v as an indented JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
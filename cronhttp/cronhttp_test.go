@@ -0,0 +1,29 @@
+package cronhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestStats(t *testing.T) {
+	c := cron.New()
+	c.AddFunc("@hourly", func() {}, cron.WithName("sync"))
+
+	rec := httptest.NewRecorder()
+	Stats(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response %d %v", rec.Code, rec.Header())
+	}
+	var stats []cron.EntryStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].Name != "sync" || stats[0].Spec != "@hourly" {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,27 @@
+package cronhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// healthTimeout is how long Healthz waits for the scheduling loop to answer.
+const healthTimeout = time.Second
+
+// Healthz returns a handler suitable for liveness probes, such as those of
+// Kubernetes. It responds 200 if c's scheduling loop is running and
+// responsive and is no more than maxLag behind schedule, and 503 otherwise,
+// so that a wedged scheduler is restarted. A maxLag of zero disables the lag
+// check. The body is the JSON encoding of the cron.Health.
+func Healthz(c *cron.Cron, maxLag time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := c.Health(healthTimeout)
+		status := http.StatusOK
+		if !h.Running || !h.Responsive || (maxLag > 0 && h.Lag > maxLag) {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, h)
+	})
+}
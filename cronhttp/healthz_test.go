@@ -0,0 +1,29 @@
+package cronhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestHealthz(t *testing.T) {
+	c := cron.New()
+	h := Healthz(c, time.Minute)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 before start, got %d", rec.Code)
+	}
+
+	c.Start()
+	defer c.Stop()
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 while running, got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,10 @@
+package cronhttp
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 description of the API served by Admin, in JSON,
+// for generating clients in other languages and for serving to tools. The
+// cronclient package is its Go client.
+//
+//go:embed openapi.json
+var OpenAPI []byte
@@ -0,0 +1,196 @@
+package cronhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxNext is the most times a request to /next may ask for.
+const maxNext = 100
+
+// ValidateRequest is the JSON body of requests to the handler of Validator.
+type ValidateRequest struct {
+	Spec string `json:"spec"`
+
+	// Lang is the language of the messages, such as "zh", or empty for
+	// English.
+	Lang string `json:"lang,omitempty"`
+
+	// For /next: the time zone of the times, the time to start after, and
+	// how many to return. They default to UTC, now and 5.
+	TimeZone string    `json:"tz,omitempty"`
+	After    time.Time `json:"after,omitempty"`
+	Count    int       `json:"count,omitempty"`
+}
+
+// ValidateResponse is the JSON body of responses from the handler of
+// Validator.
+type ValidateResponse struct {
+	Valid       bool         `json:"valid"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+
+	// Times are the next activation times, for /next.
+	Times []time.Time `json:"times,omitempty"`
+}
+
+// Diagnostic is an error or warning about a spec.
+type Diagnostic struct {
+	// Severity is "error" or "warning".
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+
+	// Span is the part of the spec the diagnostic is about, if it is about a
+	// part, and Field the name of the field, if it is about one.
+	Span  *Span  `json:"span,omitempty"`
+	Field string `json:"field,omitempty"`
+
+	// Suggestion is what was probably meant, for a misspelling.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Span is a range of byte offsets in a spec, from Start to End.
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Validator returns a handler that validates specs with the parser, keeping
+// no state, for backends of forms and editor plugins. Mount it with
+// http.StripPrefix to serve it under a path. It serves:
+//
+//	POST /validate  diagnostics for the spec of the ValidateRequest
+//	POST /next      diagnostics and the spec's next activation times
+//
+// Both respond with a ValidateResponse, with status 200 if the spec is
+// valid and 422 if it is not.
+//
+//	http.Handle("/cron/", http.StripPrefix("/cron", cronhttp.Validator(cron.NewParser(
+//		cron.Minute|cron.Hour|cron.Dom|cron.Month|cron.Dow|cron.Descriptor))))
+func Validator(p cron.Parser) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeRequest(w, r)
+		if !ok {
+			return
+		}
+		resp, _ := validate(p, req)
+		respond(w, resp)
+	})
+	mux.HandleFunc("/next", func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeRequest(w, r)
+		if !ok {
+			return
+		}
+		resp, schedule := validate(p, req)
+		if schedule != nil {
+			loc := time.UTC
+			if req.TimeZone != "" {
+				var err error
+				if loc, err = time.LoadLocation(req.TimeZone); err != nil {
+					http.Error(w, "unknown time zone: "+req.TimeZone, http.StatusBadRequest)
+					return
+				}
+			}
+			after := req.After
+			if after.IsZero() {
+				after = time.Now()
+			}
+			count := req.Count
+			if count <= 0 {
+				count = 5
+			}
+			if count > maxNext {
+				count = maxNext
+			}
+			t := after.In(loc)
+			for len(resp.Times) < count {
+				if t = schedule.Next(t); t.IsZero() {
+					break
+				}
+				resp.Times = append(resp.Times, t)
+			}
+		}
+		respond(w, resp)
+	})
+	return mux
+}
+
+// decodeRequest decodes the ValidateRequest of a POST, or responds with an
+// error and returns false.
+func decodeRequest(w http.ResponseWriter, r *http.Request) (ValidateRequest, bool) {
+	var req ValidateRequest
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return req, false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// validate returns the diagnostics for the spec of the request, and its
+// schedule if it is valid.
+func validate(p cron.Parser, req ValidateRequest) (ValidateResponse, cron.Schedule) {
+	resp := ValidateResponse{Diagnostics: []Diagnostic{}}
+	schedule, err := p.Parse(req.Spec)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, errorDiagnostics(p, req, err)...)
+		return resp, nil
+	}
+	resp.Valid = true
+	for _, w := range p.Lint(req.Spec) {
+		resp.Diagnostics = append(resp.Diagnostics, Diagnostic{
+			Severity: "warning",
+			Message:  w.Localize(req.Lang),
+			Field:    w.Field,
+		})
+	}
+	return resp, schedule
+}
+
+// errorDiagnostics returns a diagnostic for each run of tokens with the same
+// error, or for err as a whole if no token has one.
+func errorDiagnostics(p cron.Parser, req ValidateRequest, err error) []Diagnostic {
+	var diags []Diagnostic
+	var last error
+	for _, tok := range p.Tokenize(req.Spec) {
+		if tok.Err == nil {
+			last = nil
+			continue
+		}
+		if tok.Err == last {
+			diags[len(diags)-1].Span.End = tok.Pos + len(tok.Text)
+			continue
+		}
+		last = tok.Err
+		diags = append(diags, errorDiagnostic(tok.Err, req.Lang))
+		diags[len(diags)-1].Span = &Span{Start: tok.Pos, End: tok.Pos + len(tok.Text)}
+	}
+	if len(diags) == 0 {
+		diags = append(diags, errorDiagnostic(err, req.Lang))
+	}
+	return diags
+}
+
+func errorDiagnostic(err error, lang string) Diagnostic {
+	d := Diagnostic{Severity: "error", Message: cron.Localize(err, lang)}
+	if pe, ok := err.(*cron.ParseError); ok {
+		d.Suggestion = pe.Suggestion
+	}
+	return d
+}
+
+// respond writes the response, with status 422 if the spec is invalid.
+func respond(w http.ResponseWriter, resp ValidateResponse) {
+	status := http.StatusOK
+	if !resp.Valid {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, resp)
+}
@@ -0,0 +1,74 @@
+package cronhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var standard = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+func post(t *testing.T, h http.Handler, path, body string) (int, ValidateResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	var resp ValidateResponse
+	if rec.Code == http.StatusOK || rec.Code == http.StatusUnprocessableEntity {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func TestValidate(t *testing.T) {
+	h := Validator(standard)
+
+	code, resp := post(t, h, "/validate", `{"spec": "*/1 * * * *"}`)
+	if code != http.StatusOK || !resp.Valid || len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != "warning" {
+		t.Errorf("expected a valid spec with a warning, got %d %+v", code, resp)
+	}
+
+	code, resp = post(t, h, "/validate", `{"spec": "0 5-70 * * mno"}`)
+	if code != http.StatusUnprocessableEntity || resp.Valid || len(resp.Diagnostics) != 2 {
+		t.Fatalf("expected two errors, got %d %+v", code, resp)
+	}
+	if d := resp.Diagnostics[0]; d.Span == nil || *d.Span != (Span{2, 6}) {
+		t.Errorf("expected the range to be flagged, got %+v", d)
+	}
+	if d := resp.Diagnostics[1]; d.Span == nil || *d.Span != (Span{11, 14}) || d.Suggestion != "mon" {
+		t.Errorf("expected the name to be flagged with a suggestion, got %+v", d)
+	}
+
+	code, resp = post(t, h, "/validate", `{"spec": "TZ=UTC"}`)
+	if code != http.StatusUnprocessableEntity || resp.Valid {
+		t.Errorf("expected a spec of only a time zone rejected, got %d %+v", code, resp)
+	}
+
+	_, resp = post(t, h, "/validate", `{"spec": "", "lang": "zh"}`)
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Span != nil || resp.Diagnostics[0].Message != "表达式为空" {
+		t.Errorf("expected a translated error about the whole spec, got %+v", resp)
+	}
+}
+
+func TestValidateNext(t *testing.T) {
+	h := Validator(standard)
+	code, resp := post(t, h, "/next", `{"spec": "0 9 * * MON-FRI", "after": "2024-03-01T08:00:00Z", "count": 2, "tz": "Asia/Tokyo"}`)
+	if code != http.StatusOK || len(resp.Times) != 2 {
+		t.Fatalf("expected two times, got %d %+v", code, resp)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !resp.Times[0].Equal(want) {
+		t.Errorf("expected %v, got %v", want, resp.Times[0])
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/next", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET to be refused, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,160 @@
+package cronk8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ExportOptions configure how entries are converted to CronJobs.
+type ExportOptions struct {
+	// Namespace of the CronJobs, if set.
+	Namespace string
+
+	// Image is the container image the jobs run in.
+	Image string
+
+	// Command returns the container command for the entry. It defaults to
+	// running the Command of a *cron.ShellCommandJob with "/bin/sh -c", and
+	// reports false for other jobs.
+	Command func(e cron.Entry) ([]string, bool)
+}
+
+// ExportCronJobs converts entries into a multi-document YAML stream of
+// batch/v1 CronJob manifests, for migrating them to the cluster's scheduler.
+//
+// Entries must have been added with a spec string in the standard five-field
+// format or one of the @yearly, @monthly, @weekly, @daily and @hourly
+// descriptors, which are what CronJob supports. Five-field schedules are
+// exported as they were parsed, with H fields resolved and names as numbers;
+// the Quartz L, W and # modifiers are not supported. A CRON_TZ or TZ prefix
+// is converted to the CronJob's timeZone. CronJobs are named after the
+// entries, or "cron-<id>" for unnamed entries.
+func ExportCronJobs(entries []cron.Entry, opts ExportOptions) ([]byte, error) {
+	command := opts.Command
+	if command == nil {
+		command = shellCommand
+	}
+	var buf bytes.Buffer
+	for i, e := range entries {
+		tz, schedule, err := cronJobSchedule(e)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %v", e.ID, err)
+		}
+		args, ok := command(e)
+		if !ok {
+			return nil, fmt.Errorf("entry %d: no command for job of type %T", e.ID, e.Job)
+		}
+		name := e.Name
+		if name == "" {
+			name = fmt.Sprintf("cron-%d", e.ID)
+		}
+
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.WriteString("apiVersion: batch/v1\nkind: CronJob\nmetadata:\n")
+		fmt.Fprintf(&buf, "  name: %s\n", quote(resourceName(name)))
+		if opts.Namespace != "" {
+			fmt.Fprintf(&buf, "  namespace: %s\n", quote(opts.Namespace))
+		}
+		buf.WriteString("spec:\n")
+		fmt.Fprintf(&buf, "  schedule: %s\n", quote(schedule))
+		if tz != "" {
+			fmt.Fprintf(&buf, "  timeZone: %s\n", quote(tz))
+		}
+		buf.WriteString("  jobTemplate:\n    spec:\n      template:\n        spec:\n")
+		buf.WriteString("          restartPolicy: OnFailure\n          containers:\n")
+		buf.WriteString("          - name: job\n")
+		fmt.Fprintf(&buf, "            image: %s\n", quote(opts.Image))
+		fmt.Fprintf(&buf, "            command: %s\n", quote(args))
+	}
+	return buf.Bytes(), nil
+}
+
+// shellCommand runs a ShellCommandJob's command line in the container.
+func shellCommand(e cron.Entry) ([]string, bool) {
+	j, ok := e.Job.(*cron.ShellCommandJob)
+	if !ok {
+		return nil, false
+	}
+	return []string{"/bin/sh", "-c", j.Command}, true
+}
+
+// cronJobDescriptors are the descriptors supported by CronJob schedules.
+var cronJobDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true,
+	"@weekly": true, "@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// cronJobSchedule returns the time zone and schedule of the entry's CronJob.
+// The schedule is the entry's resolved SpecSchedule, so that hashed fields
+// are exported as the values they were resolved to, and names as numbers.
+// Schedules that CronJob cannot express, such as ones with seconds or the
+// Quartz L, W and # modifiers, are errors.
+func cronJobSchedule(e cron.Entry) (tz, schedule string, err error) {
+	if e.Spec == "" {
+		return "", "", fmt.Errorf("entry has no spec string")
+	}
+	fields := strings.Fields(e.Spec)
+	if strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=") {
+		tz, fields = fields[0][strings.Index(fields[0], "=")+1:], fields[1:]
+		if len(fields) == 0 {
+			return "", "", fmt.Errorf("no schedule after time zone: %s", e.Spec)
+		}
+	}
+	if strings.HasPrefix(fields[0], "@") {
+		schedule = strings.Join(fields, " ")
+		if !cronJobDescriptors[schedule] {
+			return "", "", fmt.Errorf("descriptor not supported by CronJob: %s", schedule)
+		}
+		return tz, schedule, nil
+	}
+	if len(fields) != 5 {
+		return "", "", fmt.Errorf("CronJob schedules need 5 fields, found %d: %s", len(fields), e.Spec)
+	}
+	spec, ok := e.Schedule.(*cron.SpecSchedule)
+	if !ok {
+		return "", "", fmt.Errorf("schedule of type %T not supported by CronJob", e.Schedule)
+	}
+	fields = strings.Fields(spec.String())
+	if strings.HasPrefix(fields[0], "TZ=") {
+		fields = fields[1:]
+	}
+	schedule = strings.Join(fields, " ")
+	if len(fields) != 5 {
+		return "", "", fmt.Errorf("CronJob schedules need 5 fields, found %d: %s", len(fields), schedule)
+	}
+	if strings.ContainsAny(schedule, "LW#") {
+		return "", "", fmt.Errorf("L, W and # modifiers not supported by CronJob: %s", e.Spec)
+	}
+	return tz, schedule, nil
+}
+
+// resourceName converts name into a valid CronJob name: at most 52
+// lowercase alphanumeric characters or '-', starting and ending with an
+// alphanumeric character.
+func resourceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	s := b.String()
+	if len(s) > 52 {
+		s = s[:52]
+	}
+	return strings.Trim(s, "-")
+}
+
+// quote encodes v as JSON, which YAML accepts as a flow scalar or sequence.
+func quote(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
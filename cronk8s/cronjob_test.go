@@ -0,0 +1,107 @@
+package cronk8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestExportCronJobs(t *testing.T) {
+	c := cron.New()
+	c.AddJob("CRON_TZ=Asia/Tokyo 30 4 * * *", cron.NewShellCommandJob(`echo "hi"`), cron.WithName("Nightly_Report"))
+	c.AddJob("@hourly", cron.NewShellCommandJob("sync"))
+
+	out, err := ExportCronJobs(c.Entries(), ExportOptions{Namespace: "batch", Image: "alpine:3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: "nightly-report"
+  namespace: "batch"
+spec:
+  schedule: "30 4 * * *"
+  timeZone: "Asia/Tokyo"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: job
+            image: "alpine:3"
+            command: ["/bin/sh","-c","echo \"hi\""]
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: "cron-2"
+  namespace: "batch"
+spec:
+  schedule: "@hourly"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: job
+            image: "alpine:3"
+            command: ["/bin/sh","-c","sync"]
+`
+	if string(out) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out)
+	}
+}
+
+// Hashed fields are exported as the values they resolve to, and names as
+// numbers, so that the cluster reads the same schedule.
+func TestExportCronJobsResolved(t *testing.T) {
+	c := cron.New()
+	id, _ := c.AddJob("H H * * MON-FRI", cron.NewShellCommandJob("true"), cron.WithName("spread"))
+	out, err := ExportCronJobs(c.Entries(), ExportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := c.Entry(id).Schedule.(*cron.SpecSchedule)
+	want := fmt.Sprintf("  schedule: %q\n", spec.String())
+	if !strings.Contains(string(out), want) || strings.Contains(spec.String(), "H") {
+		t.Errorf("expected %q, got:\n%s", want, out)
+	}
+	if !strings.HasSuffix(spec.String(), " * * 1-5") {
+		t.Errorf("unexpected resolved schedule %q", spec)
+	}
+}
+
+func TestExportCronJobsErrors(t *testing.T) {
+	shell := cron.NewShellCommandJob("true")
+	tests := []struct {
+		name  string
+		entry cron.Entry
+	}{
+		{"no spec", cron.Entry{ID: 1, Job: shell}},
+		{"every", cron.Entry{ID: 1, Spec: "@every 1m", Job: shell}},
+		{"seconds", cron.Entry{ID: 1, Spec: "0 0 * * * *", Job: shell}},
+		{"not a command", cron.Entry{ID: 1, Spec: "@daily", Job: cron.FuncJob(func() {})}},
+		{"zone only", cron.Entry{ID: 1, Spec: "TZ=UTC", Job: shell}},
+	}
+	for _, test := range tests {
+		if _, err := ExportCronJobs([]cron.Entry{test.entry}, ExportOptions{}); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+
+	for _, spec := range []string{"0 0 L * *", "0 0 15W * *", "0 9 * * MON#2"} {
+		schedule, err := cron.ParseStandard(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := cron.Entry{ID: 1, Spec: spec, Schedule: schedule, Job: shell}
+		if _, err := ExportCronJobs([]cron.Entry{e}, ExportOptions{}); err == nil || !strings.Contains(err.Error(), "modifiers") {
+			t.Errorf("%s: expected the modifier rejected, got %v", spec, err)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package cronk8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronJob is the subset of a batch/v1 CronJob that ImportCronJobs reads.
+type CronJob struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		Schedule                string `json:"schedule"`
+		TimeZone                string `json:"timeZone"`
+		ConcurrencyPolicy       string `json:"concurrencyPolicy"`
+		StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds"`
+		Suspend                 bool   `json:"suspend"`
+		JobTemplate             struct {
+			Spec struct {
+				Template struct {
+					Spec struct {
+						Containers []Container `json:"containers"`
+					} `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		} `json:"jobTemplate"`
+	} `json:"spec"`
+}
+
+// Container is the subset of a pod's container that ImportCronJobs reads.
+type Container struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Command []string `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// ImportOptions configure how CronJobs are converted to entries.
+type ImportOptions struct {
+	// Job returns the job to run for the CronJob. It defaults to a
+	// *cron.ShellCommandJob running the first container's command and
+	// arguments.
+	Job func(cj CronJob) (cron.Job, error)
+
+	// Logger receives the logs of the overlap and misfire wrappers.
+	// It defaults to cron.DefaultLogger.
+	Logger cron.Logger
+}
+
+// ImportCronJobs adds an entry to c for each CronJob in data, which is the
+// JSON of a CronJob or of a list of them, as printed by
+// "kubectl get cronjobs -o json". Suspended CronJobs are skipped.
+//
+// Entries are named after the CronJobs and keep their schedule and time zone,
+// which are parsed in the standard five-field format whatever c's parser is.
+// The concurrency policy and starting deadline become job wrappers in the
+// entries' WrappedJob, which leaves their Job for ExportCronJobs to read:
+//
+//   - Allow runs invocations concurrently
+//   - Forbid becomes cron.SkipIfStillRunning
+//   - Replace becomes cron.ReplaceIfStillRunning
+//   - startingDeadlineSeconds becomes cron.SkipIfLate
+//
+// If a CronJob cannot be imported, the entries added before it are kept and
+// their IDs returned with the error.
+func ImportCronJobs(c *cron.Cron, data []byte, opts ImportOptions) ([]cron.EntryID, error) {
+	var list struct {
+		Kind  string    `json:"kind"`
+		Items []CronJob `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("cronk8s: decoding CronJobs: %v", err)
+	}
+	cronJobs := list.Items
+	if !strings.HasSuffix(list.Kind, "List") {
+		var cj CronJob
+		if err := json.Unmarshal(data, &cj); err != nil {
+			return nil, fmt.Errorf("cronk8s: decoding CronJob: %v", err)
+		}
+		cronJobs = []CronJob{cj}
+	}
+
+	if opts.Job == nil {
+		opts.Job = shellJob
+	}
+	if opts.Logger == nil {
+		opts.Logger = cron.DefaultLogger
+	}
+	var ids []cron.EntryID
+	for _, cj := range cronJobs {
+		if cj.Spec.Suspend {
+			continue
+		}
+		id, err := importCronJob(c, cj, opts)
+		if err != nil {
+			return ids, fmt.Errorf("cronk8s: CronJob %s: %v", cj.Metadata.Name, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func importCronJob(c *cron.Cron, cj CronJob, opts ImportOptions) (cron.EntryID, error) {
+	spec := cj.Spec.Schedule
+	if cj.Spec.TimeZone != "" {
+		spec = "CRON_TZ=" + cj.Spec.TimeZone + " " + spec
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return 0, err
+	}
+	job, err := opts.Job(cj)
+	if err != nil {
+		return 0, err
+	}
+
+	var wrappers []cron.JobWrapper
+	switch cj.Spec.ConcurrencyPolicy {
+	case "", "Allow":
+	case "Forbid":
+		wrappers = append(wrappers, cron.SkipIfStillRunning(opts.Logger))
+	case "Replace":
+		wrappers = append(wrappers, cron.ReplaceIfStillRunning(opts.Logger))
+	default:
+		return 0, fmt.Errorf("unknown concurrency policy %q", cj.Spec.ConcurrencyPolicy)
+	}
+	if d := cj.Spec.StartingDeadlineSeconds; d != nil {
+		wrappers = append(wrappers, cron.SkipIfLate(opts.Logger, time.Duration(*d)*time.Second))
+	}
+
+	// The wrappers go in the WrappedJob, so that the entry's Job is still the
+	// one ExportCronJobs reads.
+	return c.Schedule(schedule, job,
+		cron.WithName(cj.Metadata.Name),
+		func(e *cron.Entry) {
+			e.Spec = spec
+			e.WrappedJob = cron.NewChain(wrappers...).Then(job)
+		}), nil
+}
+
+// shellJob runs the first container's command line with the shell, undoing
+// the "/bin/sh -c" added by ExportCronJobs.
+func shellJob(cj CronJob) (cron.Job, error) {
+	containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no containers")
+	}
+	args := append(append([]string(nil), containers[0].Command...), containers[0].Args...)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("container %s has no command", containers[0].Name)
+	}
+	if len(args) == 3 && args[0] == "/bin/sh" && args[1] == "-c" {
+		return cron.NewShellCommandJob(args[2]), nil
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+	}
+	return cron.NewShellCommandJob(strings.Join(quoted, " ")), nil
+}
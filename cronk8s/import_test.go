@@ -0,0 +1,121 @@
+package cronk8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+const cronJobList = `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {
+      "metadata": {"name": "report"},
+      "spec": {
+        "schedule": "30 4 * * *",
+        "timeZone": "Asia/Tokyo",
+        "concurrencyPolicy": "Forbid",
+        "startingDeadlineSeconds": 60,
+        "jobTemplate": {"spec": {"template": {"spec": {"containers": [
+          {"name": "job", "image": "alpine", "command": ["/bin/sh", "-c", "echo hi"]}
+        ]}}}}
+      }
+    },
+    {
+      "metadata": {"name": "sync"},
+      "spec": {
+        "schedule": "@hourly",
+        "jobTemplate": {"spec": {"template": {"spec": {"containers": [
+          {"name": "job", "image": "alpine", "command": ["rsync"], "args": ["-a", "it's"]}
+        ]}}}}
+      }
+    },
+    {
+      "metadata": {"name": "paused"},
+      "spec": {"schedule": "@daily", "suspend": true}
+    }
+  ]
+}`
+
+func TestImportCronJobs(t *testing.T) {
+	c := cron.New(cron.WithSeconds())
+	var commands []string
+	ids, err := ImportCronJobs(c, []byte(cronJobList), ImportOptions{
+		Job: func(cj CronJob) (cron.Job, error) {
+			j, err := shellJob(cj)
+			if err == nil {
+				commands = append(commands, j.(*cron.ShellCommandJob).Command)
+			}
+			return j, err
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(ids))
+	}
+	e := c.Entry(ids[0])
+	if e.Name != "report" || e.Spec != "CRON_TZ=Asia/Tokyo 30 4 * * *" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e := c.Entry(ids[1]); e.Name != "sync" || e.Spec != "@hourly" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if len(commands) != 2 || commands[0] != "echo hi" || commands[1] != `'rsync' '-a' 'it'\''s'` {
+		t.Errorf("unexpected commands: %q", commands)
+	}
+}
+
+// Imported entries export back to the CronJobs they came from.
+func TestImportExportCronJobs(t *testing.T) {
+	c := cron.New()
+	if _, err := ImportCronJobs(c, []byte(cronJobList), ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Entries()[0].Job.(*cron.ShellCommandJob); !ok {
+		t.Fatalf("expected the entry's job to be the command, got %T", c.Entries()[0].Job)
+	}
+	out, err := ExportCronJobs(c.Entries(), ExportOptions{Image: "alpine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`schedule: "30 4 * * *"`,
+		`timeZone: "Asia/Tokyo"`,
+		`command: ["/bin/sh","-c","echo hi"]`,
+		`schedule: "@hourly"`,
+		`command: ["/bin/sh","-c","'rsync' '-a' 'it'\\''s'"]`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestImportCronJob(t *testing.T) {
+	c := cron.New()
+	ids, err := ImportCronJobs(c, []byte(`{"kind": "CronJob", "metadata": {"name": "one"},
+		"spec": {"schedule": "*/5 * * * *", "concurrencyPolicy": "Replace",
+		"jobTemplate": {"spec": {"template": {"spec": {"containers": [{"command": ["true"]}]}}}}}}`), ImportOptions{})
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected one entry, got %v, %v", ids, err)
+	}
+}
+
+func TestImportCronJobsErrors(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`{"kind": "CronJob", "spec": {"schedule": "bogus"}}`,
+		`{"kind": "CronJob", "spec": {"schedule": "@daily"}}`,
+		`{"kind": "CronJob", "spec": {"schedule": "@daily", "concurrencyPolicy": "Sometimes",
+		  "jobTemplate": {"spec": {"template": {"spec": {"containers": [{"command": ["true"]}]}}}}}}`,
+	}
+	for _, test := range tests {
+		if _, err := ImportCronJobs(cron.New(), []byte(test), ImportOptions{}); err == nil {
+			t.Errorf("%s: expected an error", test)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+// Package cronk8s integrates cron with Kubernetes.
+//
+// LeaseElector uses a coordination.k8s.io Lease so that only one pod of a
+// Deployment fires jobs:
+//
+//	le, err := cronk8s.InCluster("default", "my-scheduler", os.Getenv("POD_NAME"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	go le.Run(ctx)
+//	c := cron.New(cron.WithElector(le))
+//
+// The pod's service account needs get, create and update permissions on the
+// Lease. The package talks to the API server directly and does not depend on
+// client-go.
+package cronk8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serviceAccountDir is where Kubernetes mounts the pod's credentials.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+
+// microTimeFormat is the format of Kubernetes MicroTime values.
+const microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// LeaseElector is a cron.Elector that holds leadership through a Kubernetes
+// Lease. Leadership is kept by renewing the Lease, and passes to another
+// candidate once the holder stops renewing it for LeaseDuration, e.g.
+// because its pod died. The holder itself gives up leadership once it has
+// failed to renew the Lease for RenewDeadline, so that it stops firing jobs
+// before another candidate can start.
+//
+// As with client-go, candidates time the Lease from when they saw it last
+// change rather than from its renewTime, so that pods' clocks need not agree.
+// A candidate that has just started waits LeaseDuration before taking over a
+// Lease that is not renewed.
+type LeaseElector struct {
+	// Host is the base URL of the API server.
+	Host string
+
+	// Token is the bearer token used to authenticate.
+	Token string
+
+	// TokenFile, if set, is a file the bearer token is read from instead,
+	// before each request, so that service account tokens that the kubelet
+	// rotates keep working.
+	TokenFile string
+
+	// Client is used to make requests. It defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Namespace and Name identify the Lease.
+	Namespace, Name string
+
+	// Identity identifies this candidate, typically the pod name.
+	Identity string
+
+	// LeaseDuration is how long a Lease is valid without renewal. It defaults
+	// to 15 seconds.
+	LeaseDuration time.Duration
+
+	// RenewDeadline is how long the holder keeps leadership without renewing
+	// the Lease. It must be shorter than LeaseDuration, and defaults to two
+	// thirds of it.
+	RenewDeadline time.Duration
+
+	// RetryPeriod is how often candidates try to acquire or renew the Lease.
+	// It defaults to 2 seconds.
+	RetryPeriod time.Duration
+
+	mu       sync.Mutex
+	renewed  time.Time // when this candidate last acquired or renewed the Lease
+	observed string    // the holder and renewTime of the Lease last seen
+	seen     time.Time // when the Lease was first seen as observed
+}
+
+// InCluster returns a LeaseElector configured from the pod's service account.
+func InCluster(namespace, name, identity string) (*LeaseElector, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a Kubernetes cluster")
+	}
+	if _, err := os.Stat(serviceAccountDir + "token"); err != nil {
+		return nil, err
+	}
+	ca, err := ioutil.ReadFile(serviceAccountDir + "ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no certificates found in service account CA")
+	}
+	return &LeaseElector{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		TokenFile: serviceAccountDir + "token",
+		Client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+		Namespace: namespace,
+		Name:      name,
+		Identity:  identity,
+	}, nil
+}
+
+// IsLeader reports whether this candidate holds the Lease. Leadership lapses
+// if the Lease could not be renewed within RenewDeadline.
+func (le *LeaseElector) IsLeader() bool {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	return le.leading()
+}
+
+// leading reports whether the Lease was renewed within RenewDeadline. le.mu
+// must be held.
+func (le *LeaseElector) leading() bool {
+	return !le.renewed.IsZero() && time.Since(le.renewed) < le.renewDeadline()
+}
+
+// Run campaigns for leadership until ctx is done, then releases the Lease if
+// it is held so that another candidate can take over immediately.
+func (le *LeaseElector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(le.retryPeriod())
+	defer ticker.Stop()
+	for {
+		le.TryAcquireOrRenew(ctx)
+		select {
+		case <-ctx.Done():
+			release, cancel := context.WithTimeout(context.Background(), le.retryPeriod())
+			defer cancel()
+			return le.Release(release)
+		case <-ticker.C:
+		}
+	}
+}
+
+// lease is the subset of a coordination.k8s.io/v1 Lease used here.
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       *string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds *int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *string `json:"acquireTime,omitempty"`
+	RenewTime            *string `json:"renewTime,omitempty"`
+	LeaseTransitions     int     `json:"leaseTransitions,omitempty"`
+}
+
+// errConflict is returned when the Lease was changed by another candidate.
+var errConflict = errors.New("lease was modified concurrently")
+
+// TryAcquireOrRenew makes a single attempt to acquire the Lease, or renew it
+// if already held. It reports whether this candidate holds the Lease.
+func (le *LeaseElector) TryAcquireOrRenew(ctx context.Context) bool {
+	now := time.Now()
+	nowStr := now.UTC().Format(microTimeFormat)
+	seconds := int(le.leaseDuration() / time.Second)
+	identity := le.Identity
+
+	l, err := le.get(ctx)
+	if err == nil {
+		le.observe(l, now)
+	}
+	switch {
+	case err == errNotFound:
+		l = &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   leaseMetadata{Name: le.Name, Namespace: le.Namespace},
+			Spec: leaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &nowStr,
+				RenewTime:            &nowStr,
+			},
+		}
+		err = le.write(ctx, http.MethodPost, le.collectionPath(), l)
+	case err != nil:
+	case holder(l) == identity:
+		l.Spec.RenewTime = &nowStr
+		l.Spec.LeaseDurationSeconds = &seconds
+		err = le.write(ctx, http.MethodPut, le.path(), l)
+	case holder(l) == "" || le.expired(l, now):
+		l.Spec.HolderIdentity = &identity
+		l.Spec.LeaseDurationSeconds = &seconds
+		l.Spec.AcquireTime = &nowStr
+		l.Spec.RenewTime = &nowStr
+		l.Spec.LeaseTransitions++
+		err = le.write(ctx, http.MethodPut, le.path(), l)
+	default:
+		err = errHeld
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	if err != nil {
+		if err == errHeld || err == errConflict || !le.leading() {
+			le.renewed = time.Time{}
+		}
+		return le.leading()
+	}
+	le.renewed = now
+	return true
+}
+
+// errHeld is returned when another candidate holds a valid Lease.
+var errHeld = errors.New("lease is held by another candidate")
+
+// Release gives up the Lease, if this candidate holds it.
+func (le *LeaseElector) Release(ctx context.Context) error {
+	le.mu.Lock()
+	held := !le.renewed.IsZero()
+	le.renewed = time.Time{}
+	le.mu.Unlock()
+	if !held {
+		return nil
+	}
+	l, err := le.get(ctx)
+	if err != nil {
+		return err
+	}
+	if holder(l) != le.Identity {
+		return nil
+	}
+	empty := ""
+	l.Spec.HolderIdentity = &empty
+	return le.write(ctx, http.MethodPut, le.path(), l)
+}
+
+// holder returns the identity holding the Lease, or "" if none.
+func holder(l *lease) string {
+	if l.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *l.Spec.HolderIdentity
+}
+
+// observe records the Lease's holder and renewTime, and when they were first
+// seen, if they changed since it was last seen.
+func (le *LeaseElector) observe(l *lease, now time.Time) {
+	record := holder(l)
+	if l.Spec.RenewTime != nil {
+		record += " " + *l.Spec.RenewTime
+	}
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	if record != le.observed || le.seen.IsZero() {
+		le.observed, le.seen = record, now
+	}
+}
+
+// expired reports whether the Lease's holder failed to renew it in time: it
+// has not changed for its duration since this candidate saw it change.
+func (le *LeaseElector) expired(l *lease, now time.Time) bool {
+	d := le.leaseDuration()
+	if l.Spec.LeaseDurationSeconds != nil {
+		d = time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second
+	}
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	return now.After(le.seen.Add(d))
+}
+
+var errNotFound = errors.New("lease not found")
+
+func (le *LeaseElector) collectionPath() string {
+	return "/apis/coordination.k8s.io/v1/namespaces/" + le.Namespace + "/leases"
+}
+
+func (le *LeaseElector) path() string {
+	return le.collectionPath() + "/" + le.Name
+}
+
+func (le *LeaseElector) get(ctx context.Context) (*lease, error) {
+	resp, err := le.do(ctx, http.MethodGet, le.path(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get lease: %s", resp.Status)
+	}
+	var l lease
+	if err := json.NewDecoder(resp.Body).Decode(&l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// write creates or replaces the Lease. Replacing fails with errConflict if
+// the Lease's resourceVersion is stale.
+func (le *LeaseElector) write(ctx context.Context, method, path string, l *lease) error {
+	body, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	resp, err := le.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return errConflict
+	case resp.StatusCode/100 != 2:
+		return fmt.Errorf("%s lease: %s", strings.ToLower(method), resp.Status)
+	}
+	return nil
+}
+
+func (le *LeaseElector) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, le.Host+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	token := le.Token
+	if le.TokenFile != "" {
+		b, err := ioutil.ReadFile(le.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(string(b))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := le.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req.WithContext(ctx))
+}
+
+func (le *LeaseElector) leaseDuration() time.Duration {
+	if le.LeaseDuration <= 0 {
+		return 15 * time.Second
+	}
+	return le.LeaseDuration
+}
+
+func (le *LeaseElector) renewDeadline() time.Duration {
+	if le.RenewDeadline <= 0 || le.RenewDeadline >= le.leaseDuration() {
+		return le.leaseDuration() * 2 / 3
+	}
+	return le.RenewDeadline
+}
+
+func (le *LeaseElector) retryPeriod() time.Duration {
+	if le.RetryPeriod <= 0 {
+		return 2 * time.Second
+	}
+	return le.RetryPeriod
+}
@@ -0,0 +1,187 @@
+package cronk8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPIServer stores a single Lease with optimistic concurrency.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	lease   *lease
+	version int
+	auth    string // the last Authorization header
+	down    bool   // whether requests fail
+}
+
+func (s *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auth = r.Header.Get("Authorization")
+	if s.down {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if s.lease == nil {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(s.lease)
+	case http.MethodPost, http.MethodPut:
+		var l lease
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if (r.Method == http.MethodPost && s.lease != nil) ||
+			(r.Method == http.MethodPut && l.Metadata.ResourceVersion != strconv.Itoa(s.version)) {
+			http.Error(w, "conflict", http.StatusConflict)
+			return
+		}
+		s.version++
+		l.Metadata.ResourceVersion = strconv.Itoa(s.version)
+		s.lease = &l
+		json.NewEncoder(w).Encode(s.lease)
+	}
+}
+
+func TestLeaseElector(t *testing.T) {
+	srv := httptest.NewServer(&fakeAPIServer{})
+	defer srv.Close()
+	candidate := func(identity string) *LeaseElector {
+		return &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: identity,
+			LeaseDuration: time.Second}
+	}
+	a, b := candidate("a"), candidate("b")
+	ctx := context.Background()
+
+	if !a.TryAcquireOrRenew(ctx) || !a.IsLeader() {
+		t.Fatal("expected a to acquire the lease")
+	}
+	if b.TryAcquireOrRenew(ctx) || b.IsLeader() {
+		t.Fatal("expected b to be a follower")
+	}
+	if !a.TryAcquireOrRenew(ctx) {
+		t.Fatal("expected a to renew the lease")
+	}
+
+	// Releasing hands over leadership immediately.
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if a.IsLeader() {
+		t.Error("expected a to give up leadership")
+	}
+	if !b.TryAcquireOrRenew(ctx) {
+		t.Fatal("expected b to acquire the released lease")
+	}
+}
+
+// A candidate takes over once the leader stops renewing the lease.
+func TestLeaseElectorFailover(t *testing.T) {
+	srv := httptest.NewServer(&fakeAPIServer{})
+	defer srv.Close()
+	a := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "a", LeaseDuration: time.Second}
+	b := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "b", LeaseDuration: time.Second}
+	ctx := context.Background()
+
+	a.TryAcquireOrRenew(ctx)
+	if b.TryAcquireOrRenew(ctx) {
+		t.Fatal("expected b to be a follower")
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if a.IsLeader() {
+		t.Error("expected a's leadership to lapse without renewal")
+	}
+	if !b.TryAcquireOrRenew(ctx) {
+		t.Error("expected b to take over the expired lease")
+	}
+}
+
+// Leases are timed from when candidates see them change, so a holder whose
+// clock is behind keeps its Lease.
+func TestLeaseElectorClockSkew(t *testing.T) {
+	api := &fakeAPIServer{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	a := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "a", LeaseDuration: time.Second}
+	b := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "b", LeaseDuration: time.Second}
+	ctx := context.Background()
+
+	a.TryAcquireOrRenew(ctx)
+	// a's clock is an hour behind b's.
+	api.mu.Lock()
+	renewed := time.Now().Add(-time.Hour).UTC().Format(microTimeFormat)
+	api.lease.Spec.RenewTime = &renewed
+	api.mu.Unlock()
+	if b.TryAcquireOrRenew(ctx) {
+		t.Error("expected b not to take over a lease it just saw renewed")
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if !b.TryAcquireOrRenew(ctx) {
+		t.Error("expected b to take over once the lease went unchanged for its duration")
+	}
+}
+
+// The holder gives up leadership once it fails to renew for RenewDeadline,
+// before the Lease expires for other candidates.
+func TestLeaseElectorRenewDeadline(t *testing.T) {
+	api := &fakeAPIServer{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	a := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "a",
+		LeaseDuration: 2 * time.Second, RenewDeadline: 500 * time.Millisecond}
+	b := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "b",
+		LeaseDuration: 2 * time.Second}
+	ctx := context.Background()
+
+	a.TryAcquireOrRenew(ctx)
+	b.TryAcquireOrRenew(ctx)
+	setDown := func(down bool) {
+		api.mu.Lock()
+		api.down = down
+		api.mu.Unlock()
+	}
+	setDown(true)
+	if !a.TryAcquireOrRenew(ctx) || !a.IsLeader() {
+		t.Error("expected a to stay leader within the renew deadline")
+	}
+	time.Sleep(600 * time.Millisecond)
+	if a.TryAcquireOrRenew(ctx) || a.IsLeader() {
+		t.Error("expected a to give up leadership after the renew deadline")
+	}
+	setDown(false)
+	if b.TryAcquireOrRenew(ctx) {
+		t.Error("expected the lease to outlast the renew deadline")
+	}
+}
+
+// A token file is read again for each request, as kubelet rotates it.
+func TestLeaseElectorTokenFile(t *testing.T) {
+	api := &fakeAPIServer{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	file := filepath.Join(t.TempDir(), "token")
+	a := &LeaseElector{Host: srv.URL, Namespace: "default", Name: "cron", Identity: "a", TokenFile: file}
+	ctx := context.Background()
+
+	for _, token := range []string{"first", "second"} {
+		if err := os.WriteFile(file, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		a.TryAcquireOrRenew(ctx)
+		if api.auth != "Bearer "+token {
+			t.Errorf("expected the %s token, got %q", token, api.auth)
+		}
+	}
+}
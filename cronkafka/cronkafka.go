@@ -0,0 +1,76 @@
+// Package cronkafka publishes cron run lifecycle events to a Kafka topic.
+//
+// Each cron.Event is encoded as a JSON Message and keyed by the entry's name
+// (or ID, for unnamed entries), so all events for one entry land on the same
+// partition in order:
+//
+//	c := cron.New(cron.WithEventListener(
+//		cronkafka.NewEventListener(producer, "cron-events", cron.DefaultLogger)))
+//
+// The package does not depend on a Kafka client. Producer is satisfied by a
+// small adapter around an asynchronous producer, such as sarama's
+// AsyncProducer or a kafka-go Writer configured with Async.
+package cronkafka
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Producer sends a message to a Kafka topic. Since events are emitted from
+// the scheduling goroutine, Produce should hand the message off and return
+// without waiting for it to be acknowledged.
+type Producer interface {
+	Produce(topic string, key, value []byte) error
+}
+
+// Message is the JSON encoding of a cron.Event.
+type Message struct {
+	Type       string    `json:"type"`
+	Entry      int       `json:"entry"`
+	Name       string    `json:"name,omitempty"`
+	RunID      string    `json:"run_id,omitempty"`
+	Scheduled  time.Time `json:"scheduled"`
+	Time       time.Time `json:"time"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NewMessage returns the Message for the given event.
+func NewMessage(ev cron.Event) Message {
+	msg := Message{
+		Type:       ev.Type.String(),
+		Entry:      int(ev.Entry),
+		Name:       ev.Name,
+		RunID:      ev.RunID,
+		Scheduled:  ev.Scheduled,
+		Time:       ev.Time,
+		DurationMs: int64(ev.Duration / time.Millisecond),
+	}
+	if ev.Err != nil {
+		msg.Error = ev.Err.Error()
+	}
+	return msg
+}
+
+// NewEventListener returns a cron.EventListener that publishes every event to
+// the given topic. Failures to publish are logged to logger.
+func NewEventListener(p Producer, topic string, logger cron.Logger) cron.EventListener {
+	return func(ev cron.Event) {
+		value, err := json.Marshal(NewMessage(ev))
+		if err != nil {
+			logger.Error(err, "encode event", "entry", ev.Entry)
+			return
+		}
+		key := ev.Name
+		if key == "" {
+			key = strconv.Itoa(int(ev.Entry))
+		}
+		if err := p.Produce(topic, []byte(key), value); err != nil {
+			logger.Error(err, "produce event", "topic", topic, "entry", ev.Entry)
+		}
+	}
+}
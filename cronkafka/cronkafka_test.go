@@ -0,0 +1,46 @@
+package cronkafka
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+type message struct {
+	topic      string
+	key, value []byte
+}
+
+type producerFunc func(topic string, key, value []byte) error
+
+func (f producerFunc) Produce(topic string, key, value []byte) error { return f(topic, key, value) }
+
+func TestEventListener(t *testing.T) {
+	var sent []message
+	l := NewEventListener(producerFunc(func(topic string, key, value []byte) error {
+		sent = append(sent, message{topic, key, value})
+		return nil
+	}), "events", cron.DiscardLogger)
+
+	now := time.Date(2020, 8, 28, 10, 0, 0, 0, time.UTC)
+	l(cron.Event{Type: cron.EventFailed, Entry: 3, Name: "report", Scheduled: now,
+		Time: now.Add(2 * time.Second), Duration: 2 * time.Second, Err: errors.New("boom")})
+	l(cron.Event{Type: cron.EventStarted, Entry: 4, Scheduled: now, Time: now})
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(sent))
+	}
+	if sent[0].topic != "events" || string(sent[0].key) != "report" || string(sent[1].key) != "4" {
+		t.Errorf("unexpected topic or keys: %+v", sent)
+	}
+	var msg Message
+	if err := json.Unmarshal(sent[0].value, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "failed" || msg.Entry != 3 || msg.DurationMs != 2000 || msg.Error != "boom" || !msg.Scheduled.Equal(now) {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+}
@@ -0,0 +1,174 @@
+// Package cronnats distributes cron firings over NATS.
+//
+// A scheduler process publishes each due entry to a subject derived from the
+// entry's name, and any number of worker processes subscribe to those subjects
+// in a queue group, so that each firing is executed by exactly one worker:
+//
+//	// Scheduler
+//	c := cron.New(cron.WithDispatcher(cronnats.NewDispatcher(nc, "cron")))
+//	c.AddFunc("@hourly", func() {}, cron.WithName("report"))
+//	c.Start()
+//
+//	// Worker
+//	w := cronnats.NewWorker(cron.DefaultLogger)
+//	w.Handle("report", cron.FuncJob(sendReport))
+//	w.Subscribe(sub, "cron", "workers")
+//
+// The package does not depend on a NATS client. A *nats.Conn satisfies
+// Publisher directly, and Subscriber takes a few lines to adapt.
+package cronnats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Publisher publishes a message to a subject. *nats.Conn satisfies it.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// Subscriber subscribes a handler to a subject as a member of a queue group,
+// so that each message is delivered to one member of the group. It returns a
+// function that removes the subscription.
+type Subscriber interface {
+	QueueSubscribe(subject, queue string, handler func(data []byte)) (unsubscribe func() error, err error)
+}
+
+// Subject returns the subject that firings of the named entry are published
+// to under the given prefix.
+func Subject(prefix, name string) string {
+	return prefix + "." + name
+}
+
+// dispatcher publishes firings as JSON-encoded cron.Dispatch messages.
+type dispatcher struct {
+	pub    Publisher
+	prefix string
+}
+
+// NewDispatcher returns a cron.Dispatcher that publishes each firing to
+// Subject(prefix, name). Entries without a name are published under their ID.
+func NewDispatcher(pub Publisher, prefix string) cron.Dispatcher {
+	return dispatcher{pub, prefix}
+}
+
+func (d dispatcher) Dispatch(msg cron.Dispatch) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return d.pub.Publish(Subject(d.prefix, entryName(msg)), data)
+}
+
+// entryName returns the name that firings of the entry are published and
+// handled under: its name, or its ID if it has none.
+func entryName(msg cron.Dispatch) string {
+	if msg.Name == "" {
+		return strconv.Itoa(int(msg.Entry))
+	}
+	return msg.Name
+}
+
+// Worker executes the jobs named in dispatched messages.
+type Worker struct {
+	logger cron.Logger
+	mu     sync.Mutex
+	jobs   map[string]cron.Job
+	wg     sync.WaitGroup
+}
+
+// NewWorker returns a Worker with no jobs registered.
+func NewWorker(logger cron.Logger) *Worker {
+	return &Worker{
+		logger: logger,
+		jobs:   make(map[string]cron.Job),
+	}
+}
+
+// Handle registers the job to run for firings of the named entry. Entries
+// without a name are handled under their ID, as they are published. If the
+// job is a cron.PayloadJob, such as a cron.TypedJob, it runs with the payload
+// sent with each firing.
+func (w *Worker) Handle(name string, j cron.Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.jobs[name] = j
+}
+
+// Subscribe subscribes the worker to every registered job's subject as a
+// member of the given queue group. The returned function unsubscribes all of
+// them.
+func (w *Worker) Subscribe(sub Subscriber, prefix, queue string) (func() error, error) {
+	w.mu.Lock()
+	var names []string
+	for name := range w.jobs {
+		names = append(names, name)
+	}
+	w.mu.Unlock()
+
+	var unsubs []func() error
+	unsubscribe := func() error {
+		var firstErr error
+		for _, unsub := range unsubs {
+			if err := unsub(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	for _, name := range names {
+		unsub, err := sub.QueueSubscribe(Subject(prefix, name), queue, w.handle)
+		if err != nil {
+			unsubscribe()
+			return nil, fmt.Errorf("subscribing to %s: %v", name, err)
+		}
+		unsubs = append(unsubs, unsub)
+	}
+	return unsubscribe, nil
+}
+
+// Wait blocks until all jobs started by the worker have completed.
+func (w *Worker) Wait() {
+	w.wg.Wait()
+}
+
+// handle decodes a dispatched message and runs its job in a new goroutine,
+// with the firing's scheduled time in its context. Panics in the job are
+// recovered and logged, so that they do not bring down the worker.
+func (w *Worker) handle(data []byte) {
+	var msg cron.Dispatch
+	if err := json.Unmarshal(data, &msg); err != nil {
+		w.logger.Error(err, "decode dispatch")
+		return
+	}
+	name := entryName(msg)
+	w.mu.Lock()
+	j, ok := w.jobs[name]
+	w.mu.Unlock()
+	if !ok {
+		w.logger.Error(fmt.Errorf("no job registered for %q", name), "dispatch")
+		return
+	}
+	if pj, ok := j.(cron.PayloadJob); ok && len(msg.Payload) > 0 {
+		var err error
+		if j, err = pj.WithPayload(msg.Payload); err != nil {
+			w.logger.Error(err, "decode payload", "name", name)
+			return
+		}
+	}
+	w.logger.Info("run", "name", name, "scheduled", msg.Scheduled)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ctx := cron.ContextWithScheduledTime(context.Background(), msg.Scheduled)
+		if err := cron.RunJob(ctx, cron.Recover(w.logger)(j)); err != nil {
+			w.logger.Error(err, "run", "name", name)
+		}
+	}()
+}
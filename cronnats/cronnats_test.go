@@ -0,0 +1,160 @@
+package cronnats
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// bus is an in-memory message bus with queue group semantics: each message is
+// delivered to the first subscriber of its subject.
+type bus struct {
+	mu   sync.Mutex
+	subs map[string][]func([]byte)
+}
+
+func (b *bus) Publish(subject string, data []byte) error {
+	b.mu.Lock()
+	handlers := b.subs[subject]
+	b.mu.Unlock()
+	if len(handlers) > 0 {
+		handlers[0](data)
+	}
+	return nil
+}
+
+func (b *bus) QueueSubscribe(subject, queue string, handler func([]byte)) (func() error, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[string][]func([]byte))
+	}
+	b.subs[subject] = append(b.subs[subject], handler)
+	return func() error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, subject)
+		return nil
+	}, nil
+}
+
+func TestDispatchToWorker(t *testing.T) {
+	var b bus
+	ran := make(chan struct{}, 2)
+	w := NewWorker(cron.DiscardLogger)
+	w.Handle("report", cron.FuncJob(func() { ran <- struct{}{} }))
+	unsubscribe, err := w.Subscribe(&b, "cron", "workers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDispatcher(&b, "cron")
+	if err := d.Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the worker to run the job")
+	}
+
+	unsubscribe()
+	d.Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: time.Now()})
+	w.Wait()
+	if len(ran) != 0 {
+		t.Error("expected no runs after unsubscribing")
+	}
+}
+
+// Jobs see the firing's scheduled time in their context.
+func TestDispatchScheduledTime(t *testing.T) {
+	var b bus
+	got := make(chan time.Time, 1)
+	w := NewWorker(cron.DiscardLogger)
+	w.Handle("report", cron.FuncContextJob(func(ctx context.Context) error {
+		s, _ := cron.ScheduledTimeFromContext(ctx)
+		got <- s
+		return nil
+	}))
+	if _, err := w.Subscribe(&b, "cron", "workers"); err != nil {
+		t.Fatal(err)
+	}
+
+	scheduled := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	if err := NewDispatcher(&b, "cron").Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: scheduled}); err != nil {
+		t.Fatal(err)
+	}
+	w.Wait()
+	if s := <-got; !s.Equal(scheduled) {
+		t.Errorf("expected the scheduled time %v, got %v", scheduled, s)
+	}
+}
+
+// A typed job runs with the payload dispatched with the firing, not its own.
+func TestDispatchPayload(t *testing.T) {
+	var b bus
+	got := make(chan string, 1)
+	w := NewWorker(cron.DiscardLogger)
+	w.Handle("greet", cron.NewTypedJob("local", func(ctx context.Context, name string) error {
+		got <- name
+		return nil
+	}))
+	if _, err := w.Subscribe(&b, "cron", "workers"); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, _ := cron.NewTypedJob("remote", nil).MarshalPayload()
+	d := NewDispatcher(&b, "cron")
+	if err := d.Dispatch(cron.Dispatch{Entry: 1, Name: "greet", Scheduled: time.Now(), Payload: payload}); err != nil {
+		t.Fatal(err)
+	}
+	w.Wait()
+	if name := <-got; name != "remote" {
+		t.Errorf("expected the dispatched payload, got %q", name)
+	}
+}
+
+// errorLog records the messages of logged errors.
+type errorLog struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *errorLog) Info(msg string, keysAndValues ...interface{}) {}
+
+func (l *errorLog) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+// Unnamed entries are handled under their ID, and a panicking job is logged
+// rather than taking the worker down.
+func TestDispatchUnnamedPanic(t *testing.T) {
+	var (
+		b   bus
+		log errorLog
+	)
+	w := NewWorker(&log)
+	w.Handle("7", cron.FuncJob(func() { panic("boom") }))
+	if _, err := w.Subscribe(&b, "cron", "workers"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewDispatcher(&b, "cron").Dispatch(cron.Dispatch{Entry: 7, Scheduled: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	w.Wait()
+	if len(log.msgs) == 0 || log.msgs[0] != "panic" {
+		t.Errorf("expected the job's panic logged, got %v", log.msgs)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	if s := Subject("cron", "report"); s != "cron.report" {
+		t.Errorf("unexpected subject %q", s)
+	}
+}
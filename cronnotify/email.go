@@ -0,0 +1,104 @@
+package cronnotify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"text/template"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultEmailTemplate is the message body Email sends when no Template is
+// set. It is executed with the cron.Notification.
+var DefaultEmailTemplate = template.Must(template.New("email").Parse(
+	`{{if .Recovered}}{{.Name}} succeeded after a previous failure.
+{{else}}{{.Name}} failed: {{.Err}}
+{{end}}
+Scheduled: {{.Scheduled}}
+Duration:  {{.Duration}}
+`))
+
+// Email sends notifications by SMTP, in the manner of classic cron's MAILTO.
+//
+// Recipients are taken from To, plus TagRecipients for each of the entry's
+// tags. If there are no recipients, nothing is sent. To configure recipients
+// for a single entry, wrap its job in its own NotifyOnFailure.
+type Email struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+
+	// Auth authenticates with the server, if non-nil.
+	Auth smtp.Auth
+
+	// From is the sender's address.
+	From string
+
+	// To are the addresses notified about every entry.
+	To []string
+
+	// TagRecipients are additional addresses notified about entries with the
+	// given tag.
+	TagRecipients map[string][]string
+
+	// Template renders the message body. It defaults to DefaultEmailTemplate.
+	Template *template.Template
+
+	// send is smtp.SendMail, replaced in tests.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Notify mails the notification to its recipients.
+func (e *Email) Notify(ctx context.Context, n cron.Notification) error {
+	to := e.recipients(n.Tags)
+	if len(to) == 0 {
+		return nil
+	}
+	tmpl := e.Template
+	if tmpl == nil {
+		tmpl = DefaultEmailTemplate
+	}
+	subject := fmt.Sprintf("cron: %s failed", n.Name)
+	if n.Recovered {
+		subject = fmt.Sprintf("cron: %s recovered", n.Name)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	if err := tmpl.Execute(&msg, n); err != nil {
+		return err
+	}
+
+	send := e.send
+	if send == nil {
+		send = smtp.SendMail
+	}
+	return send(e.Addr, e.Auth, e.From, to, msg.Bytes())
+}
+
+// recipients returns the distinct addresses to notify for an entry with the
+// given tags.
+func (e *Email) recipients(tags []string) []string {
+	var (
+		to   []string
+		seen = make(map[string]bool)
+	)
+	add := func(addrs []string) {
+		for _, addr := range addrs {
+			if !seen[addr] {
+				seen[addr] = true
+				to = append(to, addr)
+			}
+		}
+	}
+	add(e.To)
+	for _, tag := range tags {
+		add(e.TagRecipients[tag])
+	}
+	return to
+}
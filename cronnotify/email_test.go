@@ -0,0 +1,56 @@
+package cronnotify
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestEmail(t *testing.T) {
+	var (
+		gotTo  []string
+		gotMsg string
+	)
+	e := &Email{
+		Addr:          "localhost:25",
+		From:          "cron@example.com",
+		To:            []string{"ops@example.com"},
+		TagRecipients: map[string][]string{"billing": {"billing@example.com", "ops@example.com"}},
+		send: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			gotTo, gotMsg = to, string(msg)
+			return nil
+		},
+	}
+
+	err := e.Notify(context.Background(), cron.Notification{
+		Name: "invoices",
+		Tags: []string{"billing"},
+		Err:  errors.New("boom"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ops@example.com", "billing@example.com"}; !reflect.DeepEqual(gotTo, want) {
+		t.Errorf("expected recipients %v, got %v", want, gotTo)
+	}
+	if !strings.Contains(gotMsg, "Subject: cron: invoices failed\r\n") || !strings.Contains(gotMsg, "invoices failed: boom") {
+		t.Errorf("unexpected message:\n%s", gotMsg)
+	}
+}
+
+func TestEmailNoRecipients(t *testing.T) {
+	e := &Email{
+		send: func(string, smtp.Auth, string, []string, []byte) error {
+			t.Error("expected no mail to be sent")
+			return nil
+		},
+	}
+	if err := e.Notify(context.Background(), cron.Notification{Name: "sync"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+package cronnotify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// IncidentService opens and resolves incidents in an incident management
+// service such as PagerDuty or Opsgenie. Incidents are identified by a key
+// that is stable for each entry.
+type IncidentService interface {
+	Trigger(ctx context.Context, key, summary string) error
+	Resolve(ctx context.Context, key string) error
+}
+
+// Incidents is a cron.Notifier that opens an incident once an entry has failed
+// Threshold times in a row, and resolves it on the entry's next success. It
+// should be installed with recovery notifications enabled:
+//
+//	cron.NotifyOnFailure(logger, &cronnotify.Incidents{
+//		Service:   &cronnotify.PagerDuty{RoutingKey: key},
+//		Threshold: 3,
+//	}, true)
+type Incidents struct {
+	Service IncidentService
+
+	// Threshold is the number of consecutive failures that opens an incident.
+	// Values below 1 are treated as 1.
+	Threshold int
+
+	mu       sync.Mutex
+	failures map[string]int
+	open     map[string]bool
+}
+
+// Notify counts the entry's consecutive failures, opening or resolving its
+// incident as needed.
+func (in *Incidents) Notify(ctx context.Context, n cron.Notification) error {
+	key := incidentKey(n)
+	in.mu.Lock()
+	if in.failures == nil {
+		in.failures = make(map[string]int)
+		in.open = make(map[string]bool)
+	}
+	if n.Err == nil {
+		wasOpen := in.open[key]
+		delete(in.failures, key)
+		delete(in.open, key)
+		in.mu.Unlock()
+		if !wasOpen {
+			return nil
+		}
+		return in.Service.Resolve(ctx, key)
+	}
+
+	in.failures[key]++
+	trigger := !in.open[key] && in.failures[key] >= in.threshold()
+	if trigger {
+		in.open[key] = true
+	}
+	count := in.failures[key]
+	in.mu.Unlock()
+	if !trigger {
+		return nil
+	}
+	summary := fmt.Sprintf("cron job %s failed %d times in a row: %v", n.Name, count, n.Err)
+	if err := in.Service.Trigger(ctx, key, summary); err != nil {
+		// Try again on the next failure.
+		in.mu.Lock()
+		delete(in.open, key)
+		in.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (in *Incidents) threshold() int {
+	if in.Threshold < 1 {
+		return 1
+	}
+	return in.Threshold
+}
+
+// incidentKey identifies the incident for the notification's entry.
+func incidentKey(n cron.Notification) string {
+	if n.Name != "" {
+		return "cron-" + n.Name
+	}
+	return "cron-" + strconv.Itoa(int(n.Entry))
+}
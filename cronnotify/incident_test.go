@@ -0,0 +1,48 @@
+package cronnotify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+type recordingService struct {
+	calls []string
+}
+
+func (s *recordingService) Trigger(ctx context.Context, key, summary string) error {
+	s.calls = append(s.calls, "trigger "+key)
+	return nil
+}
+
+func (s *recordingService) Resolve(ctx context.Context, key string) error {
+	s.calls = append(s.calls, "resolve "+key)
+	return nil
+}
+
+func TestIncidents(t *testing.T) {
+	var (
+		svc     recordingService
+		in      = &Incidents{Service: &svc, Threshold: 2}
+		ctx     = context.Background()
+		failure = cron.Notification{Name: "sync", Err: errors.New("boom")}
+		success = cron.Notification{Name: "sync", Recovered: true}
+	)
+
+	// One failure and a recovery do not page anyone.
+	in.Notify(ctx, failure)
+	in.Notify(ctx, success)
+	// Crossing the threshold opens a single incident, resolved on success.
+	in.Notify(ctx, failure)
+	in.Notify(ctx, failure)
+	in.Notify(ctx, failure)
+	in.Notify(ctx, success)
+
+	want := []string{"trigger cron-sync", "resolve cron-sync"}
+	if !reflect.DeepEqual(svc.calls, want) {
+		t.Errorf("expected %v, got %v", want, svc.calls)
+	}
+}
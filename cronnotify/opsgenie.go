@@ -0,0 +1,53 @@
+package cronnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// OpsgenieURL is the Opsgenie Alert API endpoint.
+const OpsgenieURL = "https://api.opsgenie.com/v2/alerts"
+
+// Opsgenie is an IncidentService backed by the Opsgenie Alert API. Incident
+// keys are used as alert aliases.
+type Opsgenie struct {
+	// APIKey is a key of an Opsgenie API integration.
+	APIKey string
+
+	// URL is the alerts endpoint. It defaults to OpsgenieURL.
+	URL string
+
+	// Client is used to send requests. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Trigger creates an alert with the given alias.
+func (o *Opsgenie) Trigger(ctx context.Context, key, summary string) error {
+	return o.post(ctx, "", map[string]string{"message": summary, "alias": key})
+}
+
+// Resolve closes the alert with the given alias.
+func (o *Opsgenie) Resolve(ctx context.Context, key string) error {
+	return o.post(ctx, "/"+url.PathEscape(key)+"/close?identifierType=alias", map[string]string{})
+}
+
+func (o *Opsgenie) post(ctx context.Context, path string, msg map[string]string) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	base := o.URL
+	if base == "" {
+		base = OpsgenieURL
+	}
+	req, err := http.NewRequest(http.MethodPost, base+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.APIKey)
+	return do(o.Client, req.WithContext(ctx))
+}
@@ -0,0 +1,35 @@
+package cronnotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestOpsgenie(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "GenieKey key" {
+			t.Errorf("unexpected authorization %q", auth)
+		}
+		requests = append(requests, r.URL.RequestURI())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	o := &Opsgenie{APIKey: "key", URL: srv.URL}
+	ctx := context.Background()
+	if err := o.Trigger(ctx, "cron-sync", "sync failed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Resolve(ctx, "cron-sync"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/", "/cron-sync/close?identifierType=alias"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected %v, got %v", want, requests)
+	}
+}
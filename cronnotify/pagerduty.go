@@ -0,0 +1,79 @@
+package cronnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty is an IncidentService backed by the PagerDuty Events API v2.
+type PagerDuty struct {
+	// RoutingKey is the integration key of the PagerDuty service.
+	RoutingKey string
+
+	// Source identifies this scheduler in incidents. It defaults to "cron".
+	Source string
+
+	// URL is the events endpoint. It defaults to PagerDutyEventsURL.
+	URL string
+
+	// Client is used to send events. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+// Trigger opens an incident with the given dedup key.
+func (p *PagerDuty) Trigger(ctx context.Context, key, summary string) error {
+	source := p.Source
+	if source == "" {
+		source = "cron"
+	}
+	return p.send(ctx, pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    key,
+		Payload:     &pagerDutyPayload{Summary: summary, Source: source, Severity: "error"},
+	})
+}
+
+// Resolve resolves the incident with the given dedup key.
+func (p *PagerDuty) Resolve(ctx context.Context, key string) error {
+	return p.send(ctx, pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    key,
+	})
+}
+
+func (p *PagerDuty) send(ctx context.Context, ev pagerDutyEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	url := p.URL
+	if url == "" {
+		url = PagerDutyEventsURL
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(p.Client, req.WithContext(ctx))
+}
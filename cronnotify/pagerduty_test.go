@@ -0,0 +1,42 @@
+package cronnotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPagerDuty(t *testing.T) {
+	var events []pagerDutyEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Error(err)
+		}
+		events = append(events, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	p := &PagerDuty{RoutingKey: "rk", URL: srv.URL}
+	ctx := context.Background()
+	if err := p.Trigger(ctx, "cron-sync", "sync failed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Resolve(ctx, "cron-sync"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if e := events[0]; e.RoutingKey != "rk" || e.EventAction != "trigger" || e.DedupKey != "cron-sync" ||
+		e.Payload == nil || e.Payload.Summary != "sync failed" || e.Payload.Source != "cron" {
+		t.Errorf("unexpected trigger event: %+v", e)
+	}
+	if e := events[1]; e.EventAction != "resolve" || e.DedupKey != "cron-sync" || e.Payload != nil {
+		t.Errorf("unexpected resolve event: %+v", e)
+	}
+}
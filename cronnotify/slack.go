@@ -0,0 +1,73 @@
+// Package cronnotify provides cron.Notifier implementations that alert people
+// when scheduled jobs fail.
+package cronnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultSlackTemplate is the message Slack sends when no Template is set.
+// It is executed with the cron.Notification.
+var DefaultSlackTemplate = template.Must(template.New("slack").Parse(
+	`{{if .Recovered}}:white_check_mark: *{{.Name}}* recovered after {{.Duration}}` +
+		`{{else}}:x: *{{.Name}}* failed after {{.Duration}}: {{.Err}}{{end}}`))
+
+// Slack posts notifications to a Slack incoming webhook.
+//
+//	c := cron.New(cron.WithChain(
+//		cron.NotifyOnFailure(logger, &cronnotify.Slack{WebhookURL: url}, true)))
+type Slack struct {
+	// WebhookURL is the incoming webhook to post to.
+	WebhookURL string
+
+	// Template renders the message text. It defaults to DefaultSlackTemplate.
+	Template *template.Template
+
+	// Client is used to post messages. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify posts the notification to the webhook.
+func (s *Slack) Notify(ctx context.Context, n cron.Notification) error {
+	tmpl := s.Template
+	if tmpl == nil {
+		tmpl = DefaultSlackTemplate
+	}
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, n); err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"text": text.String()})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(s.Client, req.WithContext(ctx))
+}
+
+// do sends the request, treating any non-2xx response as an error.
+func do(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL, resp.Status)
+	}
+	return nil
+}
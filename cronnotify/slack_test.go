@@ -0,0 +1,54 @@
+package cronnotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestSlack(t *testing.T) {
+	var texts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct{ Text string }
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Error(err)
+		}
+		texts = append(texts, msg.Text)
+	}))
+	defer srv.Close()
+
+	s := &Slack{WebhookURL: srv.URL}
+	ctx := context.Background()
+	if err := s.Notify(ctx, cron.Notification{Name: "sync", Duration: time.Second, Err: errors.New("boom")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Notify(ctx, cron.Notification{Name: "sync", Duration: time.Second, Recovered: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		":x: *sync* failed after 1s: boom",
+		":white_check_mark: *sync* recovered after 1s",
+	}
+	if len(texts) != 2 || texts[0] != want[0] || texts[1] != want[1] {
+		t.Errorf("expected %q, got %q", want, texts)
+	}
+}
+
+func TestSlackError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := &Slack{WebhookURL: srv.URL}
+	if err := s.Notify(context.Background(), cron.Notification{Name: "sync"}); err == nil {
+		t.Error("expected an error for a 404 response")
+	}
+}
@@ -0,0 +1,137 @@
+// Package cronprom exposes cron entry statistics as Prometheus metrics, either
+// for scraping or pushed to a Pushgateway.
+//
+// Batch binaries that start, run their due jobs and exit can push their final
+// metrics on shutdown rather than serving a scrape endpoint:
+//
+//	c := cron.New()
+//	...
+//	c.Start()
+//	<-shutdown
+//	if err := cronprom.StopAndPush(c, "http://pushgateway:9091", "nightly-batch"); err != nil {
+//		log.Print(err)
+//	}
+//
+// The package writes the Prometheus text exposition format itself, so it does
+// not depend on the Prometheus client library.
+package cronprom
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// WriteMetrics writes the statistics in the Prometheus text exposition format.
+// Every series is labelled with the entry's ID and name.
+func WriteMetrics(w io.Writer, stats []cron.EntryStats) error {
+	var buf bytes.Buffer
+	family := func(name, typ, help string, value func(cron.EntryStats) (float64, bool), extra string) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+		for _, st := range stats {
+			if v, ok := value(st); ok {
+				fmt.Fprintf(&buf, "%s{entry=\"%d\",name=%s%s} %s\n",
+					name, st.Entry, quote(st.Name), extra, strconv.FormatFloat(v, 'g', -1, 64))
+			}
+		}
+	}
+	timestamp := func(t time.Time) (float64, bool) {
+		return float64(t.UnixNano()) / 1e9, !t.IsZero()
+	}
+
+	family("cron_entry_runs_total", "counter", "Completed runs of the entry.",
+		func(st cron.EntryStats) (float64, bool) { return float64(st.Runs), true }, "")
+	family("cron_entry_failures_total", "counter", "Failed runs of the entry.",
+		func(st cron.EntryStats) (float64, bool) { return float64(st.Failures), true }, "")
+	family("cron_entry_last_run_timestamp_seconds", "gauge", "Time the entry last ran.",
+		func(st cron.EntryStats) (float64, bool) { return timestamp(st.Prev) }, "")
+	family("cron_entry_next_run_timestamp_seconds", "gauge", "Time the entry will next run.",
+		func(st cron.EntryStats) (float64, bool) { return timestamp(st.Next) }, "")
+	family("cron_entry_overrun", "gauge", "Runs of the entry still in flight when it last fired.",
+		func(st cron.EntryStats) (float64, bool) { return float64(st.Overrun), true }, "")
+	family("cron_entry_overruns_total", "counter", "Firings of the entry that found runs of it still in flight.",
+		func(st cron.EntryStats) (float64, bool) { return float64(st.Overruns), true }, "")
+	family("cron_entry_interval_ratio", "gauge", "Mean duration of the entry's recent runs over its interval.",
+		func(st cron.EntryStats) (float64, bool) { return st.IntervalRatio, st.IntervalRatio > 0 }, "")
+	quantiles := func(name, help string, q50, q95, q99 func(cron.EntryStats) time.Duration) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", name)
+		for _, st := range stats {
+			if st.Runs == 0 {
+				continue
+			}
+			for _, q := range []struct {
+				quantile string
+				d        time.Duration
+			}{{"0.5", q50(st)}, {"0.95", q95(st)}, {"0.99", q99(st)}} {
+				fmt.Fprintf(&buf, "%s{entry=\"%d\",name=%s,quantile=\"%s\"} %s\n",
+					name, st.Entry, quote(st.Name), q.quantile, strconv.FormatFloat(q.d.Seconds(), 'g', -1, 64))
+			}
+		}
+	}
+	quantiles("cron_entry_duration_seconds", "Duration percentiles of the entry's recent runs.",
+		func(st cron.EntryStats) time.Duration { return st.DurationP50 },
+		func(st cron.EntryStats) time.Duration { return st.DurationP95 },
+		func(st cron.EntryStats) time.Duration { return st.DurationP99 })
+	quantiles("cron_entry_skew_seconds", "Percentiles of how late the entry's recent runs started.",
+		func(st cron.EntryStats) time.Duration { return st.SkewP50 },
+		func(st cron.EntryStats) time.Duration { return st.SkewP95 },
+		func(st cron.EntryStats) time.Duration { return st.SkewP99 })
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Handler returns an HTTP handler serving c's metrics for scraping.
+func Handler(c *cron.Cron) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		WriteMetrics(w, c.Stats())
+	})
+}
+
+// Push replaces the metrics of the given job in the Pushgateway at
+// gatewayURL with c's current metrics.
+func Push(ctx context.Context, c *cron.Cron, gatewayURL, job string) error {
+	var body bytes.Buffer
+	if err := WriteMetrics(&body, c.Stats()); err != nil {
+		return err
+	}
+	u := strings.TrimRight(gatewayURL, "/") + "/metrics/job/" + url.PathEscape(job)
+	req, err := http.NewRequest(http.MethodPut, u, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("push to %s: %s", u, resp.Status)
+	}
+	return nil
+}
+
+// StopAndPush stops c, waits for its running jobs to complete, and then
+// pushes its final metrics to the Pushgateway.
+func StopAndPush(c *cron.Cron, gatewayURL, job string) error {
+	<-c.Stop().Done()
+	return Push(context.Background(), c, gatewayURL, job)
+}
+
+// quote returns s as a quoted Prometheus label value.
+func quote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
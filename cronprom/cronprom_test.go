@@ -0,0 +1,88 @@
+package cronprom
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	stats := []cron.EntryStats{{
+		Entry:       1,
+		Name:        `say "hi"`,
+		Prev:        time.Unix(1598608800, 0),
+		Runs:        4,
+		Failures:    1,
+		DurationP50: 1500 * time.Millisecond,
+		DurationP95: 2 * time.Second,
+		DurationP99: 2 * time.Second,
+		SkewP99:     3 * time.Millisecond,
+
+		Overrun:       2,
+		Overruns:      5,
+		IntervalRatio: 0.25,
+	}, {
+		Entry: 2,
+	}}
+	var buf bytes.Buffer
+	if err := WriteMetrics(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE cron_entry_runs_total counter\n",
+		`cron_entry_runs_total{entry="1",name="say \"hi\""} 4` + "\n",
+		`cron_entry_failures_total{entry="2",name=""} 0` + "\n",
+		`cron_entry_last_run_timestamp_seconds{entry="1",name="say \"hi\""} 1.5986088e+09` + "\n",
+		`cron_entry_duration_seconds{entry="1",name="say \"hi\"",quantile="0.5"} 1.5` + "\n",
+		`cron_entry_skew_seconds{entry="1",name="say \"hi\"",quantile="0.99"} 0.003` + "\n",
+		`cron_entry_overrun{entry="1",name="say \"hi\""} 2` + "\n",
+		`cron_entry_overruns_total{entry="2",name=""} 0` + "\n",
+		`cron_entry_interval_ratio{entry="1",name="say \"hi\""} 0.25` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `cron_entry_last_run_timestamp_seconds{entry="2"`) ||
+		strings.Contains(out, `cron_entry_duration_seconds{entry="2"`) ||
+		strings.Contains(out, `cron_entry_interval_ratio{entry="2"`) {
+		t.Errorf("expected no timestamps or durations for an entry that never ran:\n%s", out)
+	}
+}
+
+func TestStopAndPush(t *testing.T) {
+	var method, path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(b)
+	}))
+	defer srv.Close()
+
+	c := cron.New()
+	c.AddFunc("@hourly", func() {}, cron.WithName("sync"))
+	c.Start()
+	if err := StopAndPush(c, srv.URL+"/", "batch"); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || path != "/metrics/job/batch" ||
+		!strings.Contains(body, `cron_entry_runs_total{entry="1",name="sync"} 0`) {
+		t.Errorf("unexpected push %s %s:\n%s", method, path, body)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := cron.New()
+	c.AddFunc("@hourly", func() {})
+	rec := httptest.NewRecorder()
+	Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "cron_entry_runs_total") {
+		t.Errorf("unexpected metrics:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,269 @@
+// Package cronquick generates random, valid cron specs together with the
+// times they should match, for property-testing code that consumes
+// schedules:
+//
+//	g := cronquick.NewGenerator(cron.Minute|cron.Hour|cron.Dom|cron.Month|cron.Dow, rand.New(rand.NewSource(1)))
+//	for i := 0; i < 1000; i++ {
+//		spec := g.Spec()
+//		next := myScheduler.Next(spec.Text, now)
+//		if !spec.Matches(next) {
+//			t.Errorf("%s: %v does not match", spec.Text, next)
+//		}
+//	}
+//
+// Spec also implements quick.Generator, producing standard specs, so it may
+// be used directly as an argument of a function given to quick.Check.
+//
+// The meaning of a spec is worked out independently of the cron package's
+// parser, following its documented rules, so that the two may be checked
+// against each other. "@every" descriptors are not generated, since they do
+// not match fixed times.
+package cronquick
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Standard are the options of cron.ParseStandard.
+const Standard = cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor
+
+// Field positions within Spec.Fields.
+const (
+	Second = iota
+	Minute
+	Hour
+	Dom
+	Month
+	Dow
+)
+
+// Field is the meaning of one field of a spec.
+type Field struct {
+	// Bits has bit v set if the field matches the value v.
+	Bits uint64
+
+	// Star is whether the field was written with "*" or "?", without a step
+	// greater than one. If either of the day fields is a star, a day must
+	// match both; otherwise it need only match one.
+	Star bool
+}
+
+// Has reports whether the field matches the value v.
+func (f Field) Has(v int) bool {
+	return f.Bits&(1<<uint(v)) != 0
+}
+
+// Spec is a generated spec and its meaning.
+type Spec struct {
+	// Text is the spec, as given to a parser.
+	Text string
+
+	// Options are the options of the parser the spec was generated for.
+	Options cron.ParseOption
+
+	// Fields are the meanings of the second, minute, hour, day of month,
+	// month and day of week fields, including those that were left out and
+	// take their defaults.
+	Fields [6]Field
+}
+
+// Matches reports whether the spec activates at t, to the second, in t's
+// location.
+func (s Spec) Matches(t time.Time) bool {
+	f := s.Fields
+	if !f[Second].Has(t.Second()) || !f[Minute].Has(t.Minute()) || !f[Hour].Has(t.Hour()) ||
+		!f[Month].Has(int(t.Month())) {
+		return false
+	}
+	dom, dow := f[Dom].Has(t.Day()), f[Dow].Has(int(t.Weekday()))
+	if f[Dom].Star || f[Dow].Star {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Parse parses the spec with a parser for its options.
+func (s Spec) Parse() (cron.Schedule, error) {
+	return cron.NewParser(s.Options).Parse(s.Text)
+}
+
+// Generate returns a random standard spec, implementing quick.Generator.
+func (Spec) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(NewGenerator(Standard, r).Spec())
+}
+
+// Generator generates random specs accepted by a parser with its options.
+type Generator struct {
+	Options cron.ParseOption
+	rand    *rand.Rand
+}
+
+// NewGenerator returns a Generator of specs for the parser options, drawing
+// from r.
+func NewGenerator(options cron.ParseOption, r *rand.Rand) *Generator {
+	return &Generator{Options: options, rand: r}
+}
+
+type bounds struct {
+	min, max int
+	names    []string // names[v-min] is the name of v, if any
+}
+
+var fieldBounds = [6]bounds{
+	{0, 59, nil},
+	{0, 59, nil},
+	{0, 23, nil},
+	{1, 31, nil},
+	{1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{0, 6, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// defaults are the meanings of fields left out of a spec.
+var defaults = [6]string{"0", "0", "0", "*", "*", "*"}
+
+var descriptors = []struct {
+	text   string
+	fields [6]string
+}{
+	{"@yearly", [6]string{"0", "0", "0", "1", "1", "*"}},
+	{"@annually", [6]string{"0", "0", "0", "1", "1", "*"}},
+	{"@monthly", [6]string{"0", "0", "0", "1", "*", "*"}},
+	{"@weekly", [6]string{"0", "0", "0", "*", "*", "0"}},
+	{"@daily", [6]string{"0", "0", "0", "*", "*", "*"}},
+	{"@midnight", [6]string{"0", "0", "0", "*", "*", "*"}},
+	{"@hourly", [6]string{"0", "0", "*", "*", "*", "*"}},
+}
+
+// Spec returns a random spec.
+func (g *Generator) Spec() Spec {
+	s := Spec{Options: g.Options}
+	if g.Options&cron.Descriptor != 0 && g.rand.Intn(8) == 0 {
+		d := descriptors[g.rand.Intn(len(descriptors))]
+		s.Text = d.text
+		for i, text := range d.fields {
+			s.Fields[i] = meaning(text, fieldBounds[i])
+		}
+		return s
+	}
+
+	var texts []string
+	for i := range s.Fields {
+		text := defaults[i]
+		if g.included(i) {
+			text = g.field(i)
+			texts = append(texts, text)
+		}
+		s.Fields[i] = meaning(text, fieldBounds[i])
+	}
+	s.Text = strings.Join(texts, " ")
+	return s
+}
+
+// included reports whether the spec should have the field at position i.
+func (g *Generator) included(i int) bool {
+	place := [6]cron.ParseOption{cron.Second, cron.Minute, cron.Hour, cron.Dom, cron.Month, cron.Dow}[i]
+	switch {
+	case g.Options&place != 0:
+		return true
+	case i == Second && g.Options&cron.SecondOptional != 0,
+		i == Dow && g.Options&cron.DowOptional != 0:
+		return g.rand.Intn(2) == 0
+	}
+	return false
+}
+
+// field returns the text of a random field at position i: a list of one to
+// three ranges.
+func (g *Generator) field(i int) string {
+	parts := make([]string, 1+g.rand.Intn(3))
+	for j := range parts {
+		parts[j] = g.part(i)
+	}
+	return strings.Join(parts, ",")
+}
+
+// part returns the text of a random range of the field at position i.
+func (g *Generator) part(i int) string {
+	b := fieldBounds[i]
+	n := b.max - b.min + 1
+	step := func() string { return "/" + strconv.Itoa(1+g.rand.Intn(n)) }
+	switch g.rand.Intn(6) {
+	case 0:
+		if (i == Dom || i == Dow) && g.rand.Intn(2) == 0 {
+			return "?"
+		}
+		return "*"
+	case 1:
+		return "*" + step()
+	case 2:
+		lo := b.min + g.rand.Intn(n)
+		hi := lo + g.rand.Intn(b.max-lo+1)
+		return g.value(b, lo) + "-" + g.value(b, hi)
+	case 3:
+		lo := b.min + g.rand.Intn(n)
+		hi := lo + g.rand.Intn(b.max-lo+1)
+		return g.value(b, lo) + "-" + g.value(b, hi) + step()
+	case 4:
+		return g.value(b, b.min+g.rand.Intn(n)) + step()
+	}
+	return g.value(b, b.min+g.rand.Intn(n))
+}
+
+// value returns v as a number, or sometimes as a name if it has one.
+func (g *Generator) value(b bounds, v int) string {
+	if b.names != nil && g.rand.Intn(2) == 0 {
+		name := b.names[v-b.min]
+		if g.rand.Intn(2) == 0 {
+			name = strings.ToLower(name)
+		}
+		return name
+	}
+	return strconv.Itoa(v)
+}
+
+// meaning works out the meaning of a generated field.
+func meaning(text string, b bounds) Field {
+	var f Field
+	for _, part := range strings.Split(text, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rng = part[:i]
+			step, _ = strconv.Atoi(part[i+1:])
+		}
+		lo, hi := b.min, b.max
+		switch {
+		case rng == "*" || rng == "?":
+			if step == 1 {
+				f.Star = true
+			}
+		case strings.Contains(rng, "-"):
+			i := strings.Index(rng, "-")
+			lo, hi = b.parse(rng[:i]), b.parse(rng[i+1:])
+		case step > 1 || strings.Contains(part, "/"):
+			lo = b.parse(rng) // "N/step" means "N-max/step"
+		default:
+			lo = b.parse(rng)
+			hi = lo
+		}
+		for v := lo; v <= hi; v += step {
+			f.Bits |= 1 << uint(v)
+		}
+	}
+	return f
+}
+
+func (b bounds) parse(s string) int {
+	for i, name := range b.names {
+		if strings.EqualFold(name, s) {
+			return b.min + i
+		}
+	}
+	v, _ := strconv.Atoi(s)
+	return v
+}
@@ -0,0 +1,66 @@
+package cronquick
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// The cron package's schedules should activate exactly when the generated
+// meanings say, to the minute, for standard specs.
+func TestStandard(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	check := func(s Spec, offset uint32) bool {
+		schedule, err := s.Parse()
+		if err != nil {
+			t.Logf("%q: %v", s.Text, err)
+			return false
+		}
+		start := from.Add(time.Duration(offset%(366*24*60)) * time.Minute)
+		next := schedule.Next(start)
+		if next.IsZero() {
+			return true // such as "0 0 31 2 *"
+		}
+		if !s.Matches(next) {
+			t.Logf("%q: %v does not match", s.Text, next)
+			return false
+		}
+		for m := start.Add(time.Minute); m.Before(next) && m.Sub(start) < 48*time.Hour; m = m.Add(time.Minute) {
+			if s.Matches(m) {
+				t.Logf("%q: %v skipped before %v", s.Text, m, next)
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(check, &quick.Config{MaxCount: 500, Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGeneratorOptions(t *testing.T) {
+	options := cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.DowOptional | cron.Descriptor
+	g := NewGenerator(options, rand.New(rand.NewSource(2)))
+	lengths := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		s := g.Spec()
+		schedule, err := s.Parse()
+		if err != nil {
+			t.Fatalf("%q: %v", s.Text, err)
+		}
+		next := schedule.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		if !next.IsZero() && !s.Matches(next) {
+			t.Errorf("%q: %v does not match", s.Text, next)
+		}
+		if s.Text[0] != '@' {
+			lengths[len(strings.Fields(s.Text))] = true
+		}
+	}
+	if !lengths[5] || !lengths[6] || lengths[4] {
+		t.Errorf("expected the optional field both given and left out, got lengths %v", lengths)
+	}
+}
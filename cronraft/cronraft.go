@@ -0,0 +1,214 @@
+// Package cronraft replicates a cron.Store across a cluster through a Raft
+// log, so that a group of schedulers shares strongly consistent state and
+// fires jobs only from the elected leader.
+//
+// The package does not depend on a Raft library. Each node runs an FSM as
+// its Raft state machine and a Store that proposes changes through the log.
+// With hashicorp/raft, the FSM is adapted in a few lines and the Node is:
+//
+//	type node struct{ r *raft.Raft }
+//
+//	func (n node) Apply(cmd []byte, timeout time.Duration) (interface{}, error) {
+//		f := n.r.Apply(cmd, timeout)
+//		if err := f.Error(); err != nil {
+//			return nil, err
+//		}
+//		return f.Response(), nil
+//	}
+//
+//	func (n node) IsLeader() bool { return n.r.State() == raft.Leader }
+//
+// The Store is also a cron.Elector, so the same cluster decides which node
+// fires jobs:
+//
+//	fsm := cronraft.NewFSM()
+//	// ... start Raft with fsm ...
+//	store := cronraft.NewStore(node{r}, fsm, 5*time.Second)
+//	c := cron.New(cron.WithElector(store))
+package cronraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ErrNotLeader is returned by Store writes on nodes that are not the leader.
+var ErrNotLeader = errors.New("cronraft: not the leader")
+
+// Node is a member of a Raft cluster.
+type Node interface {
+	// Apply appends cmd to the replicated log, waits until it has been
+	// applied to the FSM, and returns the FSM's response.
+	Apply(cmd []byte, timeout time.Duration) (interface{}, error)
+
+	// IsLeader reports whether this node is the cluster's leader.
+	IsLeader() bool
+}
+
+// command is a change to the store, as recorded in the log.
+type command struct {
+	Op    string `json:"op"`
+	Key   string `json:"key"`
+	Value []byte `json:"value,omitempty"`
+	Old   []byte `json:"old"`
+}
+
+// FSM is the replicated state machine: the store's contents, built by
+// applying the log's commands in order.
+type FSM struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewFSM returns an empty FSM.
+func NewFSM() *FSM {
+	return &FSM{values: make(map[string][]byte)}
+}
+
+// Apply applies a committed log entry. It returns whether a compare-and-swap
+// succeeded, true for other commands, or an error for invalid entries.
+func (f *FSM) Apply(data []byte) interface{} {
+	var cmd command
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch cmd.Op {
+	case "put":
+		f.values[cmd.Key] = cmd.Value
+	case "delete":
+		delete(f.values, cmd.Key)
+	case "cas":
+		v, ok := f.values[cmd.Key]
+		if ok != (cmd.Old != nil) || string(v) != string(cmd.Old) {
+			return false
+		}
+		f.values[cmd.Key] = cmd.Value
+	default:
+		return errors.New("cronraft: unknown command " + cmd.Op)
+	}
+	return true
+}
+
+// Snapshot returns the FSM's contents, for compacting the log.
+func (f *FSM) Snapshot() ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return json.Marshal(f.values)
+}
+
+// Restore replaces the FSM's contents with a snapshot.
+func (f *FSM) Restore(data []byte) error {
+	values := make(map[string][]byte)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.values = values
+	f.mu.Unlock()
+	return nil
+}
+
+// Store is a cron.Store whose writes go through the Raft log and whose reads
+// come from the local FSM. Writes fail with ErrNotLeader on followers. Reads
+// on the leader see every write it has made; reads on followers may lag.
+type Store struct {
+	node    Node
+	fsm     *FSM
+	timeout time.Duration
+}
+
+// NewStore returns a Store that replicates writes through node, whose log is
+// applied to fsm, waiting up to timeout for each write.
+func NewStore(node Node, fsm *FSM, timeout time.Duration) *Store {
+	return &Store{node: node, fsm: fsm, timeout: timeout}
+}
+
+// IsLeader reports whether this node is the cluster's leader, making the
+// Store a cron.Elector.
+func (s *Store) IsLeader() bool {
+	return s.node.IsLeader()
+}
+
+// Get returns the value of key, or cron.ErrNotFound.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	s.fsm.mu.RLock()
+	defer s.fsm.mu.RUnlock()
+	v, ok := s.fsm.values[key]
+	if !ok {
+		return nil, cron.ErrNotFound
+	}
+	return append([]byte(nil), v...), nil
+}
+
+// Put sets the value of key.
+func (s *Store) Put(ctx context.Context, key string, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := s.apply(ctx, command{Op: "put", Key: key, Value: value})
+	return err
+}
+
+// CompareAndSwap sets the value of key to new if its current value is old.
+func (s *Store) CompareAndSwap(ctx context.Context, key string, old, new []byte) (bool, error) {
+	if new == nil {
+		new = []byte{}
+	}
+	ok, err := s.apply(ctx, command{Op: "cas", Key: key, Value: new, Old: old})
+	return ok, err
+}
+
+// Delete removes key.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	_, err := s.apply(ctx, command{Op: "delete", Key: key})
+	return err
+}
+
+// List returns the keys with the given prefix, in sorted order.
+func (s *Store) List(ctx context.Context, prefix string) ([]string, error) {
+	s.fsm.mu.RLock()
+	defer s.fsm.mu.RUnlock()
+	var keys []string
+	for k := range s.fsm.values {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// apply replicates cmd and returns whether the FSM applied it.
+func (s *Store) apply(ctx context.Context, cmd command) (bool, error) {
+	if !s.node.IsLeader() {
+		return false, ErrNotLeader
+	}
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return false, err
+	}
+	timeout := s.timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	resp, err := s.node.Apply(data, timeout)
+	if err != nil {
+		return false, err
+	}
+	switch resp := resp.(type) {
+	case error:
+		return false, resp
+	case bool:
+		return resp, nil
+	}
+	return true, nil
+}
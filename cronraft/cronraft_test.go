@@ -0,0 +1,107 @@
+package cronraft
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cluster is a Raft log that commits synchronously to every member's FSM.
+type cluster struct {
+	fsms   []*FSM
+	leader int
+}
+
+type node struct {
+	c  *cluster
+	id int
+}
+
+func (n node) Apply(cmd []byte, timeout time.Duration) (interface{}, error) {
+	if n.c.leader != n.id {
+		return nil, errors.New("not leader")
+	}
+	var resp interface{}
+	for i, fsm := range n.c.fsms {
+		if r := fsm.Apply(cmd); i == n.id {
+			resp = r
+		}
+	}
+	return resp, nil
+}
+
+func (n node) IsLeader() bool { return n.c.leader == n.id }
+
+func newCluster(size int) (*cluster, []*Store) {
+	c := &cluster{}
+	var stores []*Store
+	for i := 0; i < size; i++ {
+		c.fsms = append(c.fsms, NewFSM())
+		stores = append(stores, NewStore(node{c, i}, c.fsms[i], time.Second))
+	}
+	return c, stores
+}
+
+func TestStoreReplicates(t *testing.T) {
+	ctx := context.Background()
+	c, stores := newCluster(3)
+	leader, follower := stores[0], stores[1]
+	if !leader.IsLeader() || follower.IsLeader() {
+		t.Fatal("expected node 0 to lead")
+	}
+	if err := follower.Put(ctx, "a", []byte("1")); err != ErrNotLeader {
+		t.Errorf("expected ErrNotLeader, got %v", err)
+	}
+
+	leader.Put(ctx, "checkpoint/report", []byte("1"))
+	leader.Put(ctx, "checkpoint/sync", nil)
+	if ok, err := leader.CompareAndSwap(ctx, "checkpoint/report", []byte("1"), []byte("2")); !ok || err != nil {
+		t.Errorf("expected the swap to succeed, got %v, %v", ok, err)
+	}
+	if ok, _ := leader.CompareAndSwap(ctx, "checkpoint/sync", nil, []byte("x")); ok {
+		t.Error("expected the swap of a present key against nil to fail")
+	}
+	for i, s := range stores {
+		if v, err := s.Get(ctx, "checkpoint/report"); err != nil || string(v) != "2" {
+			t.Errorf("node %d: expected 2, got %q, %v", i, v, err)
+		}
+		if keys, _ := s.List(ctx, "checkpoint/"); !reflect.DeepEqual(keys, []string{"checkpoint/report", "checkpoint/sync"}) {
+			t.Errorf("node %d: unexpected keys %v", i, keys)
+		}
+	}
+
+	// Fail over to node 2.
+	c.leader = 2
+	if err := stores[2].Delete(ctx, "checkpoint/report"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stores[0].Get(ctx, "checkpoint/report"); err != cron.ErrNotFound {
+		t.Errorf("expected the delete replicated, got %v", err)
+	}
+}
+
+func TestFSMSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	_, stores := newCluster(1)
+	stores[0].Put(ctx, "a", []byte("1"))
+	snap, err := stores[0].fsm.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsm := NewFSM()
+	if err := fsm.Restore(snap); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := NewStore(nil, fsm, 0).Get(ctx, "a"); err != nil || string(v) != "1" {
+		t.Errorf("expected the snapshot restored, got %q, %v", v, err)
+	}
+	if resp := fsm.Apply([]byte(`{"op":"bogus"}`)); resp == nil {
+		t.Error("expected an error for an unknown command")
+	} else if _, ok := resp.(error); !ok {
+		t.Errorf("expected an error, got %v", resp)
+	}
+}
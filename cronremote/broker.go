@@ -0,0 +1,223 @@
+package cronremote
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxWait bounds how long a lease request is held open.
+const maxWait = 30 * time.Second
+
+// Broker is a cron.Dispatcher that holds firings until workers lease them.
+// It is an http.Handler serving the worker protocol.
+type Broker struct {
+	// OnComplete, if set, is called when a worker reports a firing complete.
+	OnComplete func(Completion)
+
+	leaseDuration time.Duration
+	logger        cron.Logger
+
+	mu     sync.Mutex
+	queue  []cron.Dispatch
+	leases map[string]*lease
+	wake   chan struct{}
+}
+
+// lease is a firing held by a worker.
+type lease struct {
+	Lease
+	worker string
+	start  time.Time
+}
+
+// NewBroker returns a Broker whose leases last for leaseDuration unless
+// renewed.
+func NewBroker(leaseDuration time.Duration, logger cron.Logger) *Broker {
+	return &Broker{
+		leaseDuration: leaseDuration,
+		logger:        logger,
+		leases:        make(map[string]*lease),
+		wake:          make(chan struct{}),
+	}
+}
+
+// Dispatch queues the firing for a worker to lease.
+func (b *Broker) Dispatch(d cron.Dispatch) error {
+	b.mu.Lock()
+	b.queue = append(b.queue, d)
+	b.wakeLocked()
+	b.mu.Unlock()
+	return nil
+}
+
+// Queued returns the firings waiting for a worker, oldest first.
+func (b *Broker) Queued() []cron.Dispatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expireLocked(time.Now())
+	return append([]cron.Dispatch(nil), b.queue...)
+}
+
+// ServeHTTP serves the lease, renew and complete requests.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case "/lease":
+		var req LeaseRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		l, ok := b.lease(r, req)
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, l)
+	case "/renew":
+		var req RenewRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		l, ok := b.renew(req.ID)
+		if !ok {
+			http.Error(w, "lease lost", http.StatusGone)
+			return
+		}
+		writeJSON(w, l)
+	case "/complete":
+		var req CompleteRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if !b.complete(req) {
+			http.Error(w, "lease lost", http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// decode reads the JSON request body into v, replying with an error if it
+// cannot.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// lease takes the oldest queued firing of one of the requested names,
+// waiting for one until the request's wait elapses or the client goes away.
+func (b *Broker) lease(r *http.Request, req LeaseRequest) (Lease, bool) {
+	wait := time.Duration(req.WaitMS) * time.Millisecond
+	if wait > maxWait {
+		wait = maxWait
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	names := make(map[string]bool, len(req.Names))
+	for _, name := range req.Names {
+		names[name] = true
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.expireLocked(now)
+		for i, d := range b.queue {
+			if !names[d.Name] {
+				continue
+			}
+			b.queue = append(b.queue[:i], b.queue[i+1:]...)
+			l := &lease{
+				Lease:  Lease{ID: newLeaseID(), Dispatch: d, Expires: now.Add(b.leaseDuration)},
+				worker: req.Worker,
+				start:  now,
+			}
+			b.leases[l.ID] = l
+			b.mu.Unlock()
+			b.logger.Info("lease", "name", d.Name, "worker", req.Worker, "lease", l.ID)
+			return l.Lease, true
+		}
+		wake := b.wake
+		b.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-timer.C:
+			return Lease{}, false
+		case <-r.Context().Done():
+			return Lease{}, false
+		}
+	}
+}
+
+// renew extends the lease, if it is still held.
+func (b *Broker) renew(id string) (Lease, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.expireLocked(now)
+	l, ok := b.leases[id]
+	if !ok {
+		return Lease{}, false
+	}
+	l.Expires = now.Add(b.leaseDuration)
+	return l.Lease, true
+}
+
+// complete releases the lease and reports the completion, if the lease is
+// still held.
+func (b *Broker) complete(req CompleteRequest) bool {
+	b.mu.Lock()
+	now := time.Now()
+	b.expireLocked(now)
+	l, ok := b.leases[req.ID]
+	delete(b.leases, req.ID)
+	b.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if b.OnComplete != nil {
+		b.OnComplete(Completion{Dispatch: l.Dispatch, Worker: l.worker, Duration: now.Sub(l.start), Err: req.Error})
+	}
+	return true
+}
+
+// expireLocked returns the firings of expired leases to the front of the
+// queue, oldest first.
+func (b *Broker) expireLocked(now time.Time) {
+	var expired []cron.Dispatch
+	for id, l := range b.leases {
+		if now.After(l.Expires) {
+			b.logger.Info("lease expired", "name", l.Dispatch.Name, "worker", l.worker, "lease", id)
+			expired = append(expired, l.Dispatch)
+			delete(b.leases, id)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].Scheduled.Before(expired[j].Scheduled)
+	})
+	b.queue = append(expired, b.queue...)
+	b.wakeLocked()
+}
+
+// wakeLocked wakes the lease requests waiting for a firing.
+func (b *Broker) wakeLocked() {
+	close(b.wake)
+	b.wake = make(chan struct{})
+}
@@ -0,0 +1,83 @@
+package cronremote
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func postJSON(t *testing.T, h http.Handler, path string, v interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(v)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", path, bytes.NewReader(body)))
+	return rec
+}
+
+func TestBrokerLeaseComplete(t *testing.T) {
+	b := NewBroker(time.Minute, cron.DiscardLogger)
+	var completions []Completion
+	b.OnComplete = func(c Completion) { completions = append(completions, c) }
+
+	b.Dispatch(cron.Dispatch{Entry: 1, Name: "report"})
+	b.Dispatch(cron.Dispatch{Entry: 2, Name: "sync"})
+
+	rec := postJSON(t, b, "/lease", LeaseRequest{Worker: "w1", Names: []string{"sync"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a lease, got %d", rec.Code)
+	}
+	var l Lease
+	json.NewDecoder(rec.Body).Decode(&l)
+	if l.Dispatch.Name != "sync" || l.ID == "" {
+		t.Errorf("expected a lease on sync, got %+v", l)
+	}
+	if rec := postJSON(t, b, "/lease", LeaseRequest{Worker: "w1", Names: []string{"sync"}}); rec.Code != http.StatusNoContent {
+		t.Errorf("expected no content, got %d", rec.Code)
+	}
+
+	if rec := postJSON(t, b, "/renew", RenewRequest{ID: l.ID}); rec.Code != http.StatusOK {
+		t.Errorf("expected the lease renewed, got %d", rec.Code)
+	}
+	if rec := postJSON(t, b, "/complete", CompleteRequest{ID: l.ID, Error: "boom"}); rec.Code != http.StatusNoContent {
+		t.Errorf("expected the lease completed, got %d", rec.Code)
+	}
+	if len(completions) != 1 || completions[0].Worker != "w1" || completions[0].Err != "boom" {
+		t.Errorf("unexpected completions: %+v", completions)
+	}
+	if rec := postJSON(t, b, "/complete", CompleteRequest{ID: l.ID}); rec.Code != http.StatusGone {
+		t.Errorf("expected the lease gone, got %d", rec.Code)
+	}
+	if q := b.Queued(); len(q) != 1 || q[0].Name != "report" {
+		t.Errorf("expected report still queued, got %+v", q)
+	}
+}
+
+func TestBrokerLeaseExpires(t *testing.T) {
+	b := NewBroker(time.Millisecond, cron.DiscardLogger)
+	b.Dispatch(cron.Dispatch{Entry: 1, Name: "report"})
+	var l Lease
+	json.NewDecoder(postJSON(t, b, "/lease", LeaseRequest{Names: []string{"report"}}).Body).Decode(&l)
+
+	time.Sleep(5 * time.Millisecond)
+	if rec := postJSON(t, b, "/renew", RenewRequest{ID: l.ID}); rec.Code != http.StatusGone {
+		t.Errorf("expected the lease gone, got %d", rec.Code)
+	}
+	if q := b.Queued(); len(q) != 1 || q[0].Name != "report" {
+		t.Errorf("expected the firing requeued, got %+v", q)
+	}
+}
+
+func TestBrokerLeaseWaits(t *testing.T) {
+	b := NewBroker(time.Minute, cron.DiscardLogger)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.Dispatch(cron.Dispatch{Entry: 1, Name: "report"})
+	}()
+	if rec := postJSON(t, b, "/lease", LeaseRequest{Names: []string{"report"}, WaitMS: 5000}); rec.Code != http.StatusOK {
+		t.Errorf("expected a lease once dispatched, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,89 @@
+// Package cronremote leases cron firings to remote workers over HTTP.
+//
+// The scheduler process uses a Broker as its cron.Dispatcher and serves it
+// over HTTP. Due firings wait in the broker until a worker leases one,
+// runs the named job, and reports completion. A worker that stops renewing
+// its lease, for example because it crashed, loses it and the firing is
+// leased to another worker, so each firing runs at least once:
+//
+//	// Scheduler
+//	b := cronremote.NewBroker(time.Minute, cron.DefaultLogger)
+//	c := cron.New(cron.WithDispatcher(b))
+//	c.AddFunc("@hourly", func() {}, cron.WithName("report"))
+//	c.Start()
+//	http.ListenAndServe(":8080", b)
+//
+//	// Worker
+//	w := cronremote.NewWorker("http://scheduler:8080", "worker-1", cron.DefaultLogger)
+//	w.Handle("report", cron.FuncJob(sendReport))
+//	w.Run(ctx)
+//
+// The protocol is three JSON POST requests, relative to the broker's URL:
+//
+//	/lease     {"worker", "names", "wait_ms"} -> 200 Lease, or 204 if none is due
+//	/renew     {"id"}                         -> 200 Lease, or 410 if lost
+//	/complete  {"id", "error"}                -> 204, or 410 if lost
+package cronremote
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Lease grants a worker a firing until it expires.
+type Lease struct {
+	// ID identifies the lease in renew and complete requests.
+	ID string `json:"id"`
+
+	// Dispatch is the firing to execute.
+	Dispatch cron.Dispatch `json:"dispatch"`
+
+	// Expires is when the lease is lost unless it is renewed.
+	Expires time.Time `json:"expires"`
+}
+
+// LeaseRequest asks for a firing of one of the named entries, waiting up to
+// WaitMS milliseconds for one to become due.
+type LeaseRequest struct {
+	Worker string   `json:"worker"`
+	Names  []string `json:"names"`
+	WaitMS int64    `json:"wait_ms,omitempty"`
+}
+
+// RenewRequest extends a lease.
+type RenewRequest struct {
+	ID string `json:"id"`
+}
+
+// CompleteRequest reports that a leased firing has finished. Error is empty
+// if it succeeded.
+type CompleteRequest struct {
+	ID    string `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+// Completion describes a firing reported complete by a worker.
+type Completion struct {
+	Dispatch cron.Dispatch
+	Worker   string
+	Duration time.Duration
+	Err      string
+}
+
+// newLeaseID returns a random lease ID.
+func newLeaseID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// writeJSON writes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,194 @@
+package cronremote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// errLost reports that the broker no longer holds a lease.
+var errLost = fmt.Errorf("lease lost")
+
+// Worker leases firings from a Broker and runs their jobs.
+type Worker struct {
+	// Client is used for requests to the broker. It defaults to
+	// http.DefaultClient.
+	Client *http.Client
+
+	// Wait is how long each lease request waits for a firing. It defaults to
+	// 30 seconds.
+	Wait time.Duration
+
+	// RetryInterval is how long to wait after a failed request to the broker.
+	// It defaults to 5 seconds.
+	RetryInterval time.Duration
+
+	url    string
+	id     string
+	logger cron.Logger
+	mu     sync.Mutex
+	jobs   map[string]cron.Job
+}
+
+// NewWorker returns a Worker with no jobs registered that leases from the
+// broker at url, identifying itself as id.
+func NewWorker(url, id string, logger cron.Logger) *Worker {
+	return &Worker{
+		url:    strings.TrimSuffix(url, "/"),
+		id:     id,
+		logger: logger,
+		jobs:   make(map[string]cron.Job),
+	}
+}
+
+// Handle registers the job to run for firings of the named entry. If the job
+// is a cron.PayloadJob, such as a cron.TypedJob, it runs with the payload sent
+// with each firing.
+func (w *Worker) Handle(name string, j cron.Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.jobs[name] = j
+}
+
+// Run leases and runs firings of the registered jobs one at a time until ctx
+// is done, and then returns ctx's error. Run it from several goroutines to
+// run several firings at once.
+func (w *Worker) Run(ctx context.Context) error {
+	for {
+		_, err := w.RunOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			continue
+		}
+		w.logger.Error(err, "lease")
+		retry := w.RetryInterval
+		if retry == 0 {
+			retry = 5 * time.Second
+		}
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RunOnce leases a single firing and runs its job, renewing the lease while
+// the job runs and reporting its completion. It returns false if no firing
+// became due within the worker's Wait. If the lease is lost while the job
+// runs, the job's context is canceled. A panic in the job is recovered and
+// reported as its error.
+func (w *Worker) RunOnce(ctx context.Context) (bool, error) {
+	w.mu.Lock()
+	names := make([]string, 0, len(w.jobs))
+	for name := range w.jobs {
+		names = append(names, name)
+	}
+	w.mu.Unlock()
+	wait := w.Wait
+	if wait == 0 {
+		wait = maxWait
+	}
+
+	var l Lease
+	ok, err := w.post(ctx, "/lease", LeaseRequest{Worker: w.id, Names: names, WaitMS: int64(wait / time.Millisecond)}, &l)
+	if err != nil || !ok {
+		return false, err
+	}
+	w.mu.Lock()
+	j := w.jobs[l.Dispatch.Name]
+	w.mu.Unlock()
+
+	runCtx, cancel := context.WithCancel(cron.ContextWithScheduledTime(ctx, l.Dispatch.Scheduled))
+	defer cancel()
+	done := make(chan struct{})
+	go w.renew(runCtx, cancel, l, done)
+	w.logger.Info("run", "name", l.Dispatch.Name, "scheduled", l.Dispatch.Scheduled, "lease", l.ID)
+	var jobErr error
+	if pj, ok := j.(cron.PayloadJob); ok && len(l.Dispatch.Payload) > 0 {
+		j, jobErr = pj.WithPayload(l.Dispatch.Payload)
+	}
+	if jobErr == nil {
+		jobErr = cron.RunJob(runCtx, cron.Recover(w.logger)(j))
+	}
+	close(done)
+
+	req := CompleteRequest{ID: l.ID}
+	if jobErr != nil {
+		req.Error = jobErr.Error()
+	}
+	if _, err := w.post(ctx, "/complete", req, nil); err != nil {
+		return true, fmt.Errorf("completing %s: %v", l.Dispatch.Name, err)
+	}
+	return true, nil
+}
+
+// renew extends the lease halfway to its expiry until done is closed,
+// canceling the run if the lease is lost.
+func (w *Worker) renew(ctx context.Context, cancel context.CancelFunc, l Lease, done <-chan struct{}) {
+	for {
+		timer := time.NewTimer(time.Until(l.Expires) / 2)
+		select {
+		case <-done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		_, err := w.post(ctx, "/renew", RenewRequest{ID: l.ID}, &l)
+		if err == errLost {
+			w.logger.Error(err, "renew", "name", l.Dispatch.Name, "lease", l.ID)
+			cancel()
+			return
+		}
+		if err != nil {
+			w.logger.Error(err, "renew", "name", l.Dispatch.Name, "lease", l.ID)
+		}
+	}
+}
+
+// post sends req to the broker and decodes the response into resp. It returns
+// false if the broker had no content, and errLost if the lease is gone.
+func (w *Worker) post(ctx context.Context, path string, req, resp interface{}) (bool, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, w.url+path, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNoContent:
+		return false, nil
+	case res.StatusCode == http.StatusGone:
+		return false, errLost
+	case res.StatusCode != http.StatusOK:
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 512))
+		return false, fmt.Errorf("%s: %s: %s", path, res.Status, bytes.TrimSpace(msg))
+	}
+	if resp == nil {
+		return true, nil
+	}
+	return true, json.NewDecoder(res.Body).Decode(resp)
+}
@@ -0,0 +1,97 @@
+package cronremote
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestWorker(t *testing.T) {
+	b := NewBroker(20*time.Millisecond, cron.DiscardLogger)
+	completed := make(chan Completion, 1)
+	b.OnComplete = func(c Completion) { completed <- c }
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	w := NewWorker(srv.URL, "w1", cron.DiscardLogger)
+	w.Wait = time.Second
+	w.Handle("report", cron.FuncContextJob(func(ctx context.Context) error {
+		// Outlive the lease duration so that it must be renewed.
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("boom")
+	}))
+
+	b.Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: time.Now()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	select {
+	case comp := <-completed:
+		if comp.Dispatch.Name != "report" || comp.Worker != "w1" || comp.Err != "boom" {
+			t.Errorf("unexpected completion: %+v", comp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the firing completed")
+	}
+	if q := b.Queued(); len(q) != 0 {
+		t.Errorf("expected the renewed lease not requeued, got %+v", q)
+	}
+}
+
+func TestWorkerPayloadAndScheduledTime(t *testing.T) {
+	b := NewBroker(time.Minute, cron.DiscardLogger)
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	got := make(chan int, 1)
+	scheduled := time.Now().Add(-time.Hour).Truncate(time.Second)
+	w := NewWorker(srv.URL, "w1", cron.DiscardLogger)
+	w.Wait = time.Second
+	w.Handle("report", cron.NewTypedJob(0, func(ctx context.Context, n int) error {
+		if s, _ := cron.ScheduledTimeFromContext(ctx); !s.Equal(scheduled) {
+			t.Errorf("expected the scheduled time %v, got %v", scheduled, s)
+		}
+		got <- n
+		return nil
+	}))
+	b.Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: scheduled, Payload: []byte("42")})
+
+	if ok, err := w.RunOnce(context.Background()); !ok || err != nil {
+		t.Fatalf("expected a firing run, got %v, %v", ok, err)
+	}
+	if n := <-got; n != 42 {
+		t.Errorf("expected the dispatched payload, got %d", n)
+	}
+}
+
+// A panicking job is reported as failed instead of crashing the worker.
+func TestWorkerPanic(t *testing.T) {
+	b := NewBroker(time.Minute, cron.DiscardLogger)
+	completed := make(chan Completion, 1)
+	b.OnComplete = func(c Completion) { completed <- c }
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+
+	w := NewWorker(srv.URL, "w1", cron.DiscardLogger)
+	w.Wait = time.Second
+	w.Handle("report", cron.FuncJob(func() { panic("boom") }))
+	b.Dispatch(cron.Dispatch{Entry: 1, Name: "report", Scheduled: time.Now()})
+
+	if ok, err := w.RunOnce(context.Background()); !ok || err != nil {
+		t.Fatalf("expected a firing run, got %v, %v", ok, err)
+	}
+	select {
+	case comp := <-completed:
+		if comp.Err != "boom" {
+			t.Errorf("expected the panic reported, got %+v", comp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the firing completed")
+	}
+}
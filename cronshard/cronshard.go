@@ -0,0 +1,167 @@
+// Package cronshard splits a schedule's entries across a group of processes
+// with consistent hashing.
+//
+// Every process runs the same schedule with a Ring as its cron.Sharder, and
+// fires only the entries that hash to it. When a process joins or leaves the
+// group, only the entries on its part of the ring move:
+//
+//	r := cronshard.NewRing("host-a", 0)
+//	c := cron.New(cron.WithSharder(r))
+//	c.AddFunc("@hourly", report, cron.WithName("report"))
+//	c.Start()
+//	go r.Watch(ctx, members, 10*time.Second, cron.DefaultLogger)
+//
+// Entries are hashed by name, so every process must give its entries the
+// same names. Unnamed entries are hashed by ID.
+package cronshard
+
+import (
+	"context"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultReplicas is the number of points each member has on the ring.
+const DefaultReplicas = 100
+
+// Ring is a consistent-hash ring of group members. It is a cron.Sharder for
+// the member it was created for.
+type Ring struct {
+	self     string
+	replicas int
+
+	mu      sync.RWMutex
+	members []string
+	points  []uint32
+	owners  map[uint32]string
+}
+
+// NewRing returns a ring for the member self, which starts out as the only
+// member. Each member is given replicas points on the ring, or
+// DefaultReplicas if replicas is zero.
+func NewRing(self string, replicas int) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	r := &Ring{self: self, replicas: replicas}
+	r.SetMembers([]string{self})
+	return r
+}
+
+// SetMembers replaces the members of the group.
+func (r *Ring) SetMembers(members []string) {
+	members = append([]string(nil), members...)
+	sort.Strings(members)
+	points := make([]uint32, 0, len(members)*r.replicas)
+	owners := make(map[uint32]string, len(members)*r.replicas)
+	for _, m := range members {
+		for i := 0; i < r.replicas; i++ {
+			p := crc32.ChecksumIEEE([]byte(strconv.Itoa(i) + "-" + m))
+			if _, taken := owners[p]; taken {
+				continue
+			}
+			owners[p] = m
+			points = append(points, p)
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+
+	r.mu.Lock()
+	r.members, r.points, r.owners = members, points, owners
+	r.mu.Unlock()
+}
+
+// Members returns the members of the group, in sorted order.
+func (r *Ring) Members() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.members...)
+}
+
+// Owner returns the member that owns key, or "" if the group is empty.
+func (r *Ring) Owner(key string) string {
+	h := crc32.ChecksumIEEE([]byte(key))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return ""
+	}
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// Owns reports whether this ring's member owns the entry.
+func (r *Ring) Owns(e cron.Entry) bool {
+	return r.Owner(Key(e)) == r.self
+}
+
+// Key returns the key that an entry is hashed by: its name, or its ID if it
+// has none.
+func Key(e cron.Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return strconv.Itoa(int(e.ID))
+}
+
+// Membership lists the live members of a group, for example from a service
+// registry or the endpoints of a Kubernetes Service.
+type Membership interface {
+	Members(ctx context.Context) ([]string, error)
+}
+
+// MembershipFunc is an adapter to allow the use of ordinary functions as
+// Membership.
+type MembershipFunc func(ctx context.Context) ([]string, error)
+
+// Members calls f(ctx).
+func (f MembershipFunc) Members(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// Watch polls m every interval until ctx is done, rebalancing the ring when
+// the membership changes. Errors are logged and leave the ring unchanged.
+func (r *Ring) Watch(ctx context.Context, m Membership, interval time.Duration, logger cron.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		members, err := m.Members(ctx)
+		if err != nil {
+			logger.Error(err, "list members")
+		} else if !equal(sorted(members), r.Members()) {
+			r.SetMembers(members)
+			logger.Info("rebalance", "members", members)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func sorted(s []string) []string {
+	s = append([]string(nil), s...)
+	sort.Strings(s)
+	return s
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package cronshard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestRingSingleMember(t *testing.T) {
+	r := NewRing("a", 0)
+	for i := 0; i < 100; i++ {
+		if !r.Owns(cron.Entry{Name: fmt.Sprint("job", i)}) {
+			t.Fatal("expected the only member to own every entry")
+		}
+	}
+}
+
+func TestRingBalanceAndMovement(t *testing.T) {
+	ring := func(self string, members ...string) *Ring {
+		r := NewRing(self, 0)
+		r.SetMembers(members)
+		return r
+	}
+	a, b, c := ring("a", "a", "b", "c"), ring("b", "c", "b", "a"), ring("c", "a", "b", "c")
+
+	const n = 3000
+	counts := map[string]int{}
+	before := map[string]string{}
+	for i := 0; i < n; i++ {
+		e := cron.Entry{Name: fmt.Sprint("job", i)}
+		owners := 0
+		for _, r := range []*Ring{a, b, c} {
+			if r.Owns(e) {
+				owners++
+				counts[r.self]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("expected exactly one owner of %s, got %d", e.Name, owners)
+		}
+		before[e.Name] = a.Owner(e.Name)
+	}
+	for m, count := range counts {
+		if count < n/6 {
+			t.Errorf("expected a fair share for %s, got %d of %d", m, count, n)
+		}
+	}
+
+	// Removing c only moves c's entries.
+	a.SetMembers([]string{"a", "b"})
+	for name, owner := range before {
+		if now := a.Owner(name); owner != "c" && now != owner {
+			t.Fatalf("expected %s to stay on %s, moved to %s", name, owner, now)
+		}
+	}
+}
+
+func TestRingKey(t *testing.T) {
+	if k := Key(cron.Entry{ID: 7}); k != "7" {
+		t.Errorf("expected unnamed entries keyed by ID, got %q", k)
+	}
+	if NewRing("a", 0).Owner("x") != "a" {
+		t.Error("expected a new ring to contain only itself")
+	}
+	r := NewRing("a", 0)
+	r.SetMembers(nil)
+	if r.Owner("x") != "" || r.Owns(cron.Entry{Name: "x"}) {
+		t.Error("expected an empty ring to own nothing")
+	}
+}
+
+func TestRingWatch(t *testing.T) {
+	r := NewRing("a", 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan struct{}, 10)
+	go r.Watch(ctx, MembershipFunc(func(ctx context.Context) ([]string, error) {
+		calls <- struct{}{}
+		return []string{"b", "a"}, nil
+	}), time.Millisecond, cron.DiscardLogger)
+	<-calls
+	<-calls
+	if m := r.Members(); !equal(m, []string{"a", "b"}) {
+		t.Errorf("expected the ring rebalanced, got %v", m)
+	}
+}
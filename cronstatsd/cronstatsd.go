@@ -0,0 +1,93 @@
+// Package cronstatsd emits cron run metrics in the StatsD protocol, with
+// DogStatsD-style tags, for collection by a StatsD server or Datadog agent.
+//
+//	e, err := cronstatsd.Dial("127.0.0.1:8125", "myapp.cron.", "env:prod")
+//	c := cron.New(cron.WithEventListener(e.Listener()))
+//
+// The following metrics are emitted, each tagged with "entry:<name>" (or the
+// entry's ID, if it has no name):
+//
+//	runs.started   counter  a run started
+//	runs.finished  counter  a run completed successfully
+//	runs.failed    counter  a run returned an error or panicked
+//	runs.stuck     counter  a run stopped heartbeating or ran unusually long
+//	duration       timer    how long each run took
+//	lag            timer    how long after its scheduled time each run started
+package cronstatsd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Emitter writes metrics to a StatsD server.
+type Emitter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix string
+	tags   []string
+}
+
+// Dial returns an Emitter that sends metrics over UDP to the StatsD server at
+// addr. Metric names are prefixed with prefix, and every metric carries the
+// given tags in addition to the entry tag.
+func Dial(addr, prefix string, tags ...string) (*Emitter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewEmitter(conn, prefix, tags...), nil
+}
+
+// NewEmitter returns an Emitter that writes one metric per Write to w.
+func NewEmitter(w io.Writer, prefix string, tags ...string) *Emitter {
+	return &Emitter{w: w, prefix: prefix, tags: tags}
+}
+
+// Listener returns a cron.EventListener that emits metrics for each event.
+func (e *Emitter) Listener() cron.EventListener {
+	return e.Handle
+}
+
+// Handle emits the metrics for a single event.
+func (e *Emitter) Handle(ev cron.Event) {
+	entry := ev.Name
+	if entry == "" {
+		entry = strconv.Itoa(int(ev.Entry))
+	}
+	switch ev.Type {
+	case cron.EventStarted:
+		e.send("runs.started", "1|c", entry)
+		e.send("lag", millis(ev.Time.Sub(ev.Scheduled))+"|ms", entry)
+	case cron.EventFinished:
+		e.send("runs.finished", "1|c", entry)
+		e.send("duration", millis(ev.Duration)+"|ms", entry)
+	case cron.EventFailed:
+		e.send("runs.failed", "1|c", entry)
+		e.send("duration", millis(ev.Duration)+"|ms", entry)
+	case cron.EventStuck:
+		e.send("runs.stuck", "1|c", entry)
+	}
+}
+
+// send writes a single metric line. Errors are ignored, as is usual for
+// StatsD.
+func (e *Emitter) send(name, value, entry string) {
+	tags := append([]string{"entry:" + entry}, e.tags...)
+	line := fmt.Sprintf("%s%s:%s|#%s", e.prefix, name, value, strings.Join(tags, ","))
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.w.Write([]byte(line))
+}
+
+// millis formats a duration as fractional milliseconds.
+func millis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+}
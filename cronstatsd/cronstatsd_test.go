@@ -0,0 +1,66 @@
+package cronstatsd
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+type lines []string
+
+func (l *lines) Write(p []byte) (int, error) {
+	*l = append(*l, string(p))
+	return len(p), nil
+}
+
+func TestEmitter(t *testing.T) {
+	var out lines
+	e := NewEmitter(&out, "app.cron.", "env:test")
+	l := e.Listener()
+
+	scheduled := time.Date(2020, 8, 28, 10, 0, 0, 0, time.UTC)
+	l(cron.Event{Type: cron.EventScheduled, Entry: 1, Name: "sync", Scheduled: scheduled})
+	l(cron.Event{Type: cron.EventStarted, Entry: 1, Name: "sync", Scheduled: scheduled,
+		Time: scheduled.Add(1500 * time.Microsecond)})
+	l(cron.Event{Type: cron.EventFailed, Entry: 2, Scheduled: scheduled, Duration: 2 * time.Second})
+	l(cron.Event{Type: cron.EventStuck, Entry: 2, Scheduled: scheduled, Duration: time.Minute})
+
+	want := lines{
+		"app.cron.runs.started:1|c|#entry:sync,env:test",
+		"app.cron.lag:1.5|ms|#entry:sync,env:test",
+		"app.cron.runs.failed:1|c|#entry:2,env:test",
+		"app.cron.duration:2000|ms|#entry:2,env:test",
+		"app.cron.runs.stuck:1|c|#entry:2,env:test",
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(out, "\n"))
+	}
+}
+
+func TestDial(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("udp unavailable:", err)
+	}
+	defer pc.Close()
+
+	e, err := Dial(pc.LocalAddr().String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Handle(cron.Event{Type: cron.EventFinished, Name: "sync", Duration: time.Millisecond})
+
+	buf := make([]byte, 512)
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "runs.finished:1|c|#entry:sync" {
+		t.Errorf("unexpected packet %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+package crontest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// FakeClock is a cron.Clock whose time only moves when told to.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	fired  []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer that fires once the clock has been advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) cron.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.fire(c.now)
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return t
+}
+
+// Set moves the clock to now, firing the timers due by then in order.
+// The clock never moves backwards.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Before(c.now) {
+		return
+	}
+	c.now = now
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+	for len(c.timers) > 0 && !c.timers[0].deadline.After(now) {
+		c.timers[0].fire(c.timers[0].deadline)
+		c.timers = c.timers[1:]
+	}
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// nextDeadline returns the deadline of the earliest pending timer.
+func (c *FakeClock) nextDeadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var next time.Time
+	for _, t := range c.timers {
+		if next.IsZero() || t.deadline.Before(next) {
+			next = t.deadline
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// undelivered reports whether a fired timer's time has not been received
+// yet.
+func (c *FakeClock) undelivered() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending := c.fired[:0]
+	for _, t := range c.fired {
+		if !t.stopped && len(t.c) > 0 {
+			pending = append(pending, t)
+		}
+	}
+	c.fired = pending
+	return len(pending) > 0
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	c        chan time.Time
+	stopped  bool
+}
+
+// fire sends the time on the timer's channel. The clock's lock is held.
+func (t *fakeTimer) fire(now time.Time) {
+	select {
+	case t.c <- now:
+	default:
+		// An earlier value was never received; like a time.Timer, keep it.
+		return
+	}
+	t.clock.fired = append(t.clock.fired, t)
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+// Stop prevents the timer from firing, and reports whether it was pending.
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t.stopped = true
+	return t.removeLocked()
+}
+
+// Reset rearms the timer to fire once the clock has been advanced by d, and
+// reports whether it was pending.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending := t.removeLocked()
+	t.stopped = false
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		t.fire(c.now)
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return pending
+}
+
+// removeLocked removes the timer from the pending timers, reporting whether
+// it was there.
+func (t *fakeTimer) removeLocked() bool {
+	c := t.clock
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
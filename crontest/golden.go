@@ -0,0 +1,127 @@
+package crontest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// UpdateEnv is the environment variable that makes ExpectGolden rewrite
+// golden files instead of comparing against them:
+//
+//	CRONTEST_UPDATE=1 go test ./...
+const UpdateEnv = "CRONTEST_UPDATE"
+
+// Snapshot renders the next n activation times after from of each entry, as
+// stable text for golden-file tests. Times are shown in the entry's time zone
+// if its spec has one, and in from's otherwise. Entries are sorted by name, unnamed
+// entries last by ID, and each is headed by its name and spec:
+//
+//	# report: CRON_TZ=America/New_York 0 9 * * MON-FRI
+//	2020-01-01T09:00:00-05:00 Wed
+//	2020-01-02T09:00:00-05:00 Thu
+func Snapshot(entries []cron.Entry, from time.Time, n int) string {
+	entries = append([]cron.Entry(nil), entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Name == "") != (b.Name == "") {
+			return b.Name == ""
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	var buf bytes.Buffer
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		name := e.Name
+		if name == "" {
+			name = "#" + strconv.Itoa(int(e.ID))
+		}
+		fmt.Fprintf(&buf, "# %s: %s\n", name, e.Spec)
+		loc := from.Location()
+		s := e.Schedule
+		if nth, ok := s.(*cron.NthSchedule); ok {
+			s = nth.Schedule
+		}
+		if o, ok := s.(cron.OffsetSchedule); ok {
+			s = o.Schedule
+		}
+		if y, ok := s.(cron.YearSchedule); ok {
+			s = y.Schedule
+		}
+		if m, ok := s.(cron.MissingDaySchedule); ok {
+			s = m.Schedule
+		}
+		if s, ok := s.(*cron.SpecSchedule); ok && s.Location != time.Local {
+			loc = s.Location
+		}
+		t := from
+		for k := 0; k < n; k++ {
+			t = e.Schedule.Next(t)
+			if t.IsZero() {
+				buf.WriteString("never\n")
+				break
+			}
+			t := t.In(loc)
+			fmt.Fprintf(&buf, "%s %s\n", t.Format(time.RFC3339), t.Format("Mon"))
+		}
+	}
+	return buf.String()
+}
+
+// Snapshot renders the next n activation times of the harness's entries
+// after the clock's current time.
+func (h *Harness) Snapshot(n int) string {
+	return Snapshot(h.Cron.Entries(), h.Clock.Now(), n)
+}
+
+// ExpectGolden fails the test unless got matches the contents of the golden
+// file at path, reporting the first line that differs. If the UpdateEnv
+// environment variable is set, it writes got to the file instead.
+func ExpectGolden(t testing.TB, path, got string) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (set %s=1 to create it): %v", UpdateEnv, err)
+	}
+	if string(want) == got {
+		return
+	}
+	wantLines, gotLines := strings.Split(string(want), "\n"), strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			t.Errorf("schedule differs from %s at line %d:\n  want: %s\n   got: %s\n(set %s=1 to update)", path, i+1, w, g, UpdateEnv)
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package crontest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestSnapshotGolden(t *testing.T) {
+	c := cron.New(cron.WithLocation(time.UTC))
+	c.AddFunc("CRON_TZ=America/New_York 0 9 * * MON-FRI", func() {}, cron.WithName("report"))
+	c.AddFunc("@every 36h", func() {})
+	c.AddFunc("0 0 1 * *", func() {}, cron.WithName("billing"))
+	ExpectGolden(t, filepath.Join("testdata", "schedule.golden"), Snapshot(c.Entries(), start, 3))
+}
+
+type recorder struct {
+	testing.TB
+	errors int
+}
+
+func (r *recorder) Helper()                                   {}
+func (r *recorder) Errorf(format string, args ...interface{}) { r.errors++ }
+
+func TestExpectGoldenMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.golden")
+	r := &recorder{TB: t}
+
+	os.Setenv(UpdateEnv, "1")
+	ExpectGolden(r, path, "a\nb\n")
+	os.Unsetenv(UpdateEnv)
+
+	ExpectGolden(r, path, "a\nb\n")
+	if r.errors != 0 {
+		t.Fatal("expected the written golden file to match")
+	}
+	ExpectGolden(r, path, "a\nc\n")
+	if r.errors != 1 {
+		t.Error("expected a mismatch to be reported")
+	}
+}
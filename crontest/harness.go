@@ -0,0 +1,156 @@
+// Package crontest tests code that schedules jobs, in virtual time.
+//
+// A Harness runs a Cron on a FakeClock. Advancing the clock fires the entries
+// that come due, in order, and waits for their jobs to finish before
+// returning, so tests need no sleeps:
+//
+//	func TestReport(t *testing.T) {
+//		h := crontest.New(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), cron.WithLocation(time.UTC))
+//		h.Cron.AddFunc("0 * * * *", sendReport, cron.WithName("report"))
+//		h.AdvanceTo(time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC))
+//		h.ExpectRuns("report", 3)
+//	}
+//
+// Jobs run in real time. A job that waits on the Cron's clock, such as a
+// firing deferred by a blackout, keeps the harness waiting until its timeout.
+package crontest
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultTimeout is how long a Harness waits for the scheduler and its jobs
+// after advancing the clock.
+const DefaultTimeout = 10 * time.Second
+
+// Harness is a running Cron on a FakeClock, with counts of its runs.
+type Harness struct {
+	Cron  *cron.Cron
+	Clock *FakeClock
+
+	// Timeout is how long to wait for jobs to finish after advancing the
+	// clock before failing the test. It defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	t        testing.TB
+	mu       sync.Mutex
+	runs     map[string]int
+	failures map[string]int
+}
+
+// New starts a Cron configured with opts on a FakeClock set to start, and
+// stops it when the test completes.
+func New(t testing.TB, start time.Time, opts ...cron.Option) *Harness {
+	h := &Harness{
+		Clock:    NewFakeClock(start),
+		Timeout:  DefaultTimeout,
+		t:        t,
+		runs:     make(map[string]int),
+		failures: make(map[string]int),
+	}
+	opts = append(opts, cron.WithClock(h.Clock), cron.WithEventListener(h.record))
+	h.Cron = cron.New(opts...)
+	h.Cron.Start()
+	t.Cleanup(func() { h.Cron.Stop() })
+	h.settle()
+	return h
+}
+
+// record counts completed runs by entry name.
+func (h *Harness) record(e cron.Event) {
+	if e.Type != cron.EventFinished && e.Type != cron.EventFailed {
+		return
+	}
+	name := e.Name
+	if name == "" {
+		name = "#" + strconv.Itoa(int(e.Entry))
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.runs[name]++
+	if e.Type == cron.EventFailed {
+		h.failures[name]++
+	}
+}
+
+// AdvanceTo moves the clock forward to t, stopping at each time the scheduler
+// wakes to let it fire the entries due and waiting for their jobs to finish.
+func (h *Harness) AdvanceTo(t time.Time) {
+	h.t.Helper()
+	// Let the scheduler finish handling entries added at the current time.
+	h.settle()
+	for {
+		next, ok := h.Clock.nextDeadline()
+		if !ok || next.After(t) {
+			break
+		}
+		h.Clock.Set(next)
+		h.settle()
+	}
+	h.Clock.Set(t)
+	h.settle()
+}
+
+// Advance moves the clock forward by d, as AdvanceTo.
+func (h *Harness) Advance(d time.Duration) {
+	h.t.Helper()
+	h.AdvanceTo(h.Clock.Now().Add(d))
+}
+
+// settle waits until the scheduler has received every fired timer and
+// processed the firings, and the jobs it started have finished.
+func (h *Harness) settle() {
+	h.t.Helper()
+	deadline := time.Now().Add(h.Timeout)
+	wait := func(done func() bool, what string) {
+		for !done() {
+			if time.Now().After(deadline) {
+				h.t.Fatalf("crontest: timed out waiting for %s", what)
+			}
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+	wait(func() bool { return !h.Clock.undelivered() }, "the scheduler to wake")
+	// The health check is answered by the scheduler's goroutine once it has
+	// finished handling the wake.
+	if !h.Cron.Health(h.Timeout).Responsive {
+		h.t.Fatalf("crontest: timed out waiting for the scheduler")
+	}
+	wait(h.Cron.Idle, "jobs to finish")
+}
+
+// Runs returns the number of completed runs of the named entry. Unnamed
+// entries are named "#" followed by their ID.
+func (h *Harness) Runs(name string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.runs[name]
+}
+
+// Failures returns the number of runs of the named entry that failed.
+func (h *Harness) Failures(name string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.failures[name]
+}
+
+// ExpectRuns fails the test unless the named entry has completed n runs.
+func (h *Harness) ExpectRuns(name string, n int) {
+	h.t.Helper()
+	if got := h.Runs(name); got != n {
+		h.t.Errorf("expected %d runs of %s, got %d", n, name, got)
+	}
+}
+
+// ExpectFailures fails the test unless n runs of the named entry failed.
+func (h *Harness) ExpectFailures(name string, n int) {
+	h.t.Helper()
+	if got := h.Failures(name); got != n {
+		h.t.Errorf("expected %d failed runs of %s, got %d", n, name, got)
+	}
+}
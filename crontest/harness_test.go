@@ -0,0 +1,245 @@
+package crontest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestHarness(t *testing.T) {
+	h := New(t, start, cron.WithLocation(time.UTC))
+	var seen []time.Time
+	h.Cron.AddContextFunc("0 * * * *", func(ctx context.Context) error {
+		s, _ := cron.ScheduledTimeFromContext(ctx)
+		seen = append(seen, s)
+		return nil
+	}, cron.WithName("hourly"))
+	h.Cron.AddFunc("*/20 * * * *", func() {}, cron.WithName("often"))
+	failing, _ := h.Cron.AddContextFunc("@every 90m", func(context.Context) error {
+		return errors.New("boom")
+	})
+
+	h.AdvanceTo(start.Add(3 * time.Hour))
+	h.ExpectRuns("hourly", 3)
+	h.ExpectRuns("often", 9)
+	h.ExpectRuns("#3", 2)
+	h.ExpectFailures("#3", 2)
+	if failing != 3 {
+		t.Fatalf("unexpected entry ID %d", failing)
+	}
+	for i, s := range seen {
+		if want := start.Add(time.Duration(i+1) * time.Hour); !s.Equal(want) {
+			t.Errorf("expected run %d scheduled at %v, got %v", i, want, s)
+		}
+	}
+
+	h.Advance(59 * time.Minute)
+	h.ExpectRuns("hourly", 3)
+	h.Advance(time.Minute)
+	h.ExpectRuns("hourly", 4)
+	if next := h.Cron.Entry(1).Next; !next.Equal(start.Add(5 * time.Hour)) {
+		t.Errorf("expected the next run at 05:00, got %v", next)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	c := NewFakeClock(start)
+	t1 := c.NewTimer(time.Minute)
+	t2 := c.NewTimer(time.Hour)
+	c.Advance(30 * time.Minute)
+	select {
+	case got := <-t1.C():
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("expected the timer's deadline, got %v", got)
+		}
+	default:
+		t.Fatal("expected the first timer to fire")
+	}
+	if !t2.Stop() || t1.Stop() {
+		t.Error("expected only the pending timer to stop")
+	}
+	c.Advance(time.Hour)
+	select {
+	case <-t2.C():
+		t.Error("expected the stopped timer not to fire")
+	default:
+	}
+	if t2.Reset(time.Minute) {
+		t.Error("expected the stopped timer not to be pending")
+	}
+	c.Advance(time.Minute)
+	select {
+	case <-t2.C():
+	default:
+		t.Error("expected the reset timer to fire")
+	}
+
+	c.Set(start)
+	if !c.Now().Equal(start.Add(91 * time.Minute)) {
+		t.Error("expected the clock not to move backwards")
+	}
+}
+
+// Timeouts, heartbeat watchdogs and pending waits follow the Cron's clock.
+func TestFakeClockDeadlines(t *testing.T) {
+	events := make(chan cron.EventType, 10)
+	h := New(t, start, cron.WithLocation(time.UTC), cron.WithConcurrencyLimit(1),
+		cron.WithEventListener(func(e cron.Event) {
+			switch e.Type {
+			case cron.EventSoftTimeout, cron.EventTimeout, cron.EventStuck:
+				events <- e.Type
+			}
+		}))
+	// advance moves the clock while jobs are running, which Advance would
+	// wait for.
+	advance := func(d time.Duration) {
+		h.Clock.Advance(d)
+		for h.Clock.undelivered() {
+			time.Sleep(100 * time.Microsecond)
+		}
+		h.Cron.Health(h.Timeout)
+	}
+	expect := func(want cron.EventType) {
+		t.Helper()
+		select {
+		case got := <-events:
+			if got != want {
+				t.Fatalf("expected event %v, got %v", want, got)
+			}
+		case <-time.After(h.Timeout):
+			t.Fatalf("timed out waiting for event %v", want)
+		}
+	}
+
+	started := make(chan struct{})
+	block := cron.FuncContextJob(func(ctx context.Context) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	h.Cron.AddJob("0 1 * * *", cron.NewChain(cron.Timeout(cron.DiscardLogger, time.Minute, time.Hour)).Then(block))
+	h.Cron.AddFunc("1 1 * * *", func() {}, cron.WithName("queued"))
+	h.settle()
+	advance(time.Hour)
+	<-started
+	advance(time.Minute)
+	expect(cron.EventSoftTimeout)
+	for len(h.Cron.Pending()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	advance(time.Minute)
+	if p := h.Cron.Pending(); len(p) != 1 || p[0].Name != "queued" || p[0].Wait != time.Minute {
+		t.Errorf("expected the queued run to have waited a minute, got %+v", p)
+	}
+	advance(58 * time.Minute)
+	expect(cron.EventTimeout)
+	h.settle()
+	h.ExpectRuns("queued", 1)
+
+	// The watchdog checks every quarter of the window, so the run is stuck
+	// five minutes in.
+	h.Cron.AddJob("30 2 * * *", block, cron.WithHeartbeatTimeout(4*time.Minute, true))
+	h.settle()
+	advance(30 * time.Minute)
+	<-started
+	for i := 1; i <= 20; i++ {
+		advance(time.Minute)
+		select {
+		case got := <-events:
+			if got != cron.EventStuck || i < 5 {
+				t.Fatalf("unexpected event %v after %d minutes", got, i)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Fatal("expected the run to be marked stuck")
+}
+
+// Retry backoffs and load throttling wait on the Cron's clock.
+func TestFakeClockWrappers(t *testing.T) {
+	h := New(t, start, cron.WithLocation(time.UTC))
+	advance := func(d time.Duration) {
+		h.Clock.Advance(d)
+		for h.Clock.undelivered() {
+			time.Sleep(100 * time.Microsecond)
+		}
+		h.Cron.Health(h.Timeout)
+	}
+	// waitTimer waits for a job to arm a timer that fires at t.
+	waitTimer := func(at time.Time) {
+		t.Helper()
+		deadline := time.Now().Add(h.Timeout)
+		for next, _ := h.Clock.nextDeadline(); !next.Equal(at); next, _ = h.Clock.nextDeadline() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for a timer at %v", at)
+			}
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+	expect := func(ch <-chan struct{}, want bool, what string) {
+		t.Helper()
+		wait := 10 * time.Millisecond
+		if want {
+			wait = h.Timeout
+		}
+		select {
+		case <-ch:
+			if !want {
+				t.Fatalf("unexpected %s", what)
+			}
+		case <-time.After(wait):
+			if want {
+				t.Fatalf("expected %s", what)
+			}
+		}
+	}
+
+	attempts := make(chan struct{}, 2)
+	var failed bool
+	h.Cron.AddJob("0 1 * * *", cron.NewChain(cron.Retry(cron.DiscardLogger, 1, 10*time.Minute)).Then(
+		cron.FuncContextJob(func(context.Context) error {
+			attempts <- struct{}{}
+			if !failed {
+				failed = true
+				return errors.New("boom")
+			}
+			return nil
+		})), cron.WithName("retried"))
+	h.settle()
+	advance(time.Hour)
+	expect(attempts, true, "the first attempt")
+	waitTimer(start.Add(time.Hour + 10*time.Minute))
+	advance(9 * time.Minute)
+	expect(attempts, false, "retry before the backoff")
+	advance(time.Minute)
+	expect(attempts, true, "the retry")
+	h.settle()
+	h.ExpectRuns("retried", 1)
+	h.ExpectFailures("retried", 0)
+
+	probes, ran := make(chan struct{}, 2), make(chan struct{}, 1)
+	load := 2.0
+	h.Cron.AddJob("0 2 * * *", cron.NewChain(cron.ThrottleOnLoad(cron.DiscardLogger,
+		cron.LoadProbeFunc(func() (float64, error) {
+			probes <- struct{}{}
+			return load, nil
+		}), 1, 10, 10*time.Minute)).Then(
+		cron.FuncJob(func() { ran <- struct{}{} })), cron.WithName("throttled"))
+	h.settle()
+	advance(50 * time.Minute)
+	expect(probes, true, "the load probed")
+	waitTimer(start.Add(2*time.Hour + 10*time.Minute))
+	load = 0
+	advance(9 * time.Minute)
+	expect(probes, false, "the load probed before the interval")
+	advance(time.Minute)
+	expect(probes, true, "the load probed again")
+	expect(ran, true, "the throttled run")
+	h.settle()
+}
@@ -0,0 +1,126 @@
+// Package cronv3 is the API of upstream github.com/robfig/cron/v3, with its
+// exact signatures, backed by this package. Code written against upstream
+// compiles unchanged after switching its import:
+//
+//	import cron "github.com/robfig/cron/v3/cronv3"
+//
+// The cron package itself keeps upstream's names, but some of its methods
+// take further optional arguments, such as AddFunc's EntryOptions. Calls are
+// unaffected, but interfaces declared with upstream's signatures, say for
+// mocking a Cron, are not satisfied by *cron.Cron. They are by *cronv3.Cron,
+// which also gives access to the rest of this package through Unwrap.
+package cronv3
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Types of upstream's API, which are the cron package's.
+type (
+	ConstantDelaySchedule = cron.ConstantDelaySchedule
+	Chain                 = cron.Chain
+	Entry                 = cron.Entry
+	EntryID               = cron.EntryID
+	FuncJob               = cron.FuncJob
+	Job                   = cron.Job
+	JobWrapper            = cron.JobWrapper
+	Logger                = cron.Logger
+	Option                = cron.Option
+	ParseOption           = cron.ParseOption
+	Parser                = cron.Parser
+	Schedule              = cron.Schedule
+	ScheduleParser        = cron.ScheduleParser
+	SpecSchedule          = cron.SpecSchedule
+)
+
+// Parse options.
+const (
+	Second         = cron.Second
+	SecondOptional = cron.SecondOptional
+	Minute         = cron.Minute
+	Hour           = cron.Hour
+	Dom            = cron.Dom
+	Month          = cron.Month
+	Dow            = cron.Dow
+	DowOptional    = cron.DowOptional
+	Descriptor     = cron.Descriptor
+)
+
+// Loggers.
+var (
+	DefaultLogger = cron.DefaultLogger
+	DiscardLogger = cron.DiscardLogger
+)
+
+// Functions of upstream's API, which are the cron package's.
+var (
+	WithLocation        = cron.WithLocation
+	WithSeconds         = cron.WithSeconds
+	WithParser          = cron.WithParser
+	WithChain           = cron.WithChain
+	WithLogger          = cron.WithLogger
+	NewChain            = cron.NewChain
+	Recover             = cron.Recover
+	DelayIfStillRunning = cron.DelayIfStillRunning
+	SkipIfStillRunning  = cron.SkipIfStillRunning
+	NewParser           = cron.NewParser
+	ParseStandard       = cron.ParseStandard
+	Every               = cron.Every
+	PrintfLogger        = cron.PrintfLogger
+	VerbosePrintfLogger = cron.VerbosePrintfLogger
+)
+
+// Cron is a cron.Cron with upstream's method signatures.
+type Cron struct {
+	c *cron.Cron
+}
+
+// New returns a new Cron job runner, modified by the given options.
+func New(opts ...Option) *Cron {
+	return &Cron{cron.New(opts...)}
+}
+
+// Unwrap returns the underlying cron.Cron, for the features of this package
+// that upstream lacks.
+func (c *Cron) Unwrap() *cron.Cron { return c.c }
+
+// AddFunc adds a func to the Cron to be run on the given schedule.
+func (c *Cron) AddFunc(spec string, cmd func()) (EntryID, error) {
+	return c.c.AddFunc(spec, cmd)
+}
+
+// AddJob adds a Job to the Cron to be run on the given schedule.
+func (c *Cron) AddJob(spec string, cmd Job) (EntryID, error) {
+	return c.c.AddJob(spec, cmd)
+}
+
+// Schedule adds a Job to the Cron to be run on the given schedule.
+func (c *Cron) Schedule(schedule Schedule, cmd Job) EntryID {
+	return c.c.Schedule(schedule, cmd)
+}
+
+// Entries returns a snapshot of the cron entries.
+func (c *Cron) Entries() []Entry { return c.c.Entries() }
+
+// Location gets the time zone location.
+func (c *Cron) Location() *time.Location { return c.c.Location() }
+
+// Entry returns a snapshot of the given entry, or the zero Entry if it
+// couldn't be found.
+func (c *Cron) Entry(id EntryID) Entry { return c.c.Entry(id) }
+
+// Remove an entry from being run in the future.
+func (c *Cron) Remove(id EntryID) { c.c.Remove(id) }
+
+// Start the cron scheduler in its own goroutine, or no-op if already started.
+func (c *Cron) Start() { c.c.Start() }
+
+// Run the cron scheduler, or no-op if already running.
+func (c *Cron) Run() { c.c.Run() }
+
+// Stop stops the cron scheduler if it is running, returning a context that
+// is done when running jobs have completed.
+func (c *Cron) Stop() context.Context { return c.c.Stop() }
@@ -0,0 +1,57 @@
+package cronv3
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// scheduler is an interface as code written against upstream might declare
+// it, with upstream's signatures.
+type scheduler interface {
+	AddFunc(spec string, cmd func()) (EntryID, error)
+	AddJob(spec string, cmd Job) (EntryID, error)
+	Schedule(schedule Schedule, cmd Job) EntryID
+	Entries() []Entry
+	Entry(id EntryID) Entry
+	Location() *time.Location
+	Remove(id EntryID)
+	Start()
+	Run()
+	Stop() context.Context
+}
+
+var _ scheduler = (*Cron)(nil)
+
+func TestCron(t *testing.T) {
+	ran := make(chan struct{}, 1)
+	c := New(WithSeconds(), WithChain(SkipIfStillRunning(DiscardLogger)), WithLocation(time.UTC))
+	id, err := c.AddFunc("* * * * * *", func() {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Schedule(Every(time.Hour), FuncJob(func() {}))
+	if n := len(c.Entries()); n != 2 || c.Entry(id).ID != id || c.Location() != time.UTC {
+		t.Fatalf("unexpected entries %v", c.Entries())
+	}
+	if c.Unwrap().Entry(id).ID != id {
+		t.Error("expected Unwrap to return the underlying Cron")
+	}
+
+	c.Start()
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Error("expected the func to run")
+	}
+	<-c.Stop().Done()
+	c.Remove(id)
+	if n := len(c.Entries()); n != 1 {
+		t.Errorf("expected 1 entry, got %d", n)
+	}
+}
@@ -0,0 +1,121 @@
+// Package cronwinsvc runs a Cron as a Windows service, for replacing Task
+// Scheduler with a daemon built on this package. The service control
+// manager's stop and shutdown requests stop the Cron, waiting for running
+// jobs, and its pause and continue requests pause and resume firing.
+//
+// The package does not depend on golang.org/x/sys. Its types have the values
+// of their counterparts in golang.org/x/sys/windows/svc, so a svc.Handler
+// takes a few lines to adapt:
+//
+//	type service struct{ c *cron.Cron }
+//
+//	func (s service) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+//		commands := make(chan cronwinsvc.Command)
+//		go func() {
+//			defer close(commands)
+//			for req := range r {
+//				commands <- cronwinsvc.Command(req.Cmd)
+//			}
+//		}()
+//		cronwinsvc.Run(s.c, commands, func(st cronwinsvc.Status) {
+//			changes <- svc.Status{State: svc.State(st.State), Accepts: svc.Accepted(st.Accepts)}
+//		})
+//		return false, 0
+//	}
+//
+//	// In main:
+//	svc.Run("myjobs", service{c})
+package cronwinsvc
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Command is a request from the service control manager, with the values of
+// svc.Cmd.
+type Command uint32
+
+// The commands that Run handles. Others are ignored.
+const (
+	Stop        Command = 1
+	Pause       Command = 2
+	Continue    Command = 3
+	Interrogate Command = 4
+	Shutdown    Command = 5
+)
+
+// State is the state of a service, with the values of svc.State.
+type State uint32
+
+// The states that Run reports.
+const (
+	Stopped      State = 1
+	StartPending State = 2
+	StopPending  State = 3
+	Running      State = 4
+	Paused       State = 7
+)
+
+// Accepted is the set of commands a service accepts, with the values of
+// svc.Accepted.
+type Accepted uint32
+
+// The commands that Run accepts.
+const (
+	AcceptStop             Accepted = 1
+	AcceptPauseAndContinue Accepted = 2
+	AcceptShutdown         Accepted = 4
+)
+
+// Status is the status of a service, reported to the service control
+// manager.
+type Status struct {
+	State   State
+	Accepts Accepted
+}
+
+// PauseBlackout is the name of the blackout that pauses the Cron.
+const PauseBlackout = "cronwinsvc pause"
+
+// accepts are the commands accepted while running or paused.
+const accepts = AcceptStop | AcceptShutdown | AcceptPauseAndContinue
+
+// Run starts c and serves the commands until one asks it to stop, or there
+// are no more, reporting each change of status. It then stops c and returns
+// once its running jobs have finished.
+//
+// While paused, c fires no jobs: firings that fall due are skipped, as
+// though by a cron.Blackout named PauseBlackout. Jobs already running are
+// not interrupted.
+func Run(c *cron.Cron, commands <-chan Command, report func(Status)) {
+	report(Status{State: StartPending})
+	c.Start()
+	current := Status{State: Running, Accepts: accepts}
+	report(current)
+loop:
+	for cmd := range commands {
+		switch cmd {
+		case Interrogate:
+			report(current)
+		case Pause:
+			c.AddBlackout(cron.Blackout{
+				Name:   PauseBlackout,
+				End:    time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+				Policy: cron.BlackoutSkip,
+			})
+			current.State = Paused
+			report(current)
+		case Continue:
+			c.RemoveBlackout(PauseBlackout)
+			current.State = Running
+			report(current)
+		case Stop, Shutdown:
+			break loop
+		}
+	}
+	report(Status{State: StopPending})
+	<-c.Stop().Done()
+	c.RemoveBlackout(PauseBlackout)
+}
@@ -0,0 +1,77 @@
+package cronwinsvc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestRun(t *testing.T) {
+	c := cron.New()
+	commands := make(chan Command)
+	statuses := make(chan Status, 10)
+	done := make(chan struct{})
+	go func() {
+		Run(c, commands, func(st Status) { statuses <- st })
+		close(done)
+	}()
+
+	expect := func(state State) {
+		t.Helper()
+		select {
+		case st := <-statuses:
+			if st.State != state {
+				t.Errorf("expected state %d, got %+v", state, st)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected state %d", state)
+		}
+	}
+	expect(StartPending)
+	expect(Running)
+
+	commands <- Pause
+	expect(Paused)
+	if b := c.Blackouts(); len(b) != 1 || b[0].Name != PauseBlackout {
+		t.Errorf("expected the pause blackout, got %v", b)
+	}
+	commands <- Interrogate
+	expect(Paused)
+	commands <- Continue
+	expect(Running)
+	if b := c.Blackouts(); len(b) != 0 {
+		t.Errorf("expected no blackouts, got %v", b)
+	}
+
+	commands <- Shutdown
+	expect(StopPending)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return")
+	}
+}
+
+func TestRunPausedFiresNothing(t *testing.T) {
+	c := cron.New(cron.WithSeconds())
+	fired := make(chan struct{}, 1)
+	c.AddFunc("* * * * * *", func() {
+		select {
+		case fired <- struct{}{}:
+		default:
+		}
+	})
+	commands := make(chan Command, 1)
+	commands <- Pause
+	go func() {
+		time.Sleep(1500 * time.Millisecond)
+		close(commands)
+	}()
+	Run(c, commands, func(Status) {})
+	select {
+	case <-fired:
+		t.Error("expected no firings while paused")
+	default:
+	}
+}
@@ -0,0 +1,139 @@
+package cron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// dedupPrefix is the Store key prefix of deduplication claims.
+const dedupPrefix = "dedup/"
+
+// IdempotencyKey returns a key that identifies the firing of the entry at the
+// scheduled time. Every process running the same schedule derives the same key
+// for the same firing, so systems that a job calls can use it to recognize
+// repeated requests. Entries are identified by name, or by ID if unnamed.
+func IdempotencyKey(e Entry, scheduled time.Time) string {
+	name := e.Name
+	if name == "" {
+		name = "#" + strconv.Itoa(int(e.ID))
+	}
+	sum := sha256.Sum256([]byte(name + "\x00" + scheduled.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IdempotencyKeyFromContext returns the IdempotencyKey of the run that ctx was
+// passed to. It returns false if ctx did not come from a Cron.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	ri, ok := runFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return IdempotencyKey(ri.entry, ri.scheduled), true
+}
+
+// deduper holds the configuration and state of WithDeduplication.
+type deduper struct {
+	store  Store
+	window time.Duration
+	mu     sync.Mutex
+	pruned time.Time
+}
+
+// dedupClaim records which run claimed a firing, and when.
+type dedupClaim struct {
+	Run string    `json:"run"`
+	At  time.Time `json:"at"`
+}
+
+// WithDeduplication skips runs of a firing that another run, in this or any
+// other process sharing the given Store, claimed within the window. This
+// suppresses the double fires that happen when leadership or a lock moves
+// between processes around a firing. Firings are identified by their
+// IdempotencyKey, so entries should be named.
+//
+// A run that cannot reach the Store runs anyway and logs the error.
+func WithDeduplication(s Store, window time.Duration) Option {
+	return func(c *Cron) {
+		c.dedup = &deduper{store: s, window: window}
+	}
+}
+
+// claim reports whether the run may go ahead, recording its claim on the
+// firing.
+func (c *Cron) claim(ctx context.Context, ri *runInfo) bool {
+	key := dedupPrefix + IdempotencyKey(ri.entry, ri.scheduled)
+	now := c.now()
+	claim, _ := json.Marshal(dedupClaim{Run: ri.id, At: now})
+	data := encodeValue(claim)
+	for {
+		old, err := c.dedup.store.Get(ctx, key)
+		if err != nil && err != ErrNotFound {
+			c.logger.Error(err, "deduplicate", "entry", ri.entry.ID, "run", ri.id)
+			return true
+		}
+		if err == nil {
+			var prev dedupClaim
+			if c.decodeClaim(old, &prev) == nil {
+				if prev.Run == ri.id {
+					// Replayed from the write-ahead log.
+					return true
+				}
+				if now.Sub(prev.At) < c.dedup.window {
+					c.logger.Info("skip", "entry", ri.entry.ID, "run", ri.id, "reason", "duplicate of run "+prev.Run)
+					return false
+				}
+			}
+		} else {
+			old = nil
+		}
+		ok, err := c.dedup.store.CompareAndSwap(ctx, key, old, data)
+		if err != nil {
+			c.logger.Error(err, "deduplicate", "entry", ri.entry.ID, "run", ri.id)
+			return true
+		}
+		if ok {
+			c.pruneClaims(ctx, now)
+			return true
+		}
+	}
+}
+
+// pruneClaims deletes expired claims, at most once per window.
+func (c *Cron) pruneClaims(ctx context.Context, now time.Time) {
+	c.dedup.mu.Lock()
+	if now.Sub(c.dedup.pruned) < c.dedup.window {
+		c.dedup.mu.Unlock()
+		return
+	}
+	c.dedup.pruned = now
+	c.dedup.mu.Unlock()
+
+	keys, err := c.dedup.store.List(ctx, dedupPrefix)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		data, err := c.dedup.store.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+		var claim dedupClaim
+		if c.decodeClaim(data, &claim) != nil || now.Sub(claim.At) >= c.dedup.window {
+			c.dedup.store.Delete(ctx, key)
+		}
+	}
+}
+
+// decodeClaim decodes a claim kept in the Store.
+func (c *Cron) decodeClaim(value []byte, claim *dedupClaim) error {
+	data, err := decodeValue("dedup", value, c.migration)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, claim)
+}
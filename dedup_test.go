@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	at := time.Date(2020, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*3600))
+	k := IdempotencyKey(Entry{ID: 1, Name: "report"}, at)
+	if k != IdempotencyKey(Entry{ID: 2, Name: "report"}, at.UTC()) {
+		t.Error("expected the key to depend only on the name and instant")
+	}
+	if k == IdempotencyKey(Entry{ID: 1, Name: "report"}, at.Add(time.Second)) ||
+		k == IdempotencyKey(Entry{ID: 1, Name: "sync"}, at) ||
+		IdempotencyKey(Entry{ID: 1}, at) == IdempotencyKey(Entry{ID: 2}, at) {
+		t.Error("expected different firings to have different keys")
+	}
+
+	c := New()
+	var got string
+	id, _ := c.AddContextFunc("@every 1h", func(ctx context.Context) error {
+		got, _ = IdempotencyKeyFromContext(ctx)
+		return nil
+	}, WithName("report"))
+	c.runEntry(c.Entry(id), at)
+	if got != k {
+		t.Errorf("expected the run's key %s, got %s", k, got)
+	}
+}
+
+func TestDeduplication(t *testing.T) {
+	store := NewMemoryStore()
+	var runs int32
+	newCron := func() (*Cron, Entry) {
+		c := New(WithDeduplication(store, time.Minute))
+		id, _ := c.AddFunc("@every 1h", func() { atomic.AddInt32(&runs, 1) }, WithName("report"))
+		return c, c.Entry(id)
+	}
+	c1, e1 := newCron()
+	c2, e2 := newCron()
+
+	scheduled := time.Now().Truncate(time.Hour)
+	c1.runEntry(e1, scheduled)
+	c2.runEntry(e2, scheduled)
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("expected the duplicate firing skipped, got %d runs", n)
+	}
+	c2.runEntry(e2, scheduled.Add(time.Hour))
+	if n := atomic.LoadInt32(&runs); n != 2 {
+		t.Errorf("expected the next firing to run, got %d runs", n)
+	}
+
+	// A replayed run keeps its claim.
+	c1.runEntryID(e1, scheduled.Add(2*time.Hour), "run1")
+	c1.runEntryID(e1, scheduled.Add(2*time.Hour), "run1")
+	if n := atomic.LoadInt32(&runs); n != 4 {
+		t.Errorf("expected the replay to run, got %d runs", n)
+	}
+}
+
+func TestDeduplicationWindowExpires(t *testing.T) {
+	store := NewMemoryStore()
+	var runs int32
+	c := New(WithDeduplication(store, time.Millisecond))
+	id, _ := c.AddFunc("@every 1h", func() { atomic.AddInt32(&runs, 1) }, WithName("report"))
+	scheduled := time.Now()
+	c.runEntry(c.Entry(id), scheduled)
+	time.Sleep(5 * time.Millisecond)
+	c.runEntry(c.Entry(id), scheduled)
+	if n := atomic.LoadInt32(&runs); n != 2 {
+		t.Errorf("expected a run after the window, got %d runs", n)
+	}
+	time.Sleep(5 * time.Millisecond)
+	c.runEntry(c.Entry(id), scheduled.Add(time.Hour))
+	if keys, _ := store.List(context.Background(), dedupPrefix); len(keys) != 1 {
+		t.Errorf("expected expired claims pruned, got %v", keys)
+	}
+}
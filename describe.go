@@ -0,0 +1,389 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Describe returns an English description of a standard spec, such as "At
+// 09:30 on weekdays in January" for "30 9 * JAN MON-FRI", for showing
+// schedules to people who do not read cron.
+func Describe(standardSpec string) (string, error) {
+	return standardParser.Describe(standardSpec)
+}
+
+// DescribeIn returns a description of a standard spec in the language, such
+// as "zh", as Describe does in English. Its phrases are translated with the
+// registered catalogs; those without a translation are in English.
+func DescribeIn(standardSpec, lang string) (string, error) {
+	return standardParser.DescribeIn(standardSpec, lang)
+}
+
+// Describe returns an English description of the spec, as the package's
+// Describe does for standard specs. It returns an error if the spec is not
+// valid.
+func (p Parser) Describe(spec string) (string, error) {
+	return p.DescribeIn(spec, "")
+}
+
+// DescribeIn returns a description of the spec in the language, as the
+// package's DescribeIn does for standard specs.
+func (p Parser) DescribeIn(spec, lang string) (string, error) {
+	s, err := p.Parse(spec)
+	if err != nil {
+		return "", err
+	}
+	d, ok := describer{lang}.schedule(s)
+	if !ok {
+		return "", fmt.Errorf("cannot describe a schedule of type %T", s)
+	}
+	return d, nil
+}
+
+// describer describes schedules in a language, translating its phrases as
+// ParseError.Localize does. The phrases' keys are their English format
+// strings.
+type describer struct {
+	lang string
+}
+
+// tr returns the phrase translated and formatted with the args.
+func (d describer) tr(format string, args ...interface{}) string {
+	return fmt.Sprintf(Translate(d.lang, format), args...)
+}
+
+// schedule returns the description of the schedules that parsers return, or
+// false for any other.
+func (d describer) schedule(s Schedule) (string, bool) {
+	switch s := s.(type) {
+	case *SpecSchedule:
+		return d.spec(s), true
+	case MissingDaySchedule:
+		desc := d.spec(s.Schedule)
+		switch s.Policy {
+		case MissingDayLast:
+			desc = d.tr("%s, or on the last day of months without that day", desc)
+		case MissingDayNext:
+			desc = d.tr("%s, or on the 1st of the month after months without that day", desc)
+		}
+		return desc, true
+	case YearSchedule:
+		desc, ok := d.schedule(s.Schedule)
+		if !ok {
+			return "", false
+		}
+		var years []string
+		for _, r := range runs(s.Years) {
+			if r[0] == r[1] {
+				years = append(years, strconv.Itoa(r[0]))
+			} else {
+				years = append(years, d.tr("%d through %d", r[0], r[1]))
+			}
+		}
+		return d.tr("%s, in %s", desc, d.list(years)), true
+	case ConstantDelaySchedule:
+		return d.tr("Every %s", formatDelay(s.Delay)), true
+	case *CalendarDelaySchedule:
+		return d.tr("Every %s", d.months(s.Months)), true
+	case AtSchedule:
+		return d.tr("Once at %s", s.Time.Format(time.RFC3339)), true
+	}
+	return "", false
+}
+
+// spec returns the description of the schedule: the times of day, then the
+// days, the months and the time zone.
+func (d describer) spec(s *SpecSchedule) string {
+	parts := []string{d.times(s)}
+	if days := d.days(s); days != "" {
+		parts = append(parts, days)
+	}
+	if s.Month&starBit == 0 {
+		parts = append(parts, d.tr("in %s", d.list(d.values(s.Month, months, monthNames))))
+	}
+	if s.Location != nil && s.Location != time.Local {
+		parts = append(parts, "("+s.Location.String()+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	monthNames = []string{"", "January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"}
+	dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	nthNames = []string{"", "the first %s", "the second %s", "the third %s", "the fourth %s", "the fifth %s"}
+)
+
+// times describes the times of day: as clock times if the second and minute
+// are single values, and field by field otherwise.
+func (d describer) times(s *SpecSchedule) string {
+	secs, mins, hrs := values(s.Second, seconds), values(s.Minute, minutes), values(s.Hour, hours)
+	if len(secs) == 1 && len(mins) == 1 {
+		clock := func(h uint) string {
+			if secs[0] != 0 {
+				return fmt.Sprintf("%02d:%02d:%02d", h, mins[0], secs[0])
+			}
+			return fmt.Sprintf("%02d:%02d", h, mins[0])
+		}
+		switch step, full := progression(hrs, hours); {
+		case s.Hour&starBit != 0 && mins[0] == 0 && secs[0] == 0:
+			return d.tr("Every hour")
+		case s.Hour&starBit != 0 && secs[0] == 0:
+			return d.tr("At %d minutes past the hour", mins[0])
+		case s.Hour&starBit != 0:
+			return d.tr("At %d minutes and %d seconds past the hour", mins[0], secs[0])
+		case step > 1 && full && mins[0] == 0 && secs[0] == 0:
+			return d.tr("Every %d hours", step)
+		case step > 1:
+			return d.tr("Every %d hours from %s through %s", step, clock(hrs[0]), clock(hrs[len(hrs)-1]))
+		case step == 1:
+			return d.tr("Every hour from %s through %s", clock(hrs[0]), clock(hrs[len(hrs)-1]))
+		}
+		var times []string
+		for _, h := range hrs {
+			times = append(times, clock(h))
+		}
+		return d.tr("At %s", d.list(times))
+	}
+
+	var parts []string
+	if s.Second != 1<<seconds.min {
+		parts = append(parts, d.field(s.Second, seconds, "second"))
+	}
+	if len(parts) == 0 || s.Minute&starBit == 0 {
+		parts = append(parts, d.field(s.Minute, minutes, "minute"))
+	}
+	switch step, full := progression(hrs, hours); {
+	case s.Hour&starBit != 0:
+	case step > 1 && full:
+		parts = append(parts, d.tr("in every %s hour", d.ordinal(int(step))))
+	case step == 1 || len(hrs) == 1:
+		parts = append(parts, d.tr("between %02d:00 and %02d:59", hrs[0], hrs[len(hrs)-1]))
+	default:
+		parts = append(parts, d.tr("in hours %s", d.list(d.values(s.Hour, hours, nil))))
+	}
+	desc := parts[0]
+	for _, part := range parts[1:] {
+		desc = d.tr("%s, %s", desc, part)
+	}
+	r, n := utf8.DecodeRuneInString(desc)
+	return string(unicode.ToUpper(r)) + desc[n:]
+}
+
+// field describes the values of a second or minute field.
+func (d describer) field(bits uint64, r bounds, unit string) string {
+	vals := values(bits, r)
+	step, full := progression(vals, r)
+	name := d.tr(unit)
+	switch {
+	case bits&starBit != 0:
+		return d.tr("every %s", name)
+	case step > 1 && full:
+		return d.tr("every %d %ss", step, name)
+	case step > 1:
+		return d.tr("every %d %ss from %s %d through %d", step, name, name, vals[0], vals[len(vals)-1])
+	case step == 1:
+		return d.tr("every %s from %d through %d", name, vals[0], vals[len(vals)-1])
+	case len(vals) == 1:
+		return d.tr("at %s %d", name, vals[0])
+	}
+	return d.tr("at %ss %s", name, d.list(d.values(bits, r, nil)))
+}
+
+// days describes the days of month and of week, or returns "" if every day
+// matches. When both are restricted, a day matching either runs.
+func (d describer) days(s *SpecSchedule) string {
+	var onDom, onDow string
+	if s.Dom&starBit == 0 {
+		var items []string
+		for _, v := range d.values(s.Dom, dom, nil) {
+			items = append(items, d.tr("day %s", v))
+		}
+		for day := 1; day <= 31; day++ {
+			if s.Dom&(1<<uint(weekdayDomShift+day)) != 0 {
+				items = append(items, d.tr("the weekday nearest day %d", day))
+			}
+		}
+		if s.Dom&lastDomBit != 0 {
+			items = append(items, d.tr("the last day"))
+		}
+		onDom = d.tr("on %s of the month", d.list(items))
+	}
+	if s.Dow&starBit == 0 {
+		var items []string
+		switch days := s.Dow & getBits(0, 6, 1); days {
+		case getBits(1, 5, 1):
+			items = append(items, d.tr("weekdays"))
+		case 1<<0 | 1<<6:
+			items = append(items, d.tr("weekends"))
+		default:
+			items = d.values(days, dow, dayNames)
+		}
+		nth := false
+		for n := 1; n <= 5; n++ {
+			for w := 0; w <= 6; w++ {
+				if s.Dow&(1<<uint(nthDowShift+(n-1)*7+w)) != 0 {
+					items = append(items, d.tr(nthNames[n], d.tr(dayNames[w])))
+					nth = true
+				}
+			}
+		}
+		if nth {
+			onDow = d.tr("on %s of the month", d.list(items))
+		} else {
+			onDow = d.tr("on %s", d.list(items))
+		}
+	}
+	switch {
+	case onDom != "" && onDow != "":
+		return d.tr("%s or %s", onDom, onDow)
+	case onDom != "":
+		return onDom
+	}
+	return onDow
+}
+
+// values returns the values of a field's bits, in order.
+func values(bits uint64, r bounds) []uint {
+	var vals []uint
+	for v := r.min; v <= r.max; v++ {
+		if bits&(1<<v) != 0 {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+// progression returns the step between the values if they are evenly spaced,
+// and whether they run from the start of the field to within a step of its
+// end. Two values far apart are a progression only if they run so, as */30
+// does for minutes. It returns 0 otherwise.
+func progression(vals []uint, r bounds) (step uint, full bool) {
+	n := len(vals)
+	if n < 2 {
+		return 0, false
+	}
+	step = vals[1] - vals[0]
+	for i := 2; i < n; i++ {
+		if vals[i]-vals[i-1] != step {
+			return 0, false
+		}
+	}
+	full = vals[0] == r.min && vals[n-1]+step > r.max
+	if step > 1 && n < 3 && !full {
+		return 0, false
+	}
+	return step, full
+}
+
+// values returns the values of a field's bits as numbers or translated names,
+// with runs of three or more as ranges.
+func (d describer) values(bits uint64, r bounds, names []string) []string {
+	name := func(v uint) string {
+		if names != nil {
+			return d.tr(names[v])
+		}
+		return strconv.Itoa(int(v))
+	}
+	var vals []int
+	for _, v := range values(bits, r) {
+		vals = append(vals, int(v))
+	}
+	var items []string
+	for _, run := range runs(vals) {
+		switch run[1] - run[0] {
+		case 0:
+			items = append(items, name(uint(run[0])))
+		case 1:
+			items = append(items, name(uint(run[0])), name(uint(run[1])))
+		default:
+			items = append(items, d.tr("%s through %s", name(uint(run[0])), name(uint(run[1]))))
+		}
+	}
+	return items
+}
+
+// runs returns the first and last of each run of consecutive values.
+func runs(vals []int) [][2]int {
+	var rs [][2]int
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		rs = append(rs, [2]int{vals[i], vals[j]})
+		i = j + 1
+	}
+	return rs
+}
+
+// list joins items as a list: "a", "a and b", "a, b and c" in English.
+func (d describer) list(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	list := items[0]
+	for i, item := range items[1:] {
+		if i == len(items)-2 {
+			list = d.tr("%s and %s", list, item)
+		} else {
+			list = d.tr("%s, %s", list, item)
+		}
+	}
+	return list
+}
+
+// ordinal returns n as an ordinal, such as "2nd" or "11th" in English.
+func (d describer) ordinal(n int) string {
+	format := "%dth"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		format = "%dst"
+	case n%10 == 2:
+		format = "%dnd"
+	case n%10 == 3:
+		format = "%drd"
+	}
+	return d.tr(format, n)
+}
+
+// formatDelay returns a duration without its zero minutes and seconds, such
+// as "1h30m" for 1h30m0s.
+func formatDelay(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// months returns a number of months in years and months, such as "3 months",
+// "year" or "1 year and 6 months" in English.
+func (d describer) months(n int) string {
+	unit := func(n int, one, many string) string {
+		if n == 1 {
+			return d.tr(one)
+		}
+		return d.tr(many, n)
+	}
+	years, months := n/12, n%12
+	switch {
+	case n == 1:
+		return d.tr("month")
+	case n == 12:
+		return d.tr("year")
+	case years == 0:
+		return unit(months, "1 month", "%d months")
+	case months == 0:
+		return unit(years, "1 year", "%d years")
+	}
+	return d.tr("%s and %s", unit(years, "1 year", "%d years"), unit(months, "1 month", "%d months"))
+}
@@ -0,0 +1,107 @@
+package cron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		spec, want string
+	}{
+		{"30 9 * JAN MON-FRI", "At 09:30 on weekdays in January"},
+		{"* * * * *", "Every minute"},
+		{"0 * * * *", "Every hour"},
+		{"15 * * * *", "At 15 minutes past the hour"},
+		{"*/5 * * * *", "Every 5 minutes"},
+		{"*/15 9-17 * * *", "Every 15 minutes, between 09:00 and 17:59"},
+		{"0 */2 * * *", "Every 2 hours"},
+		{"30 */6 * * *", "Every 6 hours from 00:30 through 18:30"},
+		{"0 9-17 * * *", "Every hour from 09:00 through 17:00"},
+		{"0 9,12,18 * * *", "At 09:00, 12:00 and 18:00"},
+		{"0,30 9 * * *", "Every 30 minutes, between 09:00 and 09:59"},
+		{"0,20 9 * * *", "At minutes 0 and 20, between 09:00 and 09:59"},
+		{"10-20 * * * *", "Every minute from 10 through 20"},
+		{"0 0 1,15 * *", "At 00:00 on day 1 and day 15 of the month"},
+		{"0 0 1-7 * *", "At 00:00 on day 1 through 7 of the month"},
+		{"0 0 L * *", "At 00:00 on the last day of the month"},
+		{"0 9 15W * *", "At 09:00 on the weekday nearest day 15 of the month"},
+		{"0 9 * * MON#2", "At 09:00 on the second Monday of the month"},
+		{"0 9 * * SAT,SUN", "At 09:00 on weekends"},
+		{"0 9 * * MON,WED,FRI", "At 09:00 on Monday, Wednesday and Friday"},
+		{"0 9 1 * MON", "At 09:00 on day 1 of the month or on Monday"},
+		{"0 0 1 */3 *", "At 00:00 on day 1 of the month in January, April, July and October"},
+		{"0 0 1 JAN-MAR *", "At 00:00 on day 1 of the month in January through March"},
+		{"TZ=America/New_York 0 9 * * *", "At 09:00 (America/New_York)"},
+		{"@daily", "At 00:00"},
+		{"@every 1h30m", "Every 1h30m"},
+		{"@every 1h", "Every 1h"},
+		{"@every 3mo", "Every 3 months"},
+		{"@every 1y", "Every year"},
+		{"@every 1y6mo", "Every 1 year and 6 months"},
+		{"@at 2025-07-01T09:00:00Z", "Once at 2025-07-01T09:00:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := Describe(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.spec, tt.want, got)
+		}
+	}
+}
+
+func TestParserDescribe(t *testing.T) {
+	tests := []struct {
+		parser     Parser
+		spec, want string
+	}{
+		{secondParser, "* * * * * *", "Every second"},
+		{secondParser, "*/10 * * * * *", "Every 10 seconds"},
+		{secondParser, "15 30 9 * * *", "At 09:30:15"},
+		{secondParser, "0 */5 * * * *", "Every 5 minutes"},
+		{secondParser, "*/30 5 * * * *", "Every 30 seconds, at minute 5"},
+		{NewParser(Minute | Hour | Dom | Month | Dow | Year), "0 0 1 1 * 2026,2028-2030", "At 00:00 on day 1 of the month in January, in 2026 and 2028 through 2030"},
+		{standardParser.WithMissingDays(MissingDayLast), "0 0 31 * *", "At 00:00 on day 31 of the month, or on the last day of months without that day"},
+	}
+	for _, tt := range tests {
+		got, err := tt.parser.Describe(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.spec, tt.want, got)
+		}
+	}
+
+	if _, err := Describe("* * *"); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+}
+
+func TestDescribeIn(t *testing.T) {
+	tests := []struct {
+		spec, lang, want string
+	}{
+		{"30 9 * JAN MON-FRI", "zh", "在 09:30 在工作日 在一月"},
+		{"*/15 9-17 * * *", "zh-CN", "每 15 分钟，在 09:00 至 17:59 之间"},
+		{"0 0 1,15 * *", "zh", "在 00:00 每月的1日和15日"},
+		{"0 9 * * MON#2", "zh", "在 09:00 每月的第二个星期一"},
+		{"0 9,12,18 * * *", "zh", "在 09:00，12:00和18:00"},
+		{"@every 1y6mo", "zh", "每1年和6个月"},
+		{"30 9 * JAN MON-FRI", "fr", "At 09:30 on weekdays in January"},
+	}
+	for _, tt := range tests {
+		got, err := DescribeIn(tt.spec, tt.lang)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s in %s: expected %q, got %q", tt.spec, tt.lang, tt.want, got)
+		}
+	}
+
+	RegisterCatalog("fr", Catalog{"Every %d hours": "Toutes les %d heures"})
+	defer RegisterCatalog("fr", nil)
+	if got, _ := DescribeIn("0 */2 * * *", "fr"); got != "Toutes les 2 heures" {
+		t.Errorf("expected the registered catalog used, got %q", got)
+	}
+}
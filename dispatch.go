@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Dispatcher hands due jobs off to be executed somewhere else, such as a fleet
+// of workers behind a message bus, instead of running them in this process.
+// This separates computing the schedule from executing the work.
+type Dispatcher interface {
+	// Dispatch is called from its own goroutine each time an entry is due.
+	Dispatch(d Dispatch) error
+}
+
+// Dispatch describes a single firing of an entry.
+type Dispatch struct {
+	// Entry is the ID of the entry within the dispatching Cron.
+	Entry EntryID `json:"entry"`
+
+	// Name is the name of the entry, which workers use to find the job to run.
+	Name string `json:"name"`
+
+	// Scheduled is the time the entry was scheduled to run.
+	Scheduled time.Time `json:"scheduled"`
+
+	// Payload is the encoded payload of the entry's job, if it is a
+	// PayloadJob such as a TypedJob. Decode it with DecodePayload.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// WithDispatcher sends due entries to the given Dispatcher rather than
+// running their jobs in this process. Entries should be given a name with
+// WithName so that workers can identify them.
+func WithDispatcher(d Dispatcher) Option {
+	return func(c *Cron) {
+		c.dispatcher = d
+	}
+}
+
+// dispatch hands the entry to the Dispatcher in a new goroutine.
+func (c *Cron) dispatch(e *Entry, scheduled time.Time) {
+	payload, err := marshalPayload(e)
+	if err != nil {
+		c.logger.Error(err, "dispatch", "entry", e.ID, "name", e.Name)
+		return
+	}
+	d := Dispatch{Entry: e.ID, Name: e.Name, Scheduled: scheduled, Payload: payload}
+	c.jobWaiter.Add(1)
+	go func() {
+		defer c.jobWaiter.Done()
+		if err := c.dispatcher.Dispatch(d); err != nil {
+			c.logger.Error(err, "dispatch", "entry", d.Entry, "name", d.Name)
+		}
+	}()
+}
@@ -0,0 +1,33 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+type dispatchFunc func(Dispatch) error
+
+func (f dispatchFunc) Dispatch(d Dispatch) error { return f(d) }
+
+// Due entries are handed to the Dispatcher instead of being run locally.
+func TestWithDispatcher(t *testing.T) {
+	dispatched := make(chan Dispatch, 1)
+	cron := New(WithParser(secondParser), WithDispatcher(dispatchFunc(func(d Dispatch) error {
+		dispatched <- d
+		return nil
+	})))
+	id, _ := cron.AddFunc("* * * * * ?", func() {
+		t.Error("expected the job not to run locally")
+	}, WithName("report"))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case d := <-dispatched:
+		if d.Entry != id || d.Name != "report" || d.Scheduled.IsZero() {
+			t.Errorf("unexpected dispatch: %+v", d)
+		}
+	case <-time.After(OneSecond):
+		t.Fatal("expected a dispatch")
+	}
+}
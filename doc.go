@@ -1,7 +1,7 @@
 /*
 Package cron implements a cron spec parser and job runner.
 
-Installation
+# Installation
 
 To download the specific tagged release, run:
 
@@ -11,9 +11,9 @@ Import it in your program as:
 
 	import "github.com/robfig/cron/v3"
 
-It requires Go 1.11 or later due to usage of Go Modules.
+It requires Go 1.18 or later due to usage of generics.
 
-Usage
+# Usage
 
 Callers may register Funcs to be invoked on a given schedule.  Cron will run
 them in their own goroutines.
@@ -36,7 +36,7 @@ them in their own goroutines.
 	..
 	c.Stop()  // Stop the scheduler (does not stop any jobs already running).
 
-CRON Expression Format
+# CRON Expression Format
 
 A cron expression represents a set of times, using 5 space-separated fields.
 
@@ -44,9 +44,9 @@ A cron expression represents a set of times, using 5 space-separated fields.
 	----------   | ---------- | --------------  | --------------------------
 	Minutes      | Yes        | 0-59            | * / , -
 	Hours        | Yes        | 0-23            | * / , -
-	Day of month | Yes        | 1-31            | * / , - ?
+	Day of month | Yes        | 1-31            | * / , - ? L W
 	Month        | Yes        | 1-12 or JAN-DEC | * / , -
-	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ?
+	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ? #
 
 Month and Day-of-week field values are case insensitive.  "SUN", "Sun", and
 "sun" are equally accepted.
@@ -54,7 +54,11 @@ Month and Day-of-week field values are case insensitive.  "SUN", "Sun", and
 The specific interpretation of the format is based on the Cron Wikipedia page:
 https://en.wikipedia.org/wiki/Cron
 
-Alternative Formats
+Describe explains a spec in English, for people who do not read cron:
+"30 9 * JAN MON-FRI" is "At 09:30 on weekdays in January". DescribeIn
+explains it in another language, with the same catalogs as parse errors.
+
+# Alternative Formats
 
 Alternative Cron expression formats support other fields like seconds. You can
 implement that by creating a custom Parser as follows.
@@ -73,7 +77,17 @@ parser you saw earlier, except that its seconds field is REQUIRED:
 That emulates Quartz, the most popular alternative Cron schedule format:
 http://www.quartz-scheduler.org/documentation/quartz-2.x/tutorials/crontrigger.html
 
-Special Characters
+Quartz also has an optional 7th field, the year, from 1970 to 2099, which the
+Year and YearOptional options add after the day of week:
+
+	p := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.YearOptional)
+	p.Parse("0 0 0 1 1 * 2026")      // midnight on January 1st, 2026
+	p.Parse("0 0 0 1 1 * 2026-2030") // and in each of the four years after
+
+A schedule whose years have all passed returns the zero time, so its entry
+never runs again.
+
+# Special Characters
 
 Asterisk ( * )
 
@@ -105,7 +119,40 @@ Question mark ( ? )
 Question mark may be used instead of '*' for leaving either day-of-month or
 day-of-week blank.
 
-Predefined schedules
+# L
+
+L in the 3rd field (day of month) means the last day of the month, whichever
+day that is: "0 0 L * *" runs at midnight on January 31st, February 28th or
+29th, April 30th, and so on. It may be listed with days, as in "1,15,L", but
+not used in a range or with a step.
+
+# W
+
+W after a day in the 3rd field (day of month) means the weekday nearest that
+day of the month: "0 9 15W * *" runs on the 15th if it is a weekday, on Friday
+the 14th if the 15th is a Saturday, and on Monday the 16th if it is a Sunday.
+The weekday is always in the same month, so "1W" runs on Monday the 3rd when
+the 1st is a Saturday. Like L, it may be listed with days but not used in a
+range or with a step.
+
+Hash ( # )
+
+A day followed by # and a number from 1 to 5 in the 5th field (day of week)
+means that occurrence of the day in the month: "0 9 * * MON#2" runs at 9am on
+the second Monday of each month, and "FRI#5" only in months with five
+Fridays. It may be listed with days, but not used in a range or with a step.
+
+# H
+
+H stands for a value of the field derived from a seed, as in Jenkins, so that
+many jobs with the same spec do not all start at the same instant. "H H * * *"
+runs once a day at a time that depends on the seed, "H/15 * * * *" every 15
+minutes from a minute among the first 15, and "H(0-29) 9 * * *" at 9am and a
+minute in the first half hour. An H in the day of month is a day from 1 to 28.
+Entries added with a name, by WithName, take it as the seed; Parser's
+ParseWithSeed takes any other.
+
+# Predefined schedules
 
 You may use one of several pre-defined schedules in place of a cron expression.
 
@@ -117,12 +164,12 @@ You may use one of several pre-defined schedules in place of a cron expression.
 	@daily (or @midnight)  | Run once a day, midnight                   | 0 0 * * *
 	@hourly                | Run once an hour, beginning of hour        | 0 * * * *
 
-Intervals
+# Intervals
 
 You may also schedule a job to execute at fixed intervals, starting at the time it's added
 or cron is run. This is supported by formatting the cron spec like this:
 
-    @every <duration>
+	@every <duration>
 
 where "duration" is a string accepted by time.ParseDuration
 (http://golang.org/pkg/time/#ParseDuration).
@@ -130,17 +177,33 @@ where "duration" is a string accepted by time.ParseDuration
 For example, "@every 1h30m10s" would indicate a schedule that activates after
 1 hour, 30 minutes, 10 seconds, and then every interval after that.
 
+The duration may instead count calendar years and months, as in "@every 3mo",
+"@every 1y" or "@every 1y6mo". Such intervals keep to the same day of the
+month and time of day, clamping days that a month lacks to its last day, where
+"@every 2160h" would drift from quarter to quarter. See CalendarDelaySchedule.
+
 Note: The interval does not take the job runtime into account.  For example,
 if a job takes 3 minutes to run, and it is scheduled to run every 5 minutes,
 it will have only 2 minutes of idle time between each run.
 
-Time zones
+# One-off jobs
+
+A job may instead run just once, at a time given in RFC 3339 format:
+
+	@at 2025-07-01T09:00:00Z
+
+Afterwards its entry has no next time and never runs again; it stays among the
+entries until it is removed. See AtSchedule.
+
+# Time zones
 
 By default, all interpretation and scheduling is done in the machine's local
 time zone (time.Local). You can specify a different time zone on construction:
 
-      cron.New(
-          cron.WithLocation(time.UTC))
+	cron.New(
+	    cron.WithLocation(time.UTC))
+
+or change it later with SetLocation, which reschedules the entries that use it.
 
 Individual cron schedules may also override the time zone they are to be
 interpreted in by providing an additional space-separated field at the beginning
@@ -166,10 +229,14 @@ For example:
 
 The prefix "TZ=(TIME ZONE)" is also supported for legacy compatibility.
 
+Time zones are loaded from the time zone database when specs are parsed. A
+long-running process can pick up later changes to it, such as new daylight
+saving rules, with ReloadTimeZones.
+
 Be aware that jobs scheduled during daylight-savings leap-ahead transitions will
 not be run!
 
-Job Wrappers
+# Job Wrappers
 
 A Cron runner may be configured with a chain of job wrappers to add
 cross-cutting functionality to all submitted jobs. For example, they may be used
@@ -192,7 +259,7 @@ Install wrappers for individual jobs by explicitly wrapping them:
 		cron.SkipIfStillRunning(logger),
 	).Then(job)
 
-Thread safety
+# Thread safety
 
 Since the Cron service runs concurrently with the calling code, some amount of
 care must be taken to ensure proper synchronization.
@@ -200,7 +267,11 @@ care must be taken to ensure proper synchronization.
 All cron methods are designed to be correctly synchronized as long as the caller
 ensures that invocations have a clear happens-before ordering between them.
 
-Logging
+Entries may be added and removed from many goroutines at once. They are
+sharded by ID, one shard per CPU by default, so that such calls rarely wait for
+each other or for the scheduler; see WithEntryShards.
+
+# Logging
 
 Cron defines a Logger interface that is a subset of the one defined in
 github.com/go-logr/logr. It has two logging levels (Info and Error), and
@@ -216,16 +287,29 @@ Activate it with a one-off logger as follows:
 		cron.WithLogger(
 			cron.VerbosePrintfLogger(log.New(os.Stdout, "cron: ", log.LstdFlags))))
 
+# Events
+
+Jobs that implement ContextJob receive a context for each run and may report
+failure by returning an error; AddContextFunc adds such a func directly.
+Listeners registered with WithEventListener are told when each entry is
+scheduled, and when each run starts, finishes, or fails:
+
+	cron.New(
+		cron.WithEventListener(func(ev cron.Event) {
+			if ev.Type == cron.EventFailed {
+				log.Printf("%s failed after %v: %v", ev.Name, ev.Duration, ev.Err)
+			}
+		}))
 
-Implementation
+# Implementation
 
 Cron entries are stored in an array, sorted by their next activation time.  Cron
 sleeps until the next job is due to be run.
 
 Upon waking:
- - it runs each entry that is active on that second
- - it calculates the next run times for the jobs that were run
- - it re-sorts the array of entries by next activation time.
- - it goes to sleep until the soonest job.
+  - it runs each entry that is active on that second
+  - it calculates the next run times for the jobs that were run
+  - it re-sorts the array of entries by next activation time.
+  - it goes to sleep until the soonest job.
 */
 package cron
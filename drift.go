@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// runStart is when a run was scheduled for, and when it started.
+type runStart struct {
+	scheduled, start time.Time
+}
+
+// startHistory holds the most recent run starts of an entry in a ring.
+type startHistory struct {
+	starts []runStart
+	next   int
+}
+
+// add adds a run start, keeping the most recent size of them.
+func (h *startHistory) add(rs runStart, size int) {
+	if len(h.starts) < size {
+		h.starts = append(h.starts, rs)
+		return
+	}
+	h.starts[h.next] = rs
+	h.next = (h.next + 1) % size
+}
+
+// DriftReport compares the scheduled and actual start times of an entry's
+// runs over a window, so that a scheduler or executor short of capacity shows
+// up as runs that start late, time after time. It is encoded to JSON with
+// durations in nanoseconds.
+type DriftReport struct {
+	Entry EntryID `json:"entry"`
+	Name  string  `json:"name,omitempty"`
+
+	// Runs counts the entry's runs scheduled in the window, and Late those of
+	// them that started more than the threshold after their scheduled time.
+	Runs int `json:"runs"`
+	Late int `json:"late"`
+
+	// How long after their scheduled times the runs started.
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P95  time.Duration `json:"p95"`
+	Max  time.Duration `json:"max"`
+
+	// Systematic is whether most of the runs were late, rather than a few
+	// outliers.
+	Systematic bool `json:"systematic"`
+}
+
+// Drift reports, for each entry, how late its runs scheduled since the given
+// time started, counting those later than threshold as late. Only the most
+// recent 100 runs of each entry are kept, or as many as WithHistorySize
+// gives, and entries without runs in the window are left out. Entries late
+// systematically come first, then the others, latest first by median.
+//
+//	for _, r := range c.Drift(time.Now().Add(-time.Hour), time.Second) {
+//		if r.Systematic {
+//			log.Printf("%s starts %v late", r.Name, r.P50)
+//		}
+//	}
+func (c *Cron) Drift(since time.Time, threshold time.Duration) []DriftReport {
+	entries := c.Entries()
+	var reports []DriftReport
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	for _, e := range entries {
+		s, ok := c.stats.entries[e.ID]
+		if !ok {
+			continue
+		}
+		var lateness durationStats
+		r := DriftReport{Entry: e.ID, Name: e.Name}
+		var total time.Duration
+		for _, rs := range s.starts.starts {
+			if rs.scheduled.Before(since) {
+				continue
+			}
+			d := rs.start.Sub(rs.scheduled)
+			lateness.samples = append(lateness.samples, d)
+			total += d
+			if d > threshold {
+				r.Late++
+			}
+			if d > r.Max {
+				r.Max = d
+			}
+		}
+		if r.Runs = len(lateness.samples); r.Runs == 0 {
+			continue
+		}
+		r.Mean = total / time.Duration(r.Runs)
+		r.P50 = lateness.percentile(0.50)
+		r.P95 = lateness.percentile(0.95)
+		r.Systematic = 2*r.Late > r.Runs
+		reports = append(reports, r)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Systematic != reports[j].Systematic {
+			return reports[i].Systematic
+		}
+		return reports[i].P50 > reports[j].P50
+	})
+	return reports
+}
@@ -0,0 +1,35 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrift(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)))
+	slow, _ := c.AddFunc("@hourly", func() {}, WithName("slow"))
+	spiky, _ := c.AddFunc("@hourly", func() {}, WithName("spiky"))
+	c.AddFunc("@hourly", func() {}, WithName("idle"))
+
+	// slow starts 2s late every time, and spiky was late once.
+	for i := 1; i <= 4; i++ {
+		c.runEntry(c.Entry(slow), now.Add(-2*time.Second))
+		c.runEntry(c.Entry(spiky), now)
+	}
+	c.runEntry(c.Entry(spiky), now.Add(-5*time.Second))
+	c.runEntry(c.Entry(slow), now.Add(-2*time.Hour)) // outside the window
+
+	reports := c.Drift(now.Add(-time.Hour), time.Second)
+	if len(reports) != 2 {
+		t.Fatalf("expected reports for the entries that ran, got %+v", reports)
+	}
+	r := reports[0]
+	if r.Name != "slow" || !r.Systematic || r.Runs != 4 || r.Late != 4 || r.P50 != 2*time.Second || r.Mean != 2*time.Second {
+		t.Errorf("unexpected report: %+v", r)
+	}
+	r = reports[1]
+	if r.Name != "spiky" || r.Systematic || r.Runs != 5 || r.Late != 1 || r.Max != 5*time.Second || r.P50 != 0 {
+		t.Errorf("unexpected report: %+v", r)
+	}
+}
@@ -0,0 +1,20 @@
+package cron
+
+// Elector decides whether this process is the leader of a group of processes
+// running the same schedule. Only the leader fires jobs; the others keep
+// computing the schedule so that they can take over at any time.
+//
+// Implementations campaign for leadership in the background, for example
+// through a lease in a coordination service, and IsLeader reports the latest
+// outcome. See the cronk8s package for one backed by Kubernetes Leases.
+type Elector interface {
+	IsLeader() bool
+}
+
+// WithElector only fires jobs while the given Elector reports that this
+// process is the leader.
+func WithElector(e Elector) Option {
+	return func(c *Cron) {
+		c.elector = e
+	}
+}
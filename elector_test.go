@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type electorFunc func() bool
+
+func (f electorFunc) IsLeader() bool { return f() }
+
+func TestWithElector(t *testing.T) {
+	var leader int32
+	var runs int32
+	cron := New(WithParser(secondParser), WithElector(electorFunc(func() bool {
+		return atomic.LoadInt32(&leader) == 1
+	})))
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Fatalf("expected no runs as a follower, got %d", n)
+	}
+	atomic.StoreInt32(&leader, 1)
+	time.Sleep(OneSecond)
+	if n := atomic.LoadInt32(&runs); n == 0 {
+		t.Error("expected runs as the leader")
+	}
+}
+
+// Triggered runs are refused while the Cron is not the leader.
+func TestTriggerFollower(t *testing.T) {
+	var leader int32
+	ran := make(chan struct{}, 1)
+	c := New(WithElector(electorFunc(func() bool { return atomic.LoadInt32(&leader) == 1 })))
+	id, _ := c.AddFunc("@yearly", func() { ran <- struct{}{} })
+	if err := c.Trigger(id); err != ErrNotFiring {
+		t.Errorf("expected a follower to refuse the trigger, got %v", err)
+	}
+	atomic.StoreInt32(&leader, 1)
+	if err := c.Trigger(id); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(OneSecond):
+		t.Error("expected the leader to run the entry")
+	}
+}
@@ -0,0 +1,187 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+)
+
+// KeyProvider supplies the keys of an EncryptedStore, such as from a key
+// management service. Keys are AES keys of 16, 24 or 32 bytes.
+type KeyProvider interface {
+	// CurrentKey returns the key to encrypt new values with, and its ID.
+	CurrentKey(ctx context.Context) (id string, key []byte, err error)
+
+	// Key returns the key with the ID, to decrypt values encrypted with it.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeys is a KeyProvider of a fixed set of keys, by ID. New values are
+// encrypted with the key of Current, and the others still decrypt values
+// written before it was rotated in.
+type StaticKeys struct {
+	Current string
+	Keys    map[string][]byte
+}
+
+// CurrentKey returns the key of Current.
+func (k StaticKeys) CurrentKey(ctx context.Context) (string, []byte, error) {
+	key, err := k.Key(ctx, k.Current)
+	return k.Current, key, err
+}
+
+// Key returns the key with the ID.
+func (k StaticKeys) Key(ctx context.Context, id string) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("cron: no key %q", id)
+	}
+	return key, nil
+}
+
+// envelopeMagic begins every value of an EncryptedStore, followed by the
+// length of the key ID, the key ID, the nonce and the sealed value.
+const envelopeMagic = "cronaead1"
+
+// EncryptedStore is a Store that encrypts the values of another with
+// AES-GCM, for Stores that keep job parameters containing credentials, such
+// as the payloads of PayloadJobs in a write-ahead log. Each value is bound to
+// its key, so that values cannot be moved between keys unnoticed. Keys
+// themselves, which hold entry names and run IDs, are not encrypted.
+//
+//	store := cron.NewEncryptedStore(redisStore, cron.StaticKeys{
+//		Current: "2024-03",
+//		Keys:    map[string][]byte{"2024-03": key},
+//	})
+//	c := cron.New(cron.WithWAL(store))
+type EncryptedStore struct {
+	store Store
+	keys  KeyProvider
+}
+
+// NewEncryptedStore returns an EncryptedStore that keeps its values in s,
+// encrypted with the keys of keys. Values that are not encrypted, such as
+// those written to s before, cannot be read through it.
+func NewEncryptedStore(s Store, keys KeyProvider) *EncryptedStore {
+	return &EncryptedStore{store: s, keys: keys}
+}
+
+// Get returns the decrypted value of key, or ErrNotFound.
+func (s *EncryptedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	sealed, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, key, sealed)
+}
+
+// Put sets the value of key, encrypted.
+func (s *EncryptedStore) Put(ctx context.Context, key string, value []byte) error {
+	sealed, err := s.seal(ctx, key, value)
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, key, sealed)
+}
+
+// CompareAndSwap sets the value of key to new if its decrypted value is old.
+func (s *EncryptedStore) CompareAndSwap(ctx context.Context, key string, old, new []byte) (bool, error) {
+	// The same value encrypts differently each time, so compare the
+	// decrypted value, and swap the exact ciphertext it came from.
+	var sealedOld []byte
+	if old != nil {
+		sealed, err := s.store.Get(ctx, key)
+		if err == ErrNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		current, err := s.open(ctx, key, sealed)
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(current, old) {
+			return false, nil
+		}
+		sealedOld = sealed
+	}
+	sealedNew, err := s.seal(ctx, key, new)
+	if err != nil {
+		return false, err
+	}
+	return s.store.CompareAndSwap(ctx, key, sealedOld, sealedNew)
+}
+
+// Delete removes key.
+func (s *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+// List returns the keys with the given prefix, in sorted order.
+func (s *EncryptedStore) List(ctx context.Context, prefix string) ([]string, error) {
+	return s.store.List(ctx, prefix)
+}
+
+// seal encrypts the value of key with the current key.
+func (s *EncryptedStore) seal(ctx context.Context, key string, value []byte) ([]byte, error) {
+	id, k, err := s.keys.CurrentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(id) > 255 {
+		return nil, fmt.Errorf("cron: key ID longer than 255 bytes: %q", id)
+	}
+	aead, err := newAEAD(k)
+	if err != nil {
+		return nil, err
+	}
+	sealed := append([]byte(envelopeMagic), byte(len(id)))
+	sealed = append(sealed, id...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, value, []byte(key)), nil
+}
+
+// open decrypts the value of key.
+func (s *EncryptedStore) open(ctx context.Context, key string, sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, []byte(envelopeMagic)) || len(sealed) == len(envelopeMagic) {
+		return nil, fmt.Errorf("cron: value of %s is not encrypted", key)
+	}
+	rest := sealed[len(envelopeMagic):]
+	n := int(rest[0])
+	if len(rest) < 1+n {
+		return nil, fmt.Errorf("cron: value of %s is truncated", key)
+	}
+	id, rest := string(rest[1:1+n]), rest[1+n:]
+	k, err := s.keys.Key(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(k)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("cron: value of %s is truncated", key)
+	}
+	value, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("cron: value of %s cannot be decrypted: %v", key, err)
+	}
+	return value, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
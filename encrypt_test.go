@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	raw := NewMemoryStore()
+	keys := StaticKeys{Current: "a", Keys: map[string][]byte{"a": bytes.Repeat([]byte{1}, 32)}}
+	s := NewEncryptedStore(raw, keys)
+
+	secret := []byte(`{"password":"hunter2"}`)
+	if err := s.Put(ctx, "wal/1", secret); err != nil {
+		t.Fatal(err)
+	}
+	if sealed, _ := raw.Get(ctx, "wal/1"); bytes.Contains(sealed, []byte("hunter2")) {
+		t.Errorf("expected the value encrypted, got %q", sealed)
+	}
+	if got, err := s.Get(ctx, "wal/1"); err != nil || !bytes.Equal(got, secret) {
+		t.Errorf("expected the value back, got %q, %v", got, err)
+	}
+
+	// Values are bound to their keys.
+	sealed, _ := raw.Get(ctx, "wal/1")
+	raw.Put(ctx, "wal/2", sealed)
+	if _, err := s.Get(ctx, "wal/2"); err == nil {
+		t.Error("expected a value moved to another key to fail")
+	}
+	raw.Put(ctx, "wal/3", []byte("plain"))
+	if _, err := s.Get(ctx, "wal/3"); err == nil {
+		t.Error("expected an unencrypted value to fail")
+	}
+
+	// Rotating the key keeps older values readable.
+	keys.Current, keys.Keys["b"] = "b", bytes.Repeat([]byte{2}, 16)
+	s = NewEncryptedStore(raw, keys)
+	if got, err := s.Get(ctx, "wal/1"); err != nil || !bytes.Equal(got, secret) {
+		t.Errorf("expected the value under the old key, got %q, %v", got, err)
+	}
+}
+
+func TestEncryptedStoreCompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	s := NewEncryptedStore(NewMemoryStore(), StaticKeys{Current: "a", Keys: map[string][]byte{"a": make([]byte, 16)}})
+	if ok, err := s.CompareAndSwap(ctx, "k", nil, []byte("1")); !ok || err != nil {
+		t.Fatalf("expected to create the key, got %v, %v", ok, err)
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "k", nil, []byte("2")); ok {
+		t.Error("expected creating an existing key to fail")
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "k", []byte("0"), []byte("2")); ok {
+		t.Error("expected a swap from the wrong value to fail")
+	}
+	if ok, err := s.CompareAndSwap(ctx, "k", []byte("1"), []byte("2")); !ok || err != nil {
+		t.Errorf("expected to swap, got %v, %v", ok, err)
+	}
+	if got, _ := s.Get(ctx, "k"); string(got) != "2" {
+		t.Errorf("expected 2, got %q", got)
+	}
+}
+
+// A Cron keeps working through an EncryptedStore.
+func TestEncryptedStoreFencing(t *testing.T) {
+	s := NewEncryptedStore(NewMemoryStore(), StaticKeys{Current: "a", Keys: map[string][]byte{"a": make([]byte, 32)}})
+	c := New(WithFencing(s))
+	for want := uint64(1); want <= 3; want++ {
+		if token, err := c.nextFencingToken(context.Background()); err != nil || token != want {
+			t.Errorf("expected token %d, got %d, %v", want, token, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// OptionsFromEnv returns the Options that the CRON_* environment variables
+// set, so that containerized deployments can configure a Cron without files:
+//
+//	CRON_TZ           the default time zone, as WithLocation
+//	CRON_CONCURRENCY  how many jobs may run at once, as WithConcurrencyLimit
+//	CRON_HISTORY      how many runs of each entry to keep, as WithHistorySize
+//
+// Variables that are unset or empty set nothing. Options given after these
+// to New override them:
+//
+//	opts, err := cron.OptionsFromEnv()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	c := cron.New(append(opts, cron.WithLogger(logger))...)
+func OptionsFromEnv() ([]Option, error) {
+	return optionsFromEnv(os.Getenv)
+}
+
+func optionsFromEnv(getenv func(string) string) ([]Option, error) {
+	var opts []Option
+	if tz := getenv("CRON_TZ"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("cron: CRON_TZ: %v", err)
+		}
+		opts = append(opts, WithLocation(loc))
+	}
+	positive := func(name string) (int, bool, error) {
+		v := getenv(name)
+		if v == "" {
+			return 0, false, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, false, fmt.Errorf("cron: %s: %q is not a positive number", name, v)
+		}
+		return n, true, nil
+	}
+	if n, ok, err := positive("CRON_CONCURRENCY"); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithConcurrencyLimit(n))
+	}
+	if n, ok, err := positive("CRON_HISTORY"); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithHistorySize(n))
+	}
+	return opts, nil
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv("CRON_TZ", "Asia/Tokyo")
+	t.Setenv("CRON_CONCURRENCY", "4")
+	t.Setenv("CRON_HISTORY", "500")
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(opts...)
+	if c.Location().String() != "Asia/Tokyo" {
+		t.Errorf("expected Asia/Tokyo, got %v", c.Location())
+	}
+	if c.limiter == nil || c.limiter.limit != 4 {
+		t.Error("expected a concurrency limit of 4")
+	}
+	if c.stats.history() != 500 {
+		t.Errorf("expected a history of 500, got %d", c.stats.history())
+	}
+}
+
+func TestOptionsFromEnvUnset(t *testing.T) {
+	opts, err := optionsFromEnv(func(string) string { return "" })
+	if err != nil || len(opts) != 0 {
+		t.Errorf("expected no options, got %d and %v", len(opts), err)
+	}
+}
+
+func TestOptionsFromEnvInvalid(t *testing.T) {
+	for name, value := range map[string]string{
+		"CRON_TZ":          "Mars/Olympus",
+		"CRON_CONCURRENCY": "0",
+		"CRON_HISTORY":     "lots",
+	} {
+		_, err := optionsFromEnv(func(n string) string {
+			if n == name {
+				return value
+			}
+			return ""
+		})
+		if err == nil {
+			t.Errorf("%s=%s: expected an error", name, value)
+		}
+	}
+}
+
+func TestHistorySize(t *testing.T) {
+	c := New(WithHistorySize(3))
+	for i := 1; i <= 5; i++ {
+		c.stats.add(1, time.Duration(i)*time.Second, nil)
+	}
+	if got := c.stats.entries[1].durations.samples; len(got) != 3 {
+		t.Errorf("expected 3 samples kept, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+package cron
+
+import "time"
+
+// EventType identifies a point in the lifecycle of an entry's runs.
+type EventType int
+
+const (
+	EventScheduled      EventType = iota // The entry's next run time was computed
+	EventStarted                         // A run of the entry's job started
+	EventFinished                        // A run completed successfully
+	EventFailed                          // A run returned an error or panicked
+	EventStuck                           // A run stopped making progress
+	EventSoftTimeout                     // A run passed its soft deadline
+	EventTimeout                         // A run passed its hard deadline and was cancelled
+	EventBudgetExceeded                  // A run was skipped because the entry used up its daily budget
+	EventOverrunWarning                  // The entry's runs take nearly as long as its interval
+)
+
+var eventTypeNames = []string{
+	"scheduled",
+	"started",
+	"finished",
+	"failed",
+	"stuck",
+	"soft timeout",
+	"timeout",
+	"budget exceeded",
+	"overrun warning",
+}
+
+func (t EventType) String() string {
+	if int(t) < len(eventTypeNames) {
+		return eventTypeNames[t]
+	}
+	return "unknown"
+}
+
+// Event describes something that happened to an entry.
+type Event struct {
+	Type EventType
+
+	// Entry and Name identify the entry.
+	Entry EntryID
+	Name  string
+
+	// RunID identifies the run, for all but EventScheduled.
+	RunID string
+
+	// Scheduled is the time the run was scheduled for. For EventScheduled, it
+	// is the entry's next run time.
+	Scheduled time.Time
+
+	// Time is when the event occurred.
+	Time time.Time
+
+	// Duration is how long the run took, for EventFinished and EventFailed.
+	// For EventStuck, it is how long the run has gone without progress, and
+	// for the timeout events, it is the deadline that passed. For
+	// EventBudgetExceeded, it is the run time used so far that day, and for
+	// EventOverrunWarning, the mean duration of the entry's recent runs.
+	Duration time.Duration
+
+	// Err is the error returned by the run, for EventFailed.
+	Err error
+}
+
+// EventListener receives events about entries. Listeners are called
+// synchronously, some of them from the scheduling goroutine, so they must not
+// block.
+type EventListener func(Event)
+
+// WithEventListener registers a listener for events about all entries.
+// It may be given more than once to register several listeners.
+func WithEventListener(l EventListener) Option {
+	return func(c *Cron) {
+		c.listeners = append(c.listeners, l)
+	}
+}
+
+// emit sends the event to every registered listener.
+func (c *Cron) emit(ev Event) {
+	for _, l := range c.listeners {
+		l(ev)
+	}
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Listeners see an entry scheduled, started, and then finished or failed.
+func TestEventListener(t *testing.T) {
+	events := make(chan Event, 10)
+	cron := New(WithParser(secondParser), WithEventListener(func(ev Event) {
+		events <- ev
+	}))
+	cron.AddContextFunc("* * * * * ?", func(ctx context.Context) error {
+		if _, ok := runFromContext(ctx); !ok {
+			t.Error("expected run information in the context")
+		}
+		return errors.New("boom")
+	}, WithName("failing"))
+	cron.Start()
+	defer cron.Stop()
+
+	var got []EventType
+	timeout := time.After(OneSecond)
+	for len(got) == 0 || got[len(got)-1] != EventFailed {
+		select {
+		case ev := <-events:
+			if ev.Name != "failing" {
+				t.Errorf("unexpected entry name %q", ev.Name)
+			}
+			if ev.Type == EventFailed && (ev.Err == nil || ev.Err.Error() != "boom") {
+				t.Errorf("expected the job's error, got %v", ev.Err)
+			}
+			got = append(got, ev.Type)
+		case <-timeout:
+			t.Fatalf("expected a failure, got %v", got)
+		}
+	}
+	if got[0] != EventScheduled {
+		t.Errorf("expected the entry to be scheduled first, got %v", got)
+	}
+	for i, typ := range got {
+		if typ == EventStarted {
+			break
+		}
+		if i == len(got)-1 {
+			t.Errorf("expected the run to start before failing, got %v", got)
+		}
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	if s := EventFailed.String(); s != "failed" {
+		t.Errorf("unexpected name %q", s)
+	}
+	if s := EventType(42).String(); s != "unknown" {
+		t.Errorf("unexpected name %q", s)
+	}
+}
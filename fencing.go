@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// fencingKey is the Store key of the last issued fencing token.
+const fencingKey = "fencing"
+
+// WithFencing issues each run a fencing token from the given Store just
+// before its job starts. Tokens increase monotonically across every process
+// sharing the Store, so a system that a job writes to can remember the
+// highest token it has seen and reject writes carrying a lower one. That
+// stops a run from a process that lost its lock or leadership during a
+// network partition from overwriting the work of a newer run.
+//
+// Jobs read their token with FencingTokenFromContext. A run whose token
+// cannot be issued is abandoned and logged as an error.
+func WithFencing(s Store) Option {
+	return func(c *Cron) {
+		c.fencing = s
+	}
+}
+
+// FencingTokenFromContext returns the fencing token issued to the run that
+// ctx was passed to. It returns false if ctx did not come from a Cron
+// configured WithFencing.
+func FencingTokenFromContext(ctx context.Context) (uint64, bool) {
+	ri, ok := runFromContext(ctx)
+	if !ok || ri.token == 0 {
+		return 0, false
+	}
+	return ri.token, true
+}
+
+// nextFencingToken increments the token in the Store and returns it.
+func (c *Cron) nextFencingToken(ctx context.Context) (uint64, error) {
+	for {
+		old, err := c.fencing.Get(ctx, fencingKey)
+		var last uint64
+		switch {
+		case err == ErrNotFound:
+			old = nil
+		case err != nil:
+			return 0, err
+		default:
+			data, err := decodeValue("fencing", old, c.migration)
+			if err == nil {
+				last, err = strconv.ParseUint(string(data), 10, 64)
+			}
+			if err != nil {
+				return 0, fmt.Errorf("cron: corrupt fencing token %q", old)
+			}
+		}
+		next := last + 1
+		ok, err := c.fencing.CompareAndSwap(ctx, fencingKey, old, encodeValue([]byte(strconv.FormatUint(next, 10))))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return next, nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFencingTokens(t *testing.T) {
+	store := NewMemoryStore()
+	var (
+		mu     sync.Mutex
+		tokens = map[uint64]bool{}
+	)
+	job := func(ctx context.Context) error {
+		token, ok := FencingTokenFromContext(ctx)
+		if !ok {
+			t.Error("expected a fencing token")
+		}
+		mu.Lock()
+		tokens[token] = true
+		mu.Unlock()
+		return nil
+	}
+
+	// Two schedulers sharing the store never issue the same token.
+	c1, c2 := New(WithFencing(store)), New(WithFencing(store))
+	id1, _ := c1.AddContextFunc("@every 1h", job)
+	id2, _ := c2.AddContextFunc("@every 1h", job)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); c1.runEntry(c1.Entry(id1), time.Now()) }()
+		go func() { defer wg.Done(); c2.runEntry(c2.Entry(id2), time.Now()) }()
+	}
+	wg.Wait()
+	for i := uint64(1); i <= 20; i++ {
+		if !tokens[i] {
+			t.Errorf("expected token %d issued once, got %v", i, tokens)
+		}
+	}
+
+	if _, ok := FencingTokenFromContext(context.Background()); ok {
+		t.Error("expected no token outside a run")
+	}
+}
+
+func TestFencingCorruptToken(t *testing.T) {
+	store := NewMemoryStore()
+	store.Put(context.Background(), fencingKey, []byte("x"))
+	c := New(WithFencing(store))
+	ran := false
+	id, _ := c.AddFunc("@every 1h", func() { ran = true })
+	c.runEntry(c.Entry(id), time.Now())
+	if ran {
+		t.Error("expected the run abandoned without a token")
+	}
+}
@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// TimeWindow is a daily period of time, on some days of the week. Start and
+// End are offsets from midnight. A window whose End is not after its Start
+// runs past midnight into the next day, and belongs to the day it starts on.
+type TimeWindow struct {
+	// Days the window starts on. Empty means every day.
+	Days []time.Weekday
+
+	Start, End time.Duration
+}
+
+// Weekdays are Monday through Friday, for business-hours TimeWindows.
+var Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// onDay reports whether the window starts on the given day.
+func (w TimeWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether t falls in the window.
+func (w TimeWindow) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	tod := t.Sub(midnight)
+	if w.Start < w.End {
+		return w.onDay(t.Weekday()) && tod >= w.Start && tod < w.End
+	}
+	return (w.onDay(t.Weekday()) && tod >= w.Start) ||
+		(w.onDay(midnight.AddDate(0, 0, -1).Weekday()) && tod < w.End)
+}
+
+// nextStart returns the first start of the window after t, or the zero time
+// if it has no days.
+func (w TimeWindow) nextStart(t time.Time) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i <= 7; i++ {
+		day := midnight.AddDate(0, 0, i)
+		if start := day.Add(w.Start); w.onDay(day.Weekday()) && start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// GatePolicy selects what OnlyDuring does with invocations outside its
+// windows.
+type GatePolicy int
+
+const (
+	// GateSkip drops invocations outside the windows.
+	GateSkip GatePolicy = iota
+
+	// GateDefer holds invocations outside the windows until the next window
+	// opens. Deferred runs are listed by Pending.
+	GateDefer
+)
+
+// OnlyDuring runs the Job only during the given windows, evaluated in the
+// time zone loc, whatever the schedule that invokes it. Invocations outside
+// the windows are skipped or deferred according to the policy. It is meant
+// for wrapping schedules you cannot edit, such as ones loaded from elsewhere,
+// so that they respect business hours:
+//
+//	cron.OnlyDuring([]cron.TimeWindow{{Days: cron.Weekdays, Start: 9 * time.Hour, End: 17 * time.Hour}},
+//		ny, cron.GateDefer)
+//
+// Skips and deferrals are logged at Info level to the Cron's logger.
+func OnlyDuring(windows []TimeWindow, loc *time.Location, policy GatePolicy) JobWrapper {
+	open := func(t time.Time) bool {
+		for _, w := range windows {
+			if w.contains(t) {
+				return true
+			}
+		}
+		return false
+	}
+	next := func(t time.Time) time.Time {
+		var first time.Time
+		for _, w := range windows {
+			if s := w.nextStart(t); !s.IsZero() && (first.IsZero() || s.Before(first)) {
+				first = s
+			}
+		}
+		return first
+	}
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			now := time.Now().In(loc)
+			if open(now) {
+				return RunJob(ctx, j)
+			}
+			ri, _ := runFromContext(ctx)
+			logger := DefaultLogger
+			if ri != nil && ri.cron != nil {
+				logger = ri.cron.logger
+			}
+			until := next(now)
+			if policy == GateSkip || until.IsZero() {
+				logger.Info("skip", "reason", "outside window")
+				return nil
+			}
+
+			logger.Info("defer", "until", until)
+			if ri != nil && ri.cron != nil {
+				ri.cron.pending.add(ri, "outside window")
+				defer ri.cron.pending.remove(ri)
+			}
+			timer := time.NewTimer(until.Sub(now))
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				return RunJob(ctx, j)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeWindow(t *testing.T) {
+	business := TimeWindow{Days: Weekdays, Start: 9 * time.Hour, End: 17 * time.Hour}
+	overnight := TimeWindow{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 2 * time.Hour}
+	// 2021-01-01 is a Friday.
+	at := func(day, hour, min int) time.Time { return time.Date(2021, 1, day, hour, min, 0, 0, time.UTC) }
+
+	tests := []struct {
+		w    TimeWindow
+		t    time.Time
+		in   bool
+		next time.Time
+	}{
+		{business, at(1, 8, 59), false, at(1, 9, 0)},
+		{business, at(1, 9, 0), true, at(4, 9, 0)},
+		{business, at(1, 17, 0), false, at(4, 9, 0)},
+		{business, at(2, 12, 0), false, at(4, 9, 0)},
+		{overnight, at(1, 23, 0), true, at(8, 22, 0)},
+		{overnight, at(2, 1, 0), true, at(8, 22, 0)},
+		{overnight, at(2, 3, 0), false, at(8, 22, 0)},
+		{overnight, at(1, 1, 0), false, at(1, 22, 0)},
+	}
+	for _, test := range tests {
+		if in := test.w.contains(test.t); in != test.in {
+			t.Errorf("%v: expected in window %v, got %v", test.t, test.in, in)
+		}
+		if next := test.w.nextStart(test.t); !next.Equal(test.next) {
+			t.Errorf("%v: expected next start %v, got %v", test.t, test.next, next)
+		}
+	}
+}
+
+func TestOnlyDuring(t *testing.T) {
+	now := time.Now().UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	tod := now.Sub(midnight)
+	around := []TimeWindow{{Start: tod - time.Hour, End: tod + time.Hour}}
+	later := []TimeWindow{{Start: tod + 200*time.Millisecond, End: tod + time.Hour}}
+	if tod < time.Hour || tod > 22*time.Hour {
+		t.Skip("too close to midnight")
+	}
+
+	var j countJob
+	NewChain(OnlyDuring(around, time.UTC, GateSkip)).Then(&j).Run()
+	NewChain(OnlyDuring(later, time.UTC, GateSkip)).Then(&j).Run()
+	if n := j.Done(); n != 1 {
+		t.Errorf("expected the run outside the window skipped, got %d runs", n)
+	}
+
+	start := time.Now()
+	NewChain(OnlyDuring(later, time.UTC, GateDefer)).Then(&j).Run()
+	if n := j.Done(); n != 2 || time.Since(start) < 100*time.Millisecond {
+		t.Errorf("expected the run deferred until the window opens, got %d runs after %v", n, time.Since(start))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	future := []TimeWindow{{Start: tod + time.Hour, End: tod + 2*time.Hour}}
+	if err := RunJob(ctx, NewChain(OnlyDuring(future, time.UTC, GateDefer)).Then(&j)); err != context.Canceled {
+		t.Errorf("expected a canceled deferral, got %v", err)
+	}
+}
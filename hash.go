@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// ParseWithSeed parses the spec as Parse does, but replaces each H in its
+// fields with a value derived from the seed, as Jenkins does, so that jobs
+// with the same spec and different seeds, such as their names, are spread
+// over the hour or the day instead of all starting at once:
+//
+//	H           a value of the field, the same for the same seed
+//	H(0-29)     a value of the range
+//	H/15        every 15th value, starting from one of the first 15
+//	H(0-29)/10  every 10th value of the range, starting from one of its first 10
+//
+// An H in the day of month stands for a day from 1 to 28, so that it runs in
+// every month. Parse reads an H as ParseWithSeed does with an empty seed.
+func (p Parser) ParseWithSeed(spec, seed string) (Schedule, error) {
+	p.seed = seed
+	return p.Parse(spec)
+}
+
+// hashFields returns the fields, as normalizeFields returns them, with each H
+// replaced by the values the seed gives.
+func hashFields(fields []string, seed string) ([]string, error) {
+	hashed := make([]string, len(fields))
+	copy(hashed, fields)
+	for i, r := range []bounds{seconds, minutes, hours, dom, months, dow} {
+		if !strings.Contains(fields[i], "H") {
+			continue
+		}
+		items := strings.Split(fields[i], ",")
+		for j, expr := range items {
+			var err error
+			if items[j], err = hashRange(expr, r, seed, i); err != nil {
+				return nil, err
+			}
+		}
+		hashed[i] = strings.Join(items, ",")
+	}
+	return hashed, nil
+}
+
+// hashRange returns the range an item of a field stands for, replacing an H
+// with the value the seed gives for the field at the place.
+func hashRange(expr string, r bounds, seed string, place int) (string, error) {
+	if !strings.HasPrefix(expr, "H") {
+		return expr, nil
+	}
+	min, max := r.min, r.max
+	if sameBounds(r, dom) {
+		max = 28
+	}
+	rest := expr[1:]
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 || strings.Contains(rest[:end], "/") {
+			return "", parseError("invalid H expression: %s", expr)
+		}
+		var err error
+		if min, max, _, _, err = parseRange(rest[1:end], r); err != nil {
+			return "", err
+		}
+		rest = rest[end+1:]
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	h.Write([]byte{byte(place)})
+	sum := h.Sum64()
+	switch {
+	case rest == "":
+		return strconv.Itoa(int(min + uint(sum%uint64(max-min+1)))), nil
+	case strings.HasPrefix(rest, "/"):
+		step, err := mustParseInt(rest[1:])
+		if err != nil {
+			return "", err
+		}
+		if step == 0 {
+			return "", parseError("step of range should be a positive number: %s", expr)
+		}
+		span := step
+		if span > max-min+1 {
+			span = max - min + 1
+		}
+		return fmt.Sprintf("%d-%d/%d", min+uint(sum%uint64(span)), max, step), nil
+	}
+	return "", parseError("invalid H expression: %s", expr)
+}
+
+// seedParser is a ScheduleParser that reads an H as ParseWithSeed does, as
+// Parser is.
+type seedParser interface {
+	ParseWithSeed(spec, seed string) (Schedule, error)
+}
+
+// parseEntry parses the spec of an entry named name, or given the options,
+// which may name it. If the parser takes a seed, the name seeds the H of the
+// spec, so that entries with the same spec and different names run at
+// different times.
+func parseEntry(p ScheduleParser, spec, name string, opts []EntryOption) (Schedule, error) {
+	sp, ok := p.(seedParser)
+	if !ok {
+		return p.Parse(spec)
+	}
+	e := Entry{Name: name}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return sp.ParseWithSeed(spec, e.Name)
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"math/bits"
+	"testing"
+	"time"
+)
+
+func TestParseWithSeed(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow)
+	parse := func(spec, seed string) *SpecSchedule {
+		t.Helper()
+		s, err := p.ParseWithSeed(spec, seed)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		return s.(*SpecSchedule)
+	}
+
+	// The same seed gives the same schedule, and different seeds spread it.
+	if a, b := parse("H H * * *", "backup"), parse("H H * * *", "backup"); *a != *b {
+		t.Errorf("expected the same schedule for the same seed, got %v and %v", a, b)
+	}
+	minutes := map[uint64]bool{}
+	for _, seed := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		minutes[parse("H * * * *", seed).Minute] = true
+	}
+	if len(minutes) < 4 {
+		t.Errorf("expected seeds to spread the minute, got %d distinct", len(minutes))
+	}
+
+	for _, seed := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		s := parse("H(0-29) H(9-17) H * H", seed)
+		if n := bits.OnesCount64(s.Minute); n != 1 || s.Minute&^getBits(0, 29, 1) != 0 {
+			t.Errorf("%s: minute %b outside 0-29", seed, s.Minute)
+		}
+		if s.Hour&^getBits(9, 17, 1) != 0 {
+			t.Errorf("%s: hour %b outside 9-17", seed, s.Hour)
+		}
+		if s.Dom&^getBits(1, 28, 1) != 0 {
+			t.Errorf("%s: day of month %b after the 28th", seed, s.Dom)
+		}
+
+		// H/15 runs four times an hour, starting in the first 15 minutes.
+		s = parse("H/15 * * * *", seed)
+		if n := bits.OnesCount64(s.Minute); n != 4 {
+			t.Errorf("%s: expected 4 minutes, got %d", seed, n)
+		}
+		s = parse("H(30-59)/10 * * * *", seed)
+		if n := bits.OnesCount64(s.Minute); n != 3 || s.Minute&^getBits(30, 59, 1) != 0 {
+			t.Errorf("%s: expected 3 minutes in 30-59, got %b", seed, s.Minute)
+		}
+	}
+}
+
+func TestParseWithSeedErrors(t *testing.T) {
+	for _, spec := range []string{
+		"H(0-29 * * * *",
+		"H(0-29/2) * * * *",
+		"H(0-60) * * * *",
+		"H/0 * * * *",
+		"Hx * * * *",
+		"H-5 * * * *",
+	} {
+		if _, err := standardParser.ParseWithSeed(spec, "seed"); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestAddJobSeedsWithName(t *testing.T) {
+	c := New()
+	next := map[time.Time]bool{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		id, err := c.AddFunc("H H * * *", func() {}, WithName(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, _ := standardParser.ParseWithSeed("H H * * *", name)
+		if got := c.Entry(id).Schedule; *got.(*SpecSchedule) != *s.(*SpecSchedule) {
+			t.Errorf("%s: expected the schedule seeded with the name", name)
+		}
+		next[s.Next(time.Now())] = true
+	}
+	if len(next) < 4 {
+		t.Errorf("expected names to spread the entries, got %d distinct times", len(next))
+	}
+}
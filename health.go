@@ -0,0 +1,54 @@
+package cron
+
+import "time"
+
+// Health describes the state of the scheduling loop.
+type Health struct {
+	// Running is whether the Cron has been started and not stopped.
+	Running bool `json:"running"`
+
+	// Responsive is whether the scheduling loop answered within the timeout
+	// given to Health.
+	Responsive bool `json:"responsive"`
+
+	// Lag is how long the most overdue entry has been waiting past its
+	// scheduled time, or zero if none is overdue.
+	Lag time.Duration `json:"lag"`
+}
+
+// Health checks that the scheduling loop is running and answers requests
+// within timeout, and measures how far behind schedule it is. Unlike Entries,
+// it does not block if the loop is wedged.
+func (c *Cron) Health(timeout time.Duration) Health {
+	c.runningMu.RLock()
+	running := c.running
+	c.runningMu.RUnlock()
+	if !running {
+		return Health{}
+	}
+
+	h := Health{Running: true}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	replyChan := make(chan []Entry, 1)
+	select {
+	case c.snapshot <- replyChan:
+	case <-timer.C:
+		return h
+	}
+	var entries []Entry
+	select {
+	case entries = <-replyChan:
+	case <-timer.C:
+		return h
+	}
+
+	h.Responsive = true
+	now := c.now()
+	for _, e := range entries {
+		if !e.Next.IsZero() && now.Sub(e.Next) > h.Lag {
+			h.Lag = now.Sub(e.Next)
+		}
+	}
+	return h
+}
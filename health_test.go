@@ -0,0 +1,31 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	c := New()
+	if h := c.Health(time.Second); h.Running || h.Responsive {
+		t.Errorf("expected a stopped cron to be unhealthy, got %+v", h)
+	}
+
+	c.AddFunc("@hourly", func() {})
+	c.Start()
+	if h := c.Health(time.Second); !h.Running || !h.Responsive || h.Lag != 0 {
+		t.Errorf("expected a healthy cron, got %+v", h)
+	}
+	c.Stop()
+}
+
+// A loop that doesn't answer is reported as unresponsive rather than hanging.
+func TestHealthWedged(t *testing.T) {
+	c := New()
+	c.runningMu.Lock()
+	c.running = true // pretend to run, without a loop to answer
+	c.runningMu.Unlock()
+	if h := c.Health(10 * time.Millisecond); !h.Running || h.Responsive {
+		t.Errorf("expected an unresponsive cron, got %+v", h)
+	}
+}
@@ -0,0 +1,73 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat reports that the job running with ctx is still making progress.
+// Jobs of entries added with WithHeartbeatTimeout should call it regularly;
+// for other jobs it has no effect.
+func Heartbeat(ctx context.Context) {
+	if ri, ok := runFromContext(ctx); ok {
+		ri.beat(ri.clock().Now())
+	}
+}
+
+// WithHeartbeatTimeout watches each run of the entry for calls to Heartbeat.
+// A run that goes longer than window without one is marked stuck, which is
+// logged and reported to listeners as EventStuck. If cancel is true, the
+// stuck run's context is also cancelled.
+func WithHeartbeatTimeout(window time.Duration, cancel bool) EntryOption {
+	return func(e *Entry) {
+		e.HeartbeatTimeout = window
+		e.CancelStuck = cancel
+	}
+}
+
+// beat records a heartbeat at time t.
+func (ri *runInfo) beat(t time.Time) {
+	atomic.StoreInt64(&ri.lastBeat, t.UnixNano())
+}
+
+// isStuck reports whether the run has been marked stuck.
+func (ri *runInfo) isStuck() bool {
+	return atomic.LoadInt32(&ri.stuck) == 1
+}
+
+// watchHeartbeat checks the run's heartbeats each time the timer fires until
+// done is closed, marking it stuck the first time it goes longer than its
+// entry's timeout without one.
+func (c *Cron) watchHeartbeat(ri *runInfo, timer Timer, done <-chan struct{}) {
+	window := ri.entry.HeartbeatTimeout
+	defer timer.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-timer.C():
+			since := now.Sub(time.Unix(0, atomic.LoadInt64(&ri.lastBeat)))
+			if since <= window {
+				timer.Reset(window / 4)
+				continue
+			}
+			c.markStuck(ri, since)
+			return
+		}
+	}
+}
+
+// markStuck marks the run stuck, unless it already is, reporting how long it
+// has gone without progress. The run is cancelled if its entry is configured
+// to do so.
+func (c *Cron) markStuck(ri *runInfo, since time.Duration) {
+	if !atomic.CompareAndSwapInt32(&ri.stuck, 0, 1) {
+		return
+	}
+	c.logger.Info("stuck", "entry", ri.entry.ID, "run", ri.id, "since", since)
+	ri.emit(EventStuck, since)
+	if ri.entry.CancelStuck {
+		ri.cancel()
+	}
+}
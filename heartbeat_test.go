@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// A run that stops heartbeating is reported stuck and, if configured, has its
+// context cancelled.
+func TestHeartbeatTimeout(t *testing.T) {
+	var stuck []Event
+	c := New(WithEventListener(func(ev Event) {
+		if ev.Type == EventStuck {
+			stuck = append(stuck, ev)
+		}
+	}))
+	e := Entry{ID: 1, Name: "sync"}
+	WithHeartbeatTimeout(20*time.Millisecond, true)(&e)
+
+	var beats int
+	e.WrappedJob = FuncContextJob(func(ctx context.Context) error {
+		for ; beats < 5; beats++ {
+			Heartbeat(ctx)
+			time.Sleep(10 * time.Millisecond)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("expected the stuck run to be cancelled")
+			return nil
+		}
+	})
+	c.runEntry(e, time.Now())
+
+	if len(stuck) != 1 || stuck[0].Name != "sync" || stuck[0].Duration <= 20*time.Millisecond {
+		t.Errorf("expected one stuck event, got %+v", stuck)
+	}
+}
+
+// Runs that keep heartbeating are left alone.
+func TestHeartbeatHealthy(t *testing.T) {
+	c := New(WithEventListener(func(ev Event) {
+		if ev.Type == EventStuck {
+			t.Error("unexpected stuck event")
+		}
+	}))
+	e := Entry{ID: 1}
+	WithHeartbeatTimeout(20*time.Millisecond, true)(&e)
+	e.WrappedJob = FuncContextJob(func(ctx context.Context) error {
+		for i := 0; i < 10; i++ {
+			Heartbeat(ctx)
+			time.Sleep(5 * time.Millisecond)
+		}
+		return ctx.Err()
+	})
+	c.runEntry(e, time.Now())
+}
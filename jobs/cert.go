@@ -0,0 +1,131 @@
+package jobs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// CertExpiry is a Job that fails when a certificate expires soon, so that
+// the failure is reported and alerted on like any other, in time to renew
+// the certificate.
+type CertExpiry struct {
+	// Addrs are TLS servers to check, as "host:port". The certificate each
+	// presents is checked, whether or not it is trusted.
+	Addrs []string
+
+	// Files are PEM files to check. Each certificate in them is checked.
+	Files []string
+
+	// Warn is how long before expiry a certificate fails the check.
+	Warn time.Duration
+
+	// Timeout limits the connection to each server. The default is 10
+	// seconds.
+	Timeout time.Duration
+}
+
+// Run runs the check, discarding any error.
+func (j *CertExpiry) Run() { j.RunContext(context.Background()) }
+
+// RunContext checks the certificates, returning an error that lists those
+// expiring within Warn, and those that could not be checked.
+func (j *CertExpiry) RunContext(ctx context.Context) error {
+	now := time.Now()
+	var problems []string
+	check := func(source string, certs []*x509.Certificate) {
+		for _, cert := range certs {
+			if left := cert.NotAfter.Sub(now); left < j.Warn {
+				what := fmt.Sprintf("expires in %v", left.Round(time.Minute))
+				if left <= 0 {
+					what = "expired"
+				}
+				problems = append(problems, fmt.Sprintf("%s: %s %s on %s", source, cert.Subject.CommonName, what, cert.NotAfter.Format(time.RFC3339)))
+			}
+		}
+	}
+	for _, addr := range j.Addrs {
+		certs, err := j.serverCerts(ctx, addr)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", addr, err))
+			continue
+		}
+		// Only the server's own certificate; the chain renews separately.
+		check(addr, certs[:1])
+	}
+	for _, name := range j.Files {
+		certs, err := fileCerts(name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		check(name, certs)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("jobs: certificates: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// serverCerts returns the certificates the server presents.
+func (j *CertExpiry) serverCerts(ctx context.Context, addr string) ([]*x509.Certificate, error) {
+	timeout := j.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	d := tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		// Expiry is what is checked, so accept what verification would not.
+		Config: &tls.Config{ServerName: host, InsecureSkipVerify: true},
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate")
+	}
+	return certs, nil
+}
+
+// fileCerts returns the certificates in the PEM file.
+func fileCerts(name string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate")
+	}
+	return certs, nil
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate that expires at notAfter.
+func writeCert(t *testing.T, path string, notAfter time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertExpiryFiles(t *testing.T) {
+	dir := t.TempDir()
+	soon, later := filepath.Join(dir, "soon.pem"), filepath.Join(dir, "later.pem")
+	writeCert(t, soon, time.Now().Add(24*time.Hour))
+	writeCert(t, later, time.Now().Add(90*24*time.Hour))
+
+	job := &CertExpiry{Files: []string{later}, Warn: 14 * 24 * time.Hour}
+	if err := job.RunContext(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	job.Files = append(job.Files, soon, filepath.Join(dir, "missing.pem"))
+	err := job.RunContext(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "soon.pem: example.com expires in") || !strings.Contains(msg, "missing.pem") || strings.Contains(msg, "later.pem") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCertExpiryAddrs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+	cert := srv.Certificate()
+
+	job := &CertExpiry{Addrs: []string{addr}, Warn: time.Hour}
+	if err := job.RunContext(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	job.Warn = time.Until(cert.NotAfter) + time.Hour
+	if err := job.RunContext(context.Background()); err == nil || !strings.Contains(err.Error(), addr) {
+		t.Errorf("err = %v, want one about %s", err, addr)
+	}
+}
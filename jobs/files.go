@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TempCleanup is a Job that removes old files from a directory, such as
+// abandoned uploads or scratch files.
+type TempCleanup struct {
+	// Dir is the directory to clean up.
+	Dir string
+
+	// MaxAge is how long after its last modification a file is removed.
+	MaxAge time.Duration
+
+	// Pattern, if set, limits the cleanup to files whose names match it, as
+	// filepath.Match does, such as "*.tmp".
+	Pattern string
+
+	// Recursive is whether files in subdirectories are removed too. The
+	// directories themselves are left.
+	Recursive bool
+}
+
+// Run runs the cleanup, discarding any error.
+func (j *TempCleanup) Run() { j.RunContext(context.Background()) }
+
+// RunContext removes the files, returning the first error. Files that vanish
+// while it runs are not errors.
+func (j *TempCleanup) RunContext(ctx context.Context) error {
+	if j.MaxAge <= 0 {
+		return fmt.Errorf("jobs: TempCleanup of %s has no MaxAge", j.Dir)
+	}
+	cutoff := time.Now().Add(-j.MaxAge)
+	files, err := listFiles(j.Dir, j.Pattern, j.Recursive)
+	if err != nil {
+		return err
+	}
+	var first error
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if f.mod.Before(cutoff) {
+			if err := removeFile(ctx, f.path, f.size); err != nil && !errors.Is(err, fs.ErrNotExist) && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// CacheEviction is a Job that keeps the size of a cache directory under a
+// limit, removing its least recently modified files first.
+type CacheEviction struct {
+	// Dir is the cache directory. Its subdirectories are included.
+	Dir string
+
+	// MaxBytes is the most the files may total.
+	MaxBytes int64
+}
+
+// Run runs the eviction, discarding any error.
+func (j *CacheEviction) Run() { j.RunContext(context.Background()) }
+
+// RunContext removes files until the rest total no more than MaxBytes.
+func (j *CacheEviction) RunContext(ctx context.Context) error {
+	files, err := listFiles(j.Dir, "", true)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	sort.Slice(files, func(i, k int) bool { return files[i].mod.Before(files[k].mod) })
+	for _, f := range files {
+		if total <= j.MaxBytes {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := removeFile(ctx, f.path, f.size); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		total -= f.size
+	}
+	return nil
+}
+
+// file is a regular file found by listFiles.
+type file struct {
+	path string
+	size int64
+	mod  time.Time
+}
+
+// listFiles returns the regular files in dir whose names match pattern, if
+// it is not empty, including those in subdirectories if recursive.
+func listFiles(dir, pattern string, recursive bool) ([]file, error) {
+	var files []file
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path != dir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if pattern != "" {
+			if ok, err := filepath.Match(pattern, d.Name()); err != nil || !ok {
+				return err
+			}
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		files = append(files, file{path, info.Size(), info.ModTime()})
+		return nil
+	})
+	return files, err
+}
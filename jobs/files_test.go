@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTempCleanup(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "old.tmp"), 10, 2*time.Hour)
+	writeFile(t, filepath.Join(dir, "new.tmp"), 10, time.Minute)
+	writeFile(t, filepath.Join(dir, "old.keep"), 10, 2*time.Hour)
+	writeFile(t, filepath.Join(dir, "sub", "old.tmp"), 10, 2*time.Hour)
+
+	job := &TempCleanup{Dir: dir, MaxAge: time.Hour, Pattern: "*.tmp"}
+	costs := runInCron(t, job)
+	if costs["files_removed"] != 1 || costs["bytes_removed"] != 10 {
+		t.Errorf("costs = %v, want 1 file of 10 bytes", costs)
+	}
+	if exists(filepath.Join(dir, "old.tmp")) {
+		t.Error("old.tmp was not removed")
+	}
+	for _, name := range []string{"new.tmp", "old.keep", "sub/old.tmp"} {
+		if !exists(filepath.Join(dir, name)) {
+			t.Errorf("%s was removed", name)
+		}
+	}
+
+	job.Recursive = true
+	if err := job.RunContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if exists(filepath.Join(dir, "sub", "old.tmp")) {
+		t.Error("sub/old.tmp was not removed")
+	}
+	if !exists(filepath.Join(dir, "sub")) {
+		t.Error("sub was removed")
+	}
+}
+
+func TestTempCleanupNeedsMaxAge(t *testing.T) {
+	if err := (&TempCleanup{Dir: t.TempDir()}).RunContext(context.Background()); err == nil {
+		t.Error("expected an error without MaxAge")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a"), 100, 3*time.Hour)
+	writeFile(t, filepath.Join(dir, "b"), 100, 2*time.Hour)
+	writeFile(t, filepath.Join(dir, "c"), 100, time.Hour)
+
+	costs := runInCron(t, &CacheEviction{Dir: dir, MaxBytes: 150})
+	if costs["files_removed"] != 2 || costs["bytes_removed"] != 200 {
+		t.Errorf("costs = %v, want 2 files of 200 bytes", costs)
+	}
+	if exists(filepath.Join(dir, "a")) || exists(filepath.Join(dir, "b")) || !exists(filepath.Join(dir, "c")) {
+		t.Error("expected only the newest file to be kept")
+	}
+}
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// grpcHistory is the number of recent calls a GRPCCall keeps.
+const grpcHistory = 100
+
+// GRPCInvoker makes a unary gRPC call to the method of the server at target,
+// with a request and response encoded as proto-JSON. It returns the call's
+// status code along with its error.
+//
+// The package does not depend on gRPC. With grpc-go, and the request and
+// response types linked into the binary, an adapter takes a few lines:
+//
+//	type invoker struct{}
+//
+//	func (invoker) InvokeJSON(ctx context.Context, target, method string, req []byte) ([]byte, jobs.GRPCCode, error) {
+//		conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+//		if err != nil {
+//			return nil, jobs.GRPCCode(codes.Unavailable), err
+//		}
+//		defer conn.Close()
+//		name := strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", ".")
+//		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+//		if err != nil {
+//			return nil, jobs.GRPCCode(codes.Unimplemented), err
+//		}
+//		md := d.(protoreflect.MethodDescriptor)
+//		in, out := dynamicpb.NewMessage(md.Input()), dynamicpb.NewMessage(md.Output())
+//		if err := protojson.Unmarshal(req, in); err != nil {
+//			return nil, jobs.GRPCCode(codes.InvalidArgument), err
+//		}
+//		if err := conn.Invoke(ctx, method, in, out); err != nil {
+//			return nil, jobs.GRPCCode(status.Code(err)), err
+//		}
+//		resp, err := protojson.Marshal(out)
+//		return resp, jobs.GRPCCode(codes.OK), err
+//	}
+//
+// Calls that run often should reuse a connection per target rather than
+// dialing each time.
+type GRPCInvoker interface {
+	InvokeJSON(ctx context.Context, target, method string, request []byte) (response []byte, code GRPCCode, err error)
+}
+
+// GRPCCode is a gRPC status code, as codes.Code in grpc-go.
+type GRPCCode uint32
+
+var grpcCodeNames = []string{
+	"OK",
+	"Canceled",
+	"Unknown",
+	"InvalidArgument",
+	"DeadlineExceeded",
+	"NotFound",
+	"AlreadyExists",
+	"PermissionDenied",
+	"ResourceExhausted",
+	"FailedPrecondition",
+	"Aborted",
+	"OutOfRange",
+	"Unimplemented",
+	"Internal",
+	"Unavailable",
+	"DataLoss",
+	"Unauthenticated",
+}
+
+func (c GRPCCode) String() string {
+	if int(c) < len(grpcCodeNames) {
+		return grpcCodeNames[c]
+	}
+	return "Code(" + strconv.Itoa(int(c)) + ")"
+}
+
+// The codes a GRPCCall records when the invoker gives none for an error.
+const (
+	grpcCanceled         GRPCCode = 1
+	grpcUnknown          GRPCCode = 2
+	grpcDeadlineExceeded GRPCCode = 4
+)
+
+// GRPCCall is a Job that calls a gRPC method, such as a maintenance or
+// reconciliation endpoint of a service, and fails unless the call succeeds.
+//
+//	c.AddJob("*/15 * * * *", &jobs.GRPCCall{
+//		Invoker:  invoker{},
+//		Target:   "billing:443",
+//		Method:   "/billing.v1.Billing/Reconcile",
+//		Payload:  `{"dryRun": false}`,
+//		Deadline: time.Minute,
+//	})
+type GRPCCall struct {
+	Invoker GRPCInvoker
+
+	// Target is the server, in the form grpc-go dials, such as "host:port"
+	// or "dns:///service.internal:443".
+	Target string
+
+	// Method is the full name of the method, "/package.Service/Method".
+	Method string
+
+	// Payload is the request, as proto-JSON. It defaults to "{}".
+	Payload string
+
+	// Deadline limits each call. Zero leaves only the run's own context,
+	// which WithTimeout may limit.
+	Deadline time.Duration
+
+	mu      sync.Mutex
+	history []GRPCResult
+	next    int
+}
+
+// GRPCResult is the outcome of a call made by a GRPCCall.
+type GRPCResult struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Code     GRPCCode      `json:"code"`
+
+	// Error is the error of a failed call.
+	Error string `json:"error,omitempty"`
+}
+
+// Run makes the call, discarding any error.
+func (j *GRPCCall) Run() { j.RunContext(context.Background()) }
+
+// RunContext makes the call, returning an error naming its status code if it
+// fails. The call is counted with cron.AddCost as "grpc_calls".
+func (j *GRPCCall) RunContext(ctx context.Context) error {
+	if j.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.Deadline)
+		defer cancel()
+	}
+	payload := j.Payload
+	if payload == "" {
+		payload = "{}"
+	}
+	start := time.Now()
+	_, code, err := j.Invoker.InvokeJSON(ctx, j.Target, j.Method, []byte(payload))
+	result := GRPCResult{Start: start, Duration: time.Since(start), Code: code}
+	if err != nil && code == 0 {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			result.Code = grpcDeadlineExceeded
+		case errors.Is(err, context.Canceled):
+			result.Code = grpcCanceled
+		default:
+			result.Code = grpcUnknown
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	j.record(result)
+	cron.AddCost(ctx, "grpc_calls", 1)
+
+	if err != nil {
+		return fmt.Errorf("jobs: %s %s: %s: %v", j.Target, j.Method, result.Code, err)
+	}
+	if code != 0 {
+		return fmt.Errorf("jobs: %s %s: %s", j.Target, j.Method, code)
+	}
+	return nil
+}
+
+func (j *GRPCCall) record(r GRPCResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.history) < grpcHistory {
+		j.history = append(j.history, r)
+		return
+	}
+	j.history[j.next] = r
+	j.next = (j.next + 1) % grpcHistory
+}
+
+// History returns the outcomes of the job's most recent 100 calls, oldest
+// first.
+func (j *GRPCCall) History() []GRPCResult {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	history := make([]GRPCResult, 0, len(j.history))
+	history = append(history, j.history[j.next:]...)
+	return append(history, j.history[:j.next]...)
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeInvoker records the calls made to it and returns their results.
+type fakeInvoker struct {
+	calls []string
+	code  GRPCCode
+	err   error
+	delay time.Duration
+}
+
+func (f *fakeInvoker) InvokeJSON(ctx context.Context, target, method string, request []byte) ([]byte, GRPCCode, error) {
+	f.calls = append(f.calls, target+" "+method+" "+string(request))
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+	return []byte("{}"), f.code, f.err
+}
+
+func TestGRPCCall(t *testing.T) {
+	inv := &fakeInvoker{}
+	job := &GRPCCall{Invoker: inv, Target: "billing:443", Method: "/billing.v1.Billing/Reconcile", Payload: `{"dryRun":true}`}
+	costs := runInCron(t, job)
+	if costs["grpc_calls"] != 1 {
+		t.Errorf("costs = %v", costs)
+	}
+	if len(inv.calls) != 1 || inv.calls[0] != `billing:443 /billing.v1.Billing/Reconcile {"dryRun":true}` {
+		t.Errorf("calls = %q", inv.calls)
+	}
+
+	inv.code, inv.err = 14, errors.New("connection refused")
+	err := job.RunContext(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Unavailable: connection refused") {
+		t.Errorf("err = %v", err)
+	}
+
+	history := job.History()
+	if len(history) != 2 || history[0].Code != 0 || history[1].Code != 14 || history[1].Error != "connection refused" {
+		t.Errorf("history = %+v", history)
+	}
+}
+
+func TestGRPCCallDeadline(t *testing.T) {
+	inv := &fakeInvoker{delay: time.Second}
+	job := &GRPCCall{Invoker: inv, Target: "t", Method: "/s/M", Deadline: 10 * time.Millisecond}
+	if err := job.RunContext(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if h := job.History(); len(h) != 1 || h[0].Code.String() != "DeadlineExceeded" {
+		t.Errorf("history = %+v", h)
+	}
+	if !strings.HasSuffix(inv.calls[0], " {}") {
+		t.Errorf("payload did not default to {}: %q", inv.calls[0])
+	}
+}
+
+func TestGRPCCallHistoryWraps(t *testing.T) {
+	job := &GRPCCall{Invoker: &fakeInvoker{}}
+	for i := 0; i < grpcHistory+5; i++ {
+		job.record(GRPCResult{Code: GRPCCode(i)})
+	}
+	h := job.History()
+	if len(h) != grpcHistory || h[0].Code != 5 || h[len(h)-1].Code != grpcHistory+4 {
+		t.Errorf("history runs from %v to %v", h[0].Code, h[len(h)-1].Code)
+	}
+}
+
+func TestGRPCCodeString(t *testing.T) {
+	if GRPCCode(16).String() != "Unauthenticated" || GRPCCode(17).String() != "Code(17)" {
+		t.Error("unexpected code names")
+	}
+}
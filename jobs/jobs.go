@@ -0,0 +1,31 @@
+// Package jobs provides Jobs for routine maintenance: cleaning up temporary
+// files, evicting cache files, rotating logs, running database maintenance,
+// checking certificates for expiry, calling gRPC methods and publishing
+// messages.
+//
+// Each job is configured by the fields of its struct and implements
+// cron.ContextJob, so failures are returned as errors and show up in the
+// Cron's events and statistics. Jobs also count what they did with
+// cron.AddCost, such as "files_removed", "bytes_removed" and
+// "files_rotated", for cost hooks and metrics:
+//
+//	c.AddJob("@hourly", &jobs.TempCleanup{Dir: "/var/tmp/uploads", MaxAge: 24 * time.Hour})
+//	c.AddJob("@daily", &jobs.CertExpiry{Addrs: []string{"example.com:443"}, Warn: 14 * 24 * time.Hour})
+package jobs
+
+import (
+	"context"
+	"os"
+
+	"github.com/robfig/cron/v3"
+)
+
+// removeFile removes the file, counting it and its size as costs of the run.
+func removeFile(ctx context.Context, path string, size int64) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	cron.AddCost(ctx, "files_removed", 1)
+	cron.AddCost(ctx, "bytes_removed", float64(size))
+	return nil
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// runInCron runs the job once in a Cron and returns the costs it counted.
+func runInCron(t *testing.T, job cron.Job) map[string]float64 {
+	t.Helper()
+	usage := make(chan cron.Usage, 1)
+	c := cron.New(cron.WithCostHook(func(u cron.Usage) { usage <- u }))
+	id, err := c.AddJob("@yearly", job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	defer c.Stop()
+	c.Trigger(id)
+	select {
+	case u := <-usage:
+		return u.Costs
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run")
+		return nil
+	}
+}
+
+// writeFile writes size bytes to the file and sets its modification time to
+// age ago.
+func writeFile(t *testing.T, path string, size int, age time.Duration) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(-age)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"github.com/robfig/cron/v3"
+)
+
+// LogRotate is a Job that rotates a log file once it grows past a size, as
+// logrotate does: the file is renamed to Path.1, an older Path.1 to Path.2,
+// and so on, and the oldest beyond Keep is removed.
+type LogRotate struct {
+	// Path is the log file.
+	Path string
+
+	// MaxSize is the size in bytes past which the file is rotated. Zero
+	// rotates it on every run, unless it is empty.
+	MaxSize int64
+
+	// Keep is how many rotated files are kept.
+	Keep int
+
+	// CopyTruncate copies the file and truncates it in place, rather than
+	// renaming it, for programs that keep the file open and cannot be told
+	// to reopen it. Lines written during the copy may be lost.
+	CopyTruncate bool
+}
+
+// Run runs the rotation, discarding any error.
+func (j *LogRotate) Run() { j.RunContext(context.Background()) }
+
+// RunContext rotates the file if it is past MaxSize. A missing file is not
+// an error.
+func (j *LogRotate) RunContext(ctx context.Context) error {
+	info, err := os.Stat(j.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 || info.Size() <= j.MaxSize {
+		return nil
+	}
+	if j.Keep < 1 {
+		return fmt.Errorf("jobs: LogRotate of %s keeps no files", j.Path)
+	}
+
+	// Shift the rotated files up, dropping the oldest.
+	oldest := j.rotated(j.Keep)
+	if err := os.Remove(oldest); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for n := j.Keep - 1; n >= 1; n-- {
+		if err := os.Rename(j.rotated(n), j.rotated(n+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	if j.CopyTruncate {
+		if err := copyFile(j.Path, j.rotated(1), info.Mode().Perm()); err != nil {
+			return err
+		}
+		if err := os.Truncate(j.Path, 0); err != nil {
+			return err
+		}
+	} else {
+		if err := os.Rename(j.Path, j.rotated(1)); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(j.Path, os.O_CREATE|os.O_WRONLY, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	cron.AddCost(ctx, "files_rotated", 1)
+	cron.AddCost(ctx, "bytes_rotated", float64(info.Size()))
+	return nil
+}
+
+// rotated returns the path of the nth rotated file.
+func (j *LogRotate) rotated(n int) string {
+	return j.Path + "." + strconv.Itoa(n)
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogRotate(t *testing.T) {
+	for _, copyTruncate := range []bool{false, true} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.log")
+		job := &LogRotate{Path: path, MaxSize: 5, Keep: 2, CopyTruncate: copyTruncate}
+		for _, content := range []string{"first", "second", "third!"} {
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := job.RunContext(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want := map[string]string{"app.log": "", "app.log.1": "third!", "app.log.2": "second"}
+		for name, content := range want {
+			got, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Errorf("copyTruncate=%v: %v", copyTruncate, err)
+			} else if string(got) != content {
+				t.Errorf("copyTruncate=%v: %s = %q, want %q", copyTruncate, name, got, content)
+			}
+		}
+		if exists(filepath.Join(dir, "app.log.3")) {
+			t.Errorf("copyTruncate=%v: more than Keep files were kept", copyTruncate)
+		}
+	}
+}
+
+func TestLogRotateCosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, 10, 0)
+	costs := runInCron(t, &LogRotate{Path: path, Keep: 1})
+	if costs["files_rotated"] != 1 || costs["bytes_rotated"] != 10 {
+		t.Errorf("costs = %v, want 1 file of 10 bytes", costs)
+	}
+}
+
+func TestLogRotateMissing(t *testing.T) {
+	job := &LogRotate{Path: filepath.Join(t.TempDir(), "app.log"), Keep: 1}
+	if err := job.RunContext(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
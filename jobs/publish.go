@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MessagePublisher publishes a message to a topic, subject or exchange of a
+// message broker. The package does not depend on any broker's client; each
+// takes a few lines to adapt:
+//
+//	// NATS
+//	type natsPublisher struct{ nc *nats.Conn }
+//
+//	func (p natsPublisher) Publish(_ context.Context, subject string, data []byte) error {
+//		return p.nc.Publish(subject, data)
+//	}
+//
+//	// Kafka, with kafka-go
+//	type kafkaPublisher struct{ w *kafka.Writer }
+//
+//	func (p kafkaPublisher) Publish(ctx context.Context, topic string, data []byte) error {
+//		return p.w.WriteMessages(ctx, kafka.Message{Topic: topic, Value: data})
+//	}
+//
+//	// AMQP, with amqp091-go, publishing to an exchange
+//	type amqpPublisher struct{ ch *amqp.Channel }
+//
+//	func (p amqpPublisher) Publish(ctx context.Context, exchange string, data []byte) error {
+//		return p.ch.PublishWithContext(ctx, exchange, "", false, false, amqp.Publishing{Body: data})
+//	}
+type MessagePublisher interface {
+	Publish(ctx context.Context, topic string, data []byte) error
+}
+
+// Publish is a Job that publishes a message on schedule, so that event-driven
+// systems can be triggered periodically without code of their own:
+//
+//	c.AddJob("0 * * * *", &jobs.Publish{
+//		Publisher: natsPublisher{nc},
+//		Topic:     "billing.rollup",
+//		Message:   `{"period":"{{.Scheduled.Format "2006-01-02T15"}}","run":"{{.RunID}}"}`,
+//	})
+type Publish struct {
+	Publisher MessagePublisher
+	Topic     string
+
+	// Message is a text/template for the message, executed with a
+	// PublishData. It is parsed on the first run.
+	Message string
+
+	once sync.Once
+	tmpl *template.Template
+	err  error
+}
+
+// PublishData is what the Message of a Publish is executed with.
+type PublishData struct {
+	// Scheduled is the time the run was scheduled for, and Now the time it
+	// started. They are equal outside of a Cron.
+	Scheduled time.Time
+	Now       time.Time
+
+	// Entry and Name identify the entry, and RunID the run. They are empty
+	// outside of a Cron.
+	Entry cron.EntryID
+	Name  string
+	RunID string
+}
+
+// Run publishes the message, discarding any error.
+func (j *Publish) Run() { j.RunContext(context.Background()) }
+
+// RunContext renders the message and publishes it. It counts the message
+// with cron.AddCost as "messages_published", and its size as
+// "bytes_published".
+func (j *Publish) RunContext(ctx context.Context) error {
+	j.once.Do(func() {
+		j.tmpl, j.err = template.New(j.Topic).Parse(j.Message)
+	})
+	if j.err != nil {
+		return fmt.Errorf("jobs: message for %s: %v", j.Topic, j.err)
+	}
+
+	data := PublishData{Now: time.Now()}
+	data.Scheduled = data.Now
+	if t, ok := cron.ScheduledTimeFromContext(ctx); ok {
+		data.Scheduled = t
+	}
+	if e, ok := cron.EntryFromContext(ctx); ok {
+		data.Entry, data.Name = e.ID, e.Name
+	}
+	data.RunID, _ = cron.RunIDFromContext(ctx)
+
+	var buf bytes.Buffer
+	if err := j.tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("jobs: message for %s: %v", j.Topic, err)
+	}
+	if err := j.Publisher.Publish(ctx, j.Topic, buf.Bytes()); err != nil {
+		return fmt.Errorf("jobs: publishing to %s: %v", j.Topic, err)
+	}
+	cron.AddCost(ctx, "messages_published", 1)
+	cron.AddCost(ctx, "bytes_published", float64(buf.Len()))
+	return nil
+}
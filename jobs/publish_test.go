@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+type recordingPublisher struct {
+	topics   []string
+	messages []string
+	err      error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, data []byte) error {
+	p.topics = append(p.topics, topic)
+	p.messages = append(p.messages, string(data))
+	return p.err
+}
+
+func TestPublish(t *testing.T) {
+	pub := &recordingPublisher{}
+	job := &Publish{Publisher: pub, Topic: "rollup", Message: `{"period":"{{.Scheduled.Format "2006-01-02"}}"}`}
+	scheduled := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	if err := job.RunContext(cron.ContextWithScheduledTime(context.Background(), scheduled)); err != nil {
+		t.Fatal(err)
+	}
+	if len(pub.messages) != 1 || pub.topics[0] != "rollup" || pub.messages[0] != `{"period":"2024-03-01"}` {
+		t.Errorf("published %q to %q", pub.messages, pub.topics)
+	}
+}
+
+func TestPublishInCron(t *testing.T) {
+	pub := &recordingPublisher{}
+	job := &Publish{Publisher: pub, Topic: "t", Message: "{{.Entry}} {{.RunID}}"}
+	costs := runInCron(t, job)
+	if costs["messages_published"] != 1 || costs["bytes_published"] != float64(len(pub.messages[0])) {
+		t.Errorf("costs = %v", costs)
+	}
+	if f := strings.Fields(pub.messages[0]); len(f) != 2 || f[0] != "1" || len(f[1]) != 32 {
+		t.Errorf("message = %q, want the entry and run IDs", pub.messages[0])
+	}
+}
+
+func TestPublishErrors(t *testing.T) {
+	pub := &recordingPublisher{err: errors.New("broker down")}
+	job := &Publish{Publisher: pub, Topic: "t", Message: "hello"}
+	if err := job.RunContext(context.Background()); err == nil || !strings.Contains(err.Error(), "broker down") {
+		t.Errorf("err = %v", err)
+	}
+
+	job = &Publish{Publisher: pub, Topic: "t", Message: "{{.Missing"}
+	if err := job.RunContext(context.Background()); err == nil {
+		t.Error("expected an error for a bad template")
+	}
+	if len(pub.messages) != 1 {
+		t.Error("published despite a bad template")
+	}
+}
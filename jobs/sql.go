@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+)
+
+// SQLMaintenance is a Job that runs maintenance statements on a database,
+// such as "VACUUM" and "ANALYZE", in order.
+type SQLMaintenance struct {
+	DB         *sql.DB
+	Statements []string
+}
+
+// VacuumAnalyze returns an SQLMaintenance that runs VACUUM and then ANALYZE,
+// as PostgreSQL and SQLite accept, on the tables, or on the whole database if
+// none are given. Table names are not quoted.
+func VacuumAnalyze(db *sql.DB, tables ...string) *SQLMaintenance {
+	if len(tables) == 0 {
+		return &SQLMaintenance{DB: db, Statements: []string{"VACUUM", "ANALYZE"}}
+	}
+	j := &SQLMaintenance{DB: db}
+	for _, table := range tables {
+		j.Statements = append(j.Statements, "VACUUM "+table)
+	}
+	for _, table := range tables {
+		j.Statements = append(j.Statements, "ANALYZE "+table)
+	}
+	return j
+}
+
+// Run runs the statements, discarding any error.
+func (j *SQLMaintenance) Run() { j.RunContext(context.Background()) }
+
+// RunContext runs the statements, stopping at the first that fails. They are
+// not run in a transaction, as VACUUM cannot be.
+func (j *SQLMaintenance) RunContext(ctx context.Context) error {
+	for _, stmt := range j.Statements {
+		if _, err := j.DB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("jobs: %s: %v", stmt, err)
+		}
+		cron.AddCost(ctx, "statements", 1)
+	}
+	return nil
+}
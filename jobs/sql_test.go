@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a database/sql driver that records the statements it
+// executes, failing those in fail.
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []string
+	fail  map[string]bool
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return recordingConn{d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c recordingConn) Close() error                        { return nil }
+func (c recordingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.execs = append(c.d.execs, query)
+	if c.d.fail[query] {
+		return nil, errors.New("failed")
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func openRecording(t *testing.T, d *recordingDriver) *sql.DB {
+	name := "jobs-" + t.Name()
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestVacuumAnalyze(t *testing.T) {
+	d := &recordingDriver{}
+	db := openRecording(t, d)
+	costs := runInCron(t, VacuumAnalyze(db, "events", "users"))
+	want := []string{"VACUUM events", "VACUUM users", "ANALYZE events", "ANALYZE users"}
+	if !reflect.DeepEqual(d.execs, want) {
+		t.Errorf("executed %q, want %q", d.execs, want)
+	}
+	if costs["statements"] != 4 {
+		t.Errorf("costs = %v, want 4 statements", costs)
+	}
+
+	if got := VacuumAnalyze(db).Statements; !reflect.DeepEqual(got, []string{"VACUUM", "ANALYZE"}) {
+		t.Errorf("statements = %q", got)
+	}
+}
+
+func TestSQLMaintenanceStopsAtFailure(t *testing.T) {
+	d := &recordingDriver{fail: map[string]bool{"VACUUM": true}}
+	db := openRecording(t, d)
+	err := VacuumAnalyze(db).RunContext(context.Background())
+	if err == nil || err.Error() != "jobs: VACUUM: failed" {
+		t.Errorf("err = %v", err)
+	}
+	if !reflect.DeepEqual(d.execs, []string{"VACUUM"}) {
+		t.Errorf("executed %q after the failure", d.execs)
+	}
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// Limits bounds the specs a Parser accepts, so that a service parsing specs
+// from untrusted users cannot be made to do unbounded work by a pathological
+// one. A zero field is no limit.
+type Limits struct {
+	// MaxLength is the longest spec, in bytes.
+	MaxLength int
+
+	// MaxListItems is the most comma-separated items in a single field.
+	MaxListItems int
+
+	// MaxCost is the most values that the ranges of all fields may expand to
+	// in total. "*/15" in the minutes field costs 4, and "1,1,1" costs 3.
+	MaxCost int
+
+	// MinInterval is the shortest interval of an "@every" descriptor.
+	MinInterval time.Duration
+}
+
+// DefaultLimits are limits suitable for specs entered by users. They admit
+// any spec a person would reasonably write.
+var DefaultLimits = Limits{
+	MaxLength:    256,
+	MaxListItems: 64,
+	MaxCost:      1024,
+	MinInterval:  time.Minute,
+}
+
+// WithLimits returns a copy of the parser that rejects specs beyond the
+// limits.
+//
+//	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).WithLimits(cron.DefaultLimits)
+func (p Parser) WithLimits(l Limits) Parser {
+	p.limits = l
+	return p
+}
+
+// checkLength rejects a spec longer than the limit.
+func (l Limits) checkLength(spec string) error {
+	if l.MaxLength > 0 && len(spec) > l.MaxLength {
+		return parseError("spec longer than %d bytes", l.MaxLength)
+	}
+	return nil
+}
+
+// checkFields rejects fields with too many items, or that expand to too many
+// values, before they are parsed.
+func (l Limits) checkFields(fields []string) error {
+	if l.MaxListItems <= 0 {
+		return nil
+	}
+	for _, field := range fields {
+		if n := strings.Count(field, ",") + 1; n > l.MaxListItems {
+			return parseError("more than %d items in field: %s", l.MaxListItems, field)
+		}
+	}
+	return nil
+}
+
+// cost returns the number of values a field's ranges expand to, counting
+// values repeated across ranges once for each.
+func cost(field string, r bounds) int {
+	n := 0
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		bit, err := getRange(expr, r)
+		if err != nil {
+			return n
+		}
+		n += bits.OnesCount64(bit &^ starBit)
+	}
+	return n
+}
+
+// checkCost rejects fields that expand to too many values in total.
+func (l Limits) checkCost(fields []string) error {
+	if l.MaxCost <= 0 {
+		return nil
+	}
+	n := 0
+	for i, r := range []bounds{seconds, minutes, hours, dom, months, dow} {
+		n += cost(fields[i], r)
+	}
+	if n > l.MaxCost {
+		return parseError("spec expands to more than %d values", l.MaxCost)
+	}
+	return nil
+}
+
+// checkInterval rejects an "@every" schedule shorter than the limit.
+func (l Limits) checkInterval(s Schedule) error {
+	if d, ok := s.(ConstantDelaySchedule); ok && d.Delay < l.MinInterval {
+		return parseError("interval shorter than %v", l.MinInterval)
+	}
+	return nil
+}
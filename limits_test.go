@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserLimits(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | Descriptor).WithLimits(DefaultLimits)
+	accepted := []string{
+		"0 9 * * MON-FRI",
+		"*/15 * * * *",
+		"0,15,30,45 0-23 1-31 * *",
+		"@every 1h",
+		"@daily",
+	}
+	for _, spec := range accepted {
+		if _, err := p.Parse(spec); err != nil {
+			t.Errorf("%q: unexpected error %v", spec, err)
+		}
+	}
+
+	rejected := map[string]string{
+		"0 0 * * *" + strings.Repeat(" ", 300): "longer than 256 bytes",
+		strings.Repeat("1,", 64) + "1 * * * *": "more than 64 items",
+		"@every 10s":                           "interval shorter than 1m0s",
+	}
+	for spec, want := range rejected {
+		if _, err := p.Parse(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%.20q: expected %q, got %v", spec, want, err)
+		}
+	}
+
+	costly := p.WithLimits(Limits{MaxCost: 100})
+	if _, err := costly.Parse("* * * * *"); err == nil || !strings.Contains(err.Error(), "more than 100 values") {
+		t.Errorf("expected the cost limit, got %v", err)
+	}
+	if _, err := costly.Parse("0 0 * * *"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := NewParser(Minute | Hour | Dom | Month | Dow).Parse("0 0 * * *" + strings.Repeat(" ", 300)); err != nil {
+		t.Errorf("expected no limits by default, got %v", err)
+	}
+}
+
+// Specs that are only a time zone are errors, not panics.
+func TestParseTimeZoneOnly(t *testing.T) {
+	for _, spec := range []string{"TZ=UTC", "CRON_TZ=UTC", "TZ=", "TZ=UTC "} {
+		if _, err := standardParser.Parse(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		} else if _, ok := err.(*ParseError); !ok {
+			t.Errorf("%q: expected a ParseError, got %T", spec, err)
+		}
+		if _, err := Describe(spec); err == nil {
+			t.Errorf("%q: expected Describe to fail", spec)
+		}
+		Lint(spec)
+	}
+	if _, err := standardParser.Parse("TZ=UTC\t0 5 * * *"); err != nil {
+		t.Errorf("expected a tab after the zone accepted, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Warning describes a suspicious part of a spec that is nevertheless valid.
+// Like ParseError, its message may be shown in the user's language with
+// Localize.
+type Warning struct {
+	// Field is the name of the field the warning is about, such as "minute"
+	// or "day of week", or empty if it is about the spec as a whole.
+	Field string
+
+	// Format is the English format string of the message, which is also the
+	// key of its translations in a Catalog, and Args are its arguments.
+	Format string
+	Args   []interface{}
+}
+
+// String returns the message in English.
+func (w Warning) String() string {
+	return fmt.Sprintf(w.Format, w.Args...)
+}
+
+// Localize returns the message in the language.
+func (w Warning) Localize(lang string) string {
+	return fmt.Sprintf(Translate(lang, w.Format), w.Args...)
+}
+
+// fieldNames are the names of the fields of a spec, in order.
+var fieldNames = []string{"second", "minute", "hour", "day of month", "month", "day of week"}
+
+// Lint returns warnings about patterns in a standard spec that are valid but
+// likely mistakes, or needlessly complicated, so that spec-editing UIs can
+// point them out before saving:
+//
+//   - a step of 1, as in "*/1", which is the same as no step
+//   - a step larger than its range, as in "*/90" for minutes, which matches
+//     only the start of the range
+//   - values listed more than once, as in "1,2,1" or "*,5"
+//   - both the day of month and day of week restricted, as in "0 0 1 * MON",
+//     which runs on the 1st and on every Monday, not on Mondays that are the
+//     1st
+//
+// Specs that do not parse, and descriptors, have no warnings.
+func Lint(spec string) []Warning {
+	return standardParser.Lint(spec)
+}
+
+// Lint is Lint for specs with this parser's fields.
+func (p Parser) Lint(spec string) []Warning {
+	if _, err := p.Parse(spec); err != nil {
+		return nil
+	}
+	_, spec, _ = splitTimeZone(spec)
+	if strings.HasPrefix(spec, "@") {
+		return nil
+	}
+	fields, err := normalizeFields(strings.Fields(spec), p.options)
+	if err == nil {
+		fields, err = hashFields(fields, p.seed)
+	}
+	if err != nil {
+		return nil
+	}
+
+	var warnings []Warning
+	for i, r := range []bounds{seconds, minutes, hours, dom, months, dow} {
+		warnings = append(warnings, lintField(fieldNames[i], fields[i], r)...)
+	}
+	domBits, _ := getField(fields[3], dom)
+	dowBits, _ := getField(fields[5], dow)
+	if domBits&starBit == 0 && dowBits&starBit == 0 {
+		warnings = append(warnings, Warning{
+			Format: "day of month and day of week are both restricted, so a day matching either one runs the job",
+		})
+	}
+	return warnings
+}
+
+// lintField returns the warnings about the ranges of a valid field.
+func lintField(name, field string, r bounds) []Warning {
+	var (
+		warnings []Warning
+		seen     uint64
+	)
+	warn := func(format string, args ...interface{}) {
+		warnings = append(warnings, Warning{Field: name, Format: format, Args: args})
+	}
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		bit, _ := getRange(expr, r)
+		bit &^= starBit
+		if i := strings.Index(expr, "/"); i >= 0 {
+			switch {
+			case expr[i+1:] == "1":
+				warn("step of 1 is redundant: %s", expr)
+			case bits.OnesCount64(bit) == 1:
+				warn("step is larger than the range, so only %d matches: %s", bits.TrailingZeros64(bit), expr)
+			}
+		}
+		if bit&seen != 0 {
+			warn("%s repeats values already listed in %s", expr, field)
+		}
+		seen |= bit
+	}
+	return warnings
+}
@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"*/5 * * * *", nil},
+		{"@daily", nil},
+		{"* * * *", nil},
+		{"*/1 * * * *", []string{"minute: step of 1 is redundant: */1"}},
+		{"0 9-17/1 * * *", []string{"hour: step of 1 is redundant: 9-17/1"}},
+		{"*/90 * * * *", []string{"minute: step is larger than the range, so only 0 matches: */90"}},
+		{"0 0 10-12/5 * *", []string{"day of month: step is larger than the range, so only 10 matches: 10-12/5"}},
+		{"1,2,1 * * * *", []string{"minute: 1 repeats values already listed in 1,2,1"}},
+		{"0 *,5 * * *", []string{"hour: 5 repeats values already listed in *,5"}},
+		{"0 0 1 * MON", []string{": day of month and day of week are both restricted, so a day matching either one runs the job"}},
+		{"CRON_TZ=UTC 0 0 */2 * 1-5", []string{": day of month and day of week are both restricted, so a day matching either one runs the job"}},
+		{"0 0 ? * MON", nil},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, w := range Lint(test.spec) {
+			got = append(got, w.Field+": "+w.String())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: expected %q, got %q", test.spec, test.want, got)
+		}
+	}
+
+	if w := secondParser.Lint("*/1 0 * * * *"); len(w) != 1 || w[0].Field != "second" {
+		t.Errorf("expected a warning about the seconds, got %v", w)
+	}
+	if got, want := Lint("*/1 * * * *")[0].Localize("zh"), "步长 1 是多余的: */1"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadProbe samples how busy the host is. Values around 1 mean that the host
+// is fully utilized.
+type LoadProbe interface {
+	Load() (float64, error)
+}
+
+// LoadProbeFunc is a wrapper that turns a func() (float64, error) into a
+// cron.LoadProbe
+type LoadProbeFunc func() (float64, error)
+
+func (f LoadProbeFunc) Load() (float64, error) { return f() }
+
+// LoadAverage is a LoadProbe that reports the 1-minute load average divided
+// by the number of CPUs. It is only available on Linux.
+var LoadAverage LoadProbe = LoadProbeFunc(loadAverage)
+
+func loadAverage() (float64, error) {
+	data, err := ioutil.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected /proc/loadavg: %q", data)
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return load / float64(runtime.NumCPU()), nil
+}
+
+// ThrottleOnLoad delays runs of entries with a priority below minPriority
+// while the probe reports a load above maxLoad, checking again every
+// interval, so that batch jobs yield to serving traffic. If the probe fails,
+// the run goes ahead. The delay ends early if the run's context is done. The
+// interval is timed by the Cron's Clock.
+func ThrottleOnLoad(logger Logger, probe LoadProbe, maxLoad float64, minPriority int, interval time.Duration) JobWrapper {
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			if e, ok := EntryFromContext(ctx); !ok || e.Priority < minPriority {
+				clock := clockFromContext(ctx)
+				start := clock.Now()
+				for {
+					load, err := probe.Load()
+					if err != nil {
+						logger.Error(err, "load probe")
+						break
+					}
+					if load <= maxLoad {
+						break
+					}
+					timer := clock.NewTimer(interval)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return ctx.Err()
+					case <-timer.C():
+					}
+				}
+				if dur := clock.Now().Sub(start); dur >= interval {
+					logger.Info("throttled", "duration", dur)
+				}
+			}
+			return RunJob(ctx, j)
+		})
+	}
+}
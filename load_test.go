@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottleOnLoad(t *testing.T) {
+	var samples int32
+	// The host is hot for the first three samples.
+	probe := LoadProbeFunc(func() (float64, error) {
+		if atomic.AddInt32(&samples, 1) <= 3 {
+			return 2, nil
+		}
+		return 0.5, nil
+	})
+	var ran int32
+	job := NewChain(ThrottleOnLoad(DiscardLogger, probe, 1, 10, time.Millisecond)).
+		Then(FuncJob(func() { atomic.AddInt32(&ran, 1) }))
+
+	ctx := func(priority int) context.Context {
+		return context.WithValue(context.Background(), runKey, &runInfo{entry: Entry{Priority: priority}})
+	}
+
+	// High priority entries are never delayed.
+	RunJob(ctx(10), job)
+	if n := atomic.LoadInt32(&samples); n != 0 {
+		t.Errorf("expected no load samples for a high priority entry, got %d", n)
+	}
+
+	// Low priority entries wait for the load to drop.
+	RunJob(ctx(0), job)
+	if n := atomic.LoadInt32(&samples); n != 4 {
+		t.Errorf("expected to sample until the load dropped, got %d samples", n)
+	}
+	if n := atomic.LoadInt32(&ran); n != 2 {
+		t.Errorf("expected both runs, got %d", n)
+	}
+}
+
+func TestThrottleOnLoadCancelled(t *testing.T) {
+	hot := LoadProbeFunc(func() (float64, error) { return 2, nil })
+	job := NewChain(ThrottleOnLoad(DiscardLogger, hot, 1, 1, time.Millisecond)).
+		Then(FuncJob(func() { t.Error("expected the run to be abandoned") }))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := RunJob(ctx, job); err != context.DeadlineExceeded {
+		t.Errorf("expected the context's error, got %v", err)
+	}
+}
+
+func TestThrottleOnLoadProbeError(t *testing.T) {
+	broken := LoadProbeFunc(func() (float64, error) { return 0, errors.New("no probe") })
+	var ran bool
+	job := NewChain(ThrottleOnLoad(DiscardLogger, broken, 1, 1, time.Hour)).
+		Then(FuncJob(func() { ran = true }))
+	RunJob(context.Background(), job)
+	if !ran {
+		t.Error("expected the run to go ahead")
+	}
+}
+
+func TestLoadAverage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("load average is only available on linux")
+	}
+	if load, err := LoadAverage.Load(); err != nil || load < 0 {
+		t.Errorf("unexpected load %v, %v", load, err)
+	}
+}
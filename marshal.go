@@ -0,0 +1,181 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// specJSON is the JSON encoding of a SpecSchedule. The bit sets are decimal
+// strings, as JavaScript numbers cannot hold 64 bits.
+type specJSON struct {
+	Second   uint64 `json:"second,string"`
+	Minute   uint64 `json:"minute,string"`
+	Hour     uint64 `json:"hour,string"`
+	Dom      uint64 `json:"dom,string"`
+	Month    uint64 `json:"month,string"`
+	Dow      uint64 `json:"dow,string"`
+	Location string `json:"location,omitempty"`
+}
+
+// MarshalJSON encodes the schedule with its location's name, so that it may
+// be cached or sent to another process without its spec being parsed again.
+// The Local location is encoded as "Local", which is the time zone of the
+// process decoding it. Locations made with time.FixedZone cannot be decoded.
+func (s *SpecSchedule) MarshalJSON() ([]byte, error) {
+	v := specJSON{Second: s.Second, Minute: s.Minute, Hour: s.Hour, Dom: s.Dom, Month: s.Month, Dow: s.Dow}
+	if s.Location != nil {
+		v.Location = s.Location.String()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON, loading its
+// location with time.LoadLocation.
+func (s *SpecSchedule) UnmarshalJSON(data []byte) error {
+	var v specJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var loc *time.Location
+	if v.Location != "" {
+		var err error
+		if loc, err = time.LoadLocation(v.Location); err != nil {
+			return fmt.Errorf("schedule location: %v", err)
+		}
+	}
+	*s = SpecSchedule{v.Second, v.Minute, v.Hour, v.Dom, v.Month, v.Dow, loc}
+	return nil
+}
+
+// GobEncode encodes the schedule for encoding/gob, as MarshalJSON does. To
+// send it as a Schedule, register it with gob.Register(&cron.SpecSchedule{}).
+func (s *SpecSchedule) GobEncode() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// GobDecode decodes a schedule encoded by GobEncode.
+func (s *SpecSchedule) GobDecode(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// textParser parses the specs of SpecSchedule.String.
+var textParser = NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow)
+
+// String returns the canonical spec of the schedule, which a parser with
+// SecondOptional reads back as the same schedule: fields in numbers, the
+// seconds only if they are not 0, a "TZ=" prefix unless the location is
+// Local, and steps and ranges where they fit, as in
+// "TZ=Europe/Paris 30 9 * * 1-5" or "*/15 * * * *".
+func (s *SpecSchedule) String() string {
+	var b strings.Builder
+	if s.Location != nil && s.Location != time.Local {
+		b.WriteString("TZ=" + s.Location.String() + " ")
+	}
+	if s.Second != 1<<seconds.min {
+		b.WriteString(formatField(s.Second, seconds, nil) + " ")
+	}
+	b.WriteString(formatField(s.Minute, minutes, nil) + " ")
+	b.WriteString(formatField(s.Hour, hours, nil) + " ")
+	b.WriteString(formatField(s.Dom, dom, domModifiers(s.Dom)) + " ")
+	b.WriteString(formatField(s.Month, months, nil) + " ")
+	b.WriteString(formatField(s.Dow, dow, dowModifiers(s.Dow)))
+	return b.String()
+}
+
+// MarshalText encodes the schedule as its String.
+func (s *SpecSchedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a schedule encoded by MarshalText, or any spec of its
+// fields that a parser with SecondOptional reads.
+func (s *SpecSchedule) UnmarshalText(text []byte) error {
+	schedule, err := textParser.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*s = *schedule.(*SpecSchedule)
+	return nil
+}
+
+// formatField returns the expression of a field's bits: "*" for a star, a
+// step over a range if the values are evenly spaced, and otherwise a list of
+// values and ranges, followed by the modifiers.
+func formatField(bits uint64, r bounds, modifiers []string) string {
+	if bits&starBit != 0 {
+		return strings.Join(append([]string{"*"}, modifiers...), ",")
+	}
+	var values []uint
+	for v := r.min; v <= r.max; v++ {
+		if bits&(1<<v) != 0 {
+			values = append(values, v)
+		}
+	}
+	if n := len(values); n >= 3 && values[1]-values[0] > 1 {
+		step := values[1] - values[0]
+		i := 2
+		for i < n && values[i]-values[i-1] == step {
+			i++
+		}
+		if i == n {
+			if values[0] == r.min && values[n-1]+step > r.max {
+				return "*/" + strconv.Itoa(int(step))
+			}
+			return fmt.Sprintf("%d-%d/%d", values[0], values[n-1], step)
+		}
+	}
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j > i {
+			items = append(items, fmt.Sprintf("%d-%d", values[i], values[j]))
+		} else {
+			items = append(items, strconv.Itoa(int(values[i])))
+		}
+		i = j + 1
+	}
+	return strings.Join(append(items, modifiers...), ",")
+}
+
+// delayJSON is the JSON encoding of a ConstantDelaySchedule.
+type delayJSON struct {
+	Delay string `json:"delay"`
+}
+
+// MarshalJSON encodes the schedule with its delay as a duration string, such
+// as "1h30m0s".
+func (s ConstantDelaySchedule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(delayJSON{s.Delay.String()})
+}
+
+// UnmarshalJSON decodes a schedule encoded by MarshalJSON.
+func (s *ConstantDelaySchedule) UnmarshalJSON(data []byte) error {
+	var v delayJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d, err := time.ParseDuration(v.Delay)
+	if err != nil {
+		return fmt.Errorf("schedule delay: %v", err)
+	}
+	s.Delay = d
+	return nil
+}
+
+// GobEncode encodes the schedule for encoding/gob, as MarshalJSON does. To
+// send it as a Schedule, register it with
+// gob.Register(cron.ConstantDelaySchedule{}).
+func (s ConstantDelaySchedule) GobEncode() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// GobDecode decodes a schedule encoded by GobEncode.
+func (s *ConstantDelaySchedule) GobDecode(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSpecScheduleJSON(t *testing.T) {
+	for _, spec := range []string{"TZ=America/New_York 0 9 * * MON-FRI", "*/5 * * * *", "CRON_TZ=UTC @daily"} {
+		s, err := ParseStandard(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got SpecSchedule
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		want := s.(*SpecSchedule)
+		if got.Location.String() != want.Location.String() {
+			t.Errorf("%q: expected location %v, got %v", spec, want.Location, got.Location)
+		}
+		got.Location = want.Location
+		if !reflect.DeepEqual(&got, want) {
+			t.Errorf("%q: expected %+v, got %+v from %s", spec, want, got, data)
+		}
+	}
+
+	var s SpecSchedule
+	if err := json.Unmarshal([]byte(`{"second":"1","location":"Nowhere/Never"}`), &s); err == nil {
+		t.Error("expected an error for an unknown location")
+	}
+}
+
+func TestConstantDelayScheduleJSON(t *testing.T) {
+	data, err := json.Marshal(Every(90 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"delay":"1h30m0s"}` {
+		t.Errorf("unexpected encoding %s", data)
+	}
+	var got ConstantDelaySchedule
+	if err := json.Unmarshal(data, &got); err != nil || got.Delay != 90*time.Minute {
+		t.Errorf("expected 1h30m, got %v, %v", got.Delay, err)
+	}
+}
+
+func TestScheduleGob(t *testing.T) {
+	gob.Register(&SpecSchedule{})
+	gob.Register(ConstantDelaySchedule{})
+	spec, _ := ParseStandard("TZ=Asia/Tokyo 30 6 * * *")
+	in := []Schedule{spec, Every(time.Hour)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out []Schedule
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := range in {
+		if got, want := out[i].Next(after), in[i].Next(after); !got.Equal(want) {
+			t.Errorf("schedule %d: expected %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestSpecScheduleString(t *testing.T) {
+	tests := []struct {
+		spec, want string
+	}{
+		{"0 9 * * MON-FRI", "0 9 * * 1-5"},
+		{"TZ=America/New_York 0 9 * * MON-FRI", "TZ=America/New_York 0 9 * * 1-5"},
+		{"CRON_TZ=UTC @daily", "TZ=UTC 0 0 * * *"},
+		{"*/15 * * * *", "*/15 * * * *"},
+		{"0-59/15 * * * *", "*/15 * * * *"},
+		{"5-50/15 1,2,3,7 ? JAN,mar,apr *", "5-50/15 1-3,7 * 1,3-4 *"},
+		{"0 0 1,15,L * *", "0 0 1,15,L * *"},
+		{"0 9 15W,1 * *", "0 9 1,15W * *"},
+		{"0 9 * * MON#2,FRI", "0 9 * * 5,1#2"},
+		{"0 0 1-31 * 0-6", "0 0 1-31 * 0-6"},
+	}
+	for _, tt := range tests {
+		s, err := ParseStandard(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		if got := s.(*SpecSchedule).String(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.spec, tt.want, got)
+		}
+	}
+
+	s, err := secondParser.Parse("*/10 0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.(*SpecSchedule).String(), "*/10 0 9 * * *"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+// TestSpecScheduleText checks that specs survive a round trip through text.
+func TestSpecScheduleText(t *testing.T) {
+	for _, spec := range []string{
+		"0 9 * * MON-FRI",
+		"TZ=Asia/Tokyo 30 4 1,15 * *",
+		"1,2,5-9/2 */7 3-20/3 * * SUN",
+		"0 0 0 31 JAN,MAR,MAY-DEC ?",
+		"0 0 12 L,1W,15W * 2#1,6#5",
+		"0 0 0 * * *,1#1",
+		"@monthly",
+	} {
+		s, err := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor).Parse(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		text, err := s.(*SpecSchedule).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got SpecSchedule
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("%s: %s: %v", spec, text, err)
+		}
+		if !reflect.DeepEqual(&got, s) {
+			t.Errorf("%s: %s decoded to %+v, expected %+v", spec, text, got, s)
+		}
+	}
+
+	var s SpecSchedule
+	if err := s.UnmarshalText([]byte("0 0 * *")); err == nil {
+		t.Error("expected an error for a bad spec")
+	}
+}
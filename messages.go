@@ -0,0 +1,200 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Catalog translates user-facing messages into one language. Its keys are
+// the English format strings, as used by ParseError and DescribeIn, and its
+// values are the translated format strings, taking the same arguments in the
+// same order.
+type Catalog map[string]string
+
+// Chinese is the Simplified Chinese catalog, registered as "zh".
+var Chinese = Catalog{
+	"empty spec string":                                     "表达式为空",
+	"provided bad location %s: %v":                          "无效的时区 %s: %v",
+	"parser does not accept descriptors: %v":                "解析器不接受简写表达式: %v",
+	"multiple optionals may not be configured":              "不能配置多个可选字段",
+	"expected exactly %d fields, found %d: %s":              "应为 %d 个字段，实际为 %d 个: %s",
+	"expected %d to %d fields, found %d: %s":                "应为 %d 到 %d 个字段，实际为 %d 个: %s",
+	"unknown optional field":                                "未知的可选字段",
+	"too many hyphens: %s":                                  "连字符过多: %s",
+	"too many slashes: %s":                                  "斜杠过多: %s",
+	"beginning of range (%d) below minimum (%d): %s":        "范围起点 (%d) 小于最小值 (%d): %s",
+	"end of range (%d) above maximum (%d): %s":              "范围终点 (%d) 大于最大值 (%d): %s",
+	"beginning of range (%d) beyond end of range (%d): %s":  "范围起点 (%d) 大于范围终点 (%d): %s",
+	"step of range should be a positive number: %s":         "步长必须为正数: %s",
+	"failed to parse int from %s: %s":                       "无法从 %s 解析整数: %s",
+	"negative number (%d) not allowed: %s":                  "不允许负数 (%d): %s",
+	"invalid H expression: %s":                              "无效的 H 表达式: %s",
+	"nth weekday (%d) outside 1 to 5: %s":                   "第几个星期几 (%d) 不在 1 到 5 之间: %s",
+	"failed to parse time %s: %s":                           "无法解析时间 %s: %s",
+	"failed to parse duration %s: %s":                       "无法解析时间间隔 %s: %s",
+	"unrecognized descriptor: %s":                           "无法识别的简写表达式: %s",
+	"unrecognized descriptor: %s, did you mean %s?":         "无法识别的简写表达式: %s，您是指 %s 吗？",
+	"unrecognized name: %s, did you mean %s?":               "无法识别的名称: %s，您是指 %s 吗？",
+	"spec longer than %d bytes":                             "表达式超过 %d 字节",
+	"more than %d items in field: %s":                       "字段中的项超过 %d 个: %s",
+	"spec expands to more than %d values":                   "表达式展开后超过 %d 个值",
+	"interval shorter than %v":                              "间隔短于 %v",
+	"step of 1 is redundant: %s":                            "步长 1 是多余的: %s",
+	"step is larger than the range, so only %d matches: %s": "步长大于范围，只有 %d 匹配: %s",
+	"%s repeats values already listed in %s":                "%s 重复了 %s 中已列出的值",
+	"day of month and day of week are both restricted, so a day matching either one runs the job": "日期和星期都受限制，匹配其中任意一个的日子都会运行任务",
+
+	// Describe
+	"%s, or on the last day of months without that day":            "%s，没有该日的月份则在最后一天",
+	"%s, or on the 1st of the month after months without that day": "%s，没有该日的月份则在下个月 1 日",
+	"%s, in %s":                   "%s，%s 年",
+	"Every %s":                    "每%s",
+	"Once at %s":                  "仅在 %s 运行一次",
+	"in %s":                       "在%s",
+	"Every hour":                  "每小时",
+	"At %d minutes past the hour": "每小时的第 %d 分钟",
+	"At %d minutes and %d seconds past the hour": "每小时的第 %d 分 %d 秒",
+	"Every %d hours":                     "每 %d 小时",
+	"Every %d hours from %s through %s":  "每 %d 小时，从 %s 至 %s",
+	"Every hour from %s through %s":      "每小时，从 %s 至 %s",
+	"At %s":                              "在 %s",
+	"in every %s hour":                   "每 %s 小时",
+	"between %02d:00 and %02d:59":        "在 %02d:00 至 %02d:59 之间",
+	"in hours %s":                        "在 %s 点",
+	"second":                             "秒",
+	"minute":                             "分钟",
+	"every %s":                           "每%s",
+	"every %d %ss":                       "每 %d %s",
+	"every %d %ss from %s %d through %d": "每 %d %s，%s从 %d 至 %d",
+	"every %s from %d through %d":        "每%s，从 %d 至 %d",
+	"at %s %d":                           "%s为 %d",
+	"at %ss %s":                          "%s为 %s",
+	"day %s":                             "%s日",
+	"the weekday nearest day %d":         "离 %d 日最近的工作日",
+	"the last day":                       "最后一天",
+	"on %s of the month":                 "每月的%s",
+	"weekdays":                           "工作日",
+	"weekends":                           "周末",
+	"on %s":                              "在%s",
+	"%s or %s":                           "%s或%s",
+	"%d through %d":                      "%d 至 %d",
+	"%s through %s":                      "%s至%s",
+	"%s, %s":                             "%s，%s",
+	"%s and %s":                          "%s和%s",
+	"%dst":                               "%d",
+	"%dnd":                               "%d",
+	"%drd":                               "%d",
+	"%dth":                               "%d",
+	"month":                              "月",
+	"year":                               "年",
+	"1 month":                            "1个月",
+	"%d months":                          "%d个月",
+	"1 year":                             "1年",
+	"%d years":                           "%d年",
+	"January":                            "一月",
+	"February":                           "二月",
+	"March":                              "三月",
+	"April":                              "四月",
+	"May":                                "五月",
+	"June":                               "六月",
+	"July":                               "七月",
+	"August":                             "八月",
+	"September":                          "九月",
+	"October":                            "十月",
+	"November":                           "十一月",
+	"December":                           "十二月",
+	"Sunday":                             "星期日",
+	"Monday":                             "星期一",
+	"Tuesday":                            "星期二",
+	"Wednesday":                          "星期三",
+	"Thursday":                           "星期四",
+	"Friday":                             "星期五",
+	"Saturday":                           "星期六",
+	"the first %s":                       "第一个%s",
+	"the second %s":                      "第二个%s",
+	"the third %s":                       "第三个%s",
+	"the fourth %s":                      "第四个%s",
+	"the fifth %s":                       "第五个%s",
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{"zh": Chinese}
+)
+
+// RegisterCatalog makes the catalog available for the language, such as "fr"
+// or "pt-BR", replacing any registered before. English needs no catalog:
+// messages are written in English.
+func RegisterCatalog(lang string, c Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[strings.ToLower(lang)] = c
+}
+
+// Translate returns the format string translated into the language, or
+// format itself if there is no translation. A regional language such as
+// "zh-CN" falls back to its base language.
+func Translate(lang, format string) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	lang = strings.ToLower(strings.Replace(lang, "_", "-", -1))
+	for lang != "" {
+		if s, ok := catalogs[lang][format]; ok {
+			return s
+		}
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return format
+}
+
+// ParseError is the error returned when a spec fails to parse. Its message
+// may be shown in the user's language with Localize.
+type ParseError struct {
+	// Format is the English format string of the message, which is also the
+	// key of its translations in a Catalog.
+	Format string
+
+	// Args are the arguments of the format string.
+	Args []interface{}
+
+	// Suggestion is what was probably meant, if the spec has a misspelled
+	// descriptor or name, such as "@daily" for "@dialy" or "mon" for "mno",
+	// so that a UI may offer to correct it.
+	Suggestion string
+}
+
+func parseError(format string, args ...interface{}) error {
+	return &ParseError{Format: format, Args: args}
+}
+
+// Error returns the message in English.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf(e.Format, e.Args...)
+}
+
+// Localize returns the message in the language.
+func (e *ParseError) Localize(lang string) string {
+	args := make([]interface{}, len(e.Args))
+	for i, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			arg = Localize(err, lang)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(Translate(lang, e.Format), args...)
+}
+
+// Localize returns the message of err in the language, such as "zh" or
+// "zh-CN", if it is a ParseError. Other errors, and messages without a
+// translation, are returned in English.
+func Localize(err error, lang string) string {
+	if pe, ok := err.(*ParseError); ok {
+		return pe.Localize(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	_, err := ParseStandard("* * * *")
+	if _, ok := err.(*ParseError); !ok {
+		t.Fatalf("expected a ParseError, got %T", err)
+	}
+	if got, want := err.Error(), "expected exactly 5 fields, found 4: [* * * *]"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	for _, lang := range []string{"zh", "zh-CN", "ZH_cn"} {
+		if got, want := Localize(err, lang), "应为 5 个字段，实际为 4 个: [* * * *]"; got != want {
+			t.Errorf("%s: expected %q, got %q", lang, want, got)
+		}
+	}
+	if got := Localize(err, "fr"); got != err.Error() {
+		t.Errorf("expected English without a catalog, got %q", got)
+	}
+
+	RegisterCatalog("fr", Catalog{"unrecognized descriptor: %s": "descripteur inconnu : %s"})
+	defer RegisterCatalog("fr", nil)
+	_, err = ParseStandard("@fortnightly")
+	if got, want := Localize(err, "fr-CA"), "descripteur inconnu : @fortnightly"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if got := Localize(errors.New("boom"), "zh"); got != "boom" {
+		t.Errorf("expected other errors unchanged, got %q", got)
+	}
+}
+
+// Every message in the Chinese catalog should take the same arguments as the
+// English.
+func TestChineseCatalog(t *testing.T) {
+	for en, zh := range Chinese {
+		if verbs(en) != verbs(zh) {
+			t.Errorf("%q: verbs differ in %q", en, zh)
+		}
+	}
+}
+
+func verbs(format string) string {
+	var v []byte
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] == '%' {
+			v = append(v, format[i+1])
+			i++
+		}
+	}
+	return string(v)
+}
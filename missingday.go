@@ -0,0 +1,93 @@
+package cron
+
+import "time"
+
+// MissingDayPolicy selects what a SpecSchedule does in months that lack a day
+// it names, such as February 29th in a common year, or the 31st in a month of
+// 30 days.
+type MissingDayPolicy int
+
+const (
+	// MissingDaySkip skips the month, so "0 0 29 2 *" runs only in leap
+	// years. It is the default, as in classic cron.
+	MissingDaySkip MissingDayPolicy = iota
+
+	// MissingDayLast runs on the last day of the month instead, so
+	// "0 0 29 2 *" runs on February 28th in common years, and "0 0 31 * *"
+	// runs on the last day of every month.
+	MissingDayLast
+
+	// MissingDayNext runs on the first day of the next month instead, so
+	// "0 0 29 2 *" runs on March 1st in common years.
+	MissingDayNext
+)
+
+// MissingDaySchedule is a SpecSchedule that follows a policy other than
+// MissingDaySkip in months that lack a day of month it names.
+type MissingDaySchedule struct {
+	Schedule *SpecSchedule
+	Policy   MissingDayPolicy
+}
+
+// Next returns the next activation time of the schedule after t.
+func (s MissingDaySchedule) Next(t time.Time) time.Time {
+	return s.Schedule.next(t, s.Policy)
+}
+
+// Prev returns the previous activation time of the schedule before t.
+func (s MissingDaySchedule) Prev(t time.Time) time.Time {
+	return s.Schedule.prev(t, s.Policy)
+}
+
+// WithMissingDays returns a copy of the parser whose specs follow the policy
+// in months that lack a day of month they name, as MissingDaySchedules. The
+// policy does not apply when the day of month is "*" or "?".
+//
+//	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).WithMissingDays(cron.MissingDayLast)
+func (p Parser) WithMissingDays(policy MissingDayPolicy) Parser {
+	p.missing = policy
+	return p
+}
+
+// daysIn returns the number of days in the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// lacksDays reports whether the schedule names a day of month that the month
+// of t does not have.
+func (s *SpecSchedule) lacksDays(t time.Time) bool {
+	return s.Dom&starBit == 0 && s.Dom&^weekdayDomBits>>uint(daysIn(t)+1) != 0
+}
+
+// previousMonth returns the first day of the month before t's.
+func previousMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthMatches reports whether the schedule may run in the month of t:
+// because it names the month, or under MissingDayNext, because it names the
+// month before, which lacks a day the schedule names.
+func (s *SpecSchedule) monthMatches(t time.Time, missing MissingDayPolicy) bool {
+	if 1<<uint(t.Month())&s.Month != 0 {
+		return true
+	}
+	if missing != MissingDayNext {
+		return false
+	}
+	prev := previousMonth(t)
+	return 1<<uint(prev.Month())&s.Month != 0 && s.lacksDays(prev)
+}
+
+// missingDayMatches reports whether the day of t stands in for a day of month
+// that the schedule names but a month lacks, under the policy.
+func (s *SpecSchedule) missingDayMatches(t time.Time, missing MissingDayPolicy) bool {
+	switch missing {
+	case MissingDayLast:
+		return 1<<uint(t.Month())&s.Month != 0 && t.Day() == daysIn(t) && s.lacksDays(t)
+	case MissingDayNext:
+		prev := previousMonth(t)
+		return t.Day() == 1 && 1<<uint(prev.Month())&s.Month != 0 && s.lacksDays(prev)
+	}
+	return false
+}
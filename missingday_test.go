@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMissingDays(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow)
+	tests := []struct {
+		policy MissingDayPolicy
+		spec   string
+		from   string
+		want   []string
+	}{
+		{MissingDaySkip, "0 0 29 2 *", "2025-01-01", []string{"2028-02-29"}},
+		{MissingDayLast, "0 0 29 2 *", "2025-01-01", []string{"2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"}},
+		{MissingDayNext, "0 0 29 2 *", "2025-01-01", []string{"2025-03-01", "2026-03-01", "2027-03-01", "2028-02-29", "2029-03-01"}},
+		{MissingDayLast, "0 0 31 * *", "2025-01-01", []string{"2025-01-31", "2025-02-28", "2025-03-31", "2025-04-30"}},
+		{MissingDayNext, "0 0 31 * *", "2025-01-01", []string{"2025-01-31", "2025-03-01", "2025-03-31", "2025-05-01"}},
+		{MissingDayNext, "0 0 30,31 4 *", "2025-01-01", []string{"2025-04-30", "2025-05-01", "2026-04-30"}},
+		{MissingDayLast, "0 0 * 2 *", "2025-02-26", []string{"2025-02-27", "2025-02-28", "2026-02-01"}},
+
+		// A missing day counts as a day of month, so a day of week still
+		// matches on its own.
+		{MissingDayNext, "0 0 31 4 MON", "2025-04-25", []string{"2025-04-28", "2025-05-01", "2026-04-06"}},
+	}
+	for _, test := range tests {
+		schedule, err := parser.WithMissingDays(test.policy).Parse(test.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, _ := time.Parse("2006-01-02", test.from)
+		for _, want := range test.want {
+			next = schedule.Next(next)
+			if got := next.Format("2006-01-02"); got != want || next.Hour() != 0 {
+				t.Errorf("%d %q: expected %s, got %v", test.policy, test.spec, want, next)
+				break
+			}
+		}
+	}
+}
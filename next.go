@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// NextRuns parses the spec and returns its next n activation times after now,
+// as a Cron configured with the given options would compute them: the
+// options select the parser, with WithParser or WithSeconds, the time zone,
+// with WithLocation, and the current time, with WithClock. Fewer than n times
+// are returned if the schedule stops activating.
+//
+//	times, err := cron.NextRuns("0 9 * * MON-FRI", 5)
+func NextRuns(spec string, n int, opts ...Option) ([]time.Time, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative number of runs: %d", n)
+	}
+	c := New(opts...)
+	schedule, err := c.parser.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return nextN(schedule, c.now(), make([]time.Time, 0, n), n), nil
+}
+
+// NextN returns the entry's next n run times, starting with Next and
+// following its schedule in Next's location, which is the Cron's location
+// unless the spec set its own. It returns nil if the entry has no next run,
+// as before the Cron is started, and fewer than n times if the schedule
+// stops activating.
+//
+//	for _, e := range c.Entries() {
+//		fmt.Println(e.Name, e.NextN(5))
+//	}
+func (e Entry) NextN(n int) []time.Time {
+	if n <= 0 || e.Next.IsZero() {
+		return nil
+	}
+	return nextN(e.Schedule, e.Next, append(make([]time.Time, 0, n), e.Next), n)
+}
+
+// nextN appends the schedule's activation times after t to times until it
+// holds n.
+func nextN(schedule Schedule, t time.Time, times []time.Time, n int) []time.Time {
+	for len(times) < n {
+		t = schedule.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// NextN returns the schedule's next n activation times after t, or fewer if
+// it stops activating.
+func (s *SpecSchedule) NextN(t time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	return nextN(s, t, make([]time.Time, 0, n), n)
+}
+
+// Iterator steps through the activation times of a schedule, in the manner
+// of bufio.Scanner:
+//
+//	it := cron.NewIterator(schedule, time.Now())
+//	for i := 0; i < 10 && it.Next(); i++ {
+//		fmt.Println(it.Time())
+//	}
+//
+// It stops when the schedule stops activating, as a spec that never matches
+// does, or returns a time that is not after the one before, so that a loop
+// over it cannot spin forever.
+type Iterator struct {
+	schedule Schedule
+	t        time.Time
+	done     bool
+}
+
+// NewIterator returns an iterator over the activation times of the schedule
+// after t.
+func NewIterator(s Schedule, t time.Time) *Iterator {
+	return &Iterator{schedule: s, t: t}
+}
+
+// Next advances the iterator to the next activation time, which Time then
+// returns. It returns false when there are no more.
+func (it *Iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	next := it.schedule.Next(it.t)
+	if next.IsZero() || !next.After(it.t) {
+		it.done = true
+		return false
+	}
+	it.t = next
+	return true
+}
+
+// Time returns the activation time the iterator last advanced to, or the
+// time it started after if Next has not been called.
+func (it *Iterator) Time() time.Time {
+	return it.t
+}
@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock stopped at a time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time                 { return time.Time(c) }
+func (c fixedClock) NewTimer(d time.Duration) Timer { return realClock{}.NewTimer(d) }
+
+func TestNextRuns(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC) // a Friday
+	times, err := NextRuns("0 9 * * MON-FRI", 3, WithClock(fixedClock(now)), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+	}
+	if len(times) != len(want) {
+		t.Fatalf("expected %v, got %v", want, times)
+	}
+	for i := range want {
+		if !times[i].Equal(want[i]) {
+			t.Errorf("expected %v, got %v", want, times)
+		}
+	}
+
+	if times, _ := NextRuns("30 0 9 * * *", 1, WithSeconds(), WithClock(fixedClock(now)), WithLocation(time.UTC)); len(times) != 1 ||
+		!times[0].Equal(time.Date(2024, 3, 1, 9, 0, 30, 0, time.UTC)) {
+		t.Errorf("expected the seconds parser used, got %v", times)
+	}
+	if times, _ := NextRuns("0 0 30 2 *", 3); len(times) != 0 {
+		t.Errorf("expected no runs of an unsatisfiable spec, got %v", times)
+	}
+	if _, err := NextRuns("* * *", 3); err == nil {
+		t.Error("expected the parse error")
+	}
+}
+
+func TestEntryNextN(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC) // a Friday
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	id, _ := c.AddFunc("0 9 * * MON-FRI", func() {})
+	if times := c.Entry(id).NextN(3); times != nil {
+		t.Errorf("expected no runs before the Cron is started, got %v", times)
+	}
+	c.Start()
+	defer c.Stop()
+
+	times := c.Entry(id).NextN(3)
+	want := []time.Time{
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC),
+	}
+	if len(times) != len(want) {
+		t.Fatalf("expected %v, got %v", want, times)
+	}
+	for i := range want {
+		if !times[i].Equal(want[i]) {
+			t.Errorf("expected %v, got %v", want[i], times[i])
+		}
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	id, _ = c.AddFunc("CRON_TZ=Asia/Tokyo 0 9 * * *", func() {})
+	times = c.Entry(id).NextN(2)
+	if len(times) != 2 || !times[1].Equal(time.Date(2024, 3, 3, 9, 0, 0, 0, tokyo)) {
+		t.Errorf("expected the entry's own time zone used, got %v", times)
+	}
+	if times := c.Entry(id).NextN(0); times != nil {
+		t.Errorf("expected no runs, got %v", times)
+	}
+}
+
+func TestSpecScheduleNextN(t *testing.T) {
+	s, _ := ParseStandard("0 9 * * *")
+	from := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	got := s.(*SpecSchedule).NextN(from, 3)
+	want := []time.Time{
+		time.Date(2025, 7, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := s.(*SpecSchedule).NextN(from, 0); got != nil {
+		t.Errorf("expected nil for no times, got %v", got)
+	}
+
+	never, _ := ParseStandard("0 0 30 2 *")
+	if got := never.(*SpecSchedule).NextN(from, 3); len(got) != 0 {
+		t.Errorf("expected no times for a spec that never matches, got %v", got)
+	}
+}
+
+// stuckSchedule returns the same time over and over.
+type stuckSchedule struct{ t time.Time }
+
+func (s stuckSchedule) Next(time.Time) time.Time { return s.t }
+
+func TestIterator(t *testing.T) {
+	s, _ := ParseStandard("0 9 * * MON")
+	from := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	it := NewIterator(s, from)
+	if !it.Time().Equal(from) {
+		t.Errorf("expected the start time before Next, got %v", it.Time())
+	}
+	var got []time.Time
+	for i := 0; i < 3 && it.Next(); i++ {
+		got = append(got, it.Time())
+	}
+	want := []time.Time{
+		time.Date(2025, 7, 7, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 14, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     int
+	}{
+		{"never", mustParse(t, "0 0 30 2 *"), 0},
+		{"once", At(from.Add(time.Hour)), 1},
+		{"stuck", stuckSchedule{from.Add(time.Hour)}, 1},
+		{"backwards", stuckSchedule{from.Add(-time.Hour)}, 0},
+	}
+	for _, tt := range tests {
+		it := NewIterator(tt.schedule, from)
+		n := 0
+		for it.Next() {
+			n++
+		}
+		if n != tt.want {
+			t.Errorf("%s: expected %d times, got %d", tt.name, tt.want, n)
+		}
+		if it.Next() {
+			t.Errorf("%s: expected Next to stay false", tt.name)
+		}
+	}
+}
+
+func mustParse(t *testing.T, spec string) Schedule {
+	t.Helper()
+	s, err := ParseStandard(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Notification describes a failed run, or a successful run that follows one or
+// more failures.
+type Notification struct {
+	Entry     EntryID
+	Name      string
+	Tags      []string
+	Scheduled time.Time
+	Duration  time.Duration
+
+	// Err is the error the run returned. It is nil for recoveries.
+	Err error
+
+	// Recovered is true if the run succeeded after the previous one failed.
+	Recovered bool
+}
+
+// Notifier sends notifications about runs to people, e.g. through chat or
+// email.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// notifyTimeout bounds how long NotifyOnFailure waits for its Notifier.
+const notifyTimeout = 30 * time.Second
+
+// detachedContext keeps the values of a context, such as its run, without its
+// deadline and cancellation.
+type detachedContext struct{ context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// NotifyOnFailure sends a Notification to n each time the wrapped job fails.
+// If recovery is true, it also notifies on the first success after a failure.
+// Errors from the Notifier are logged to logger. The Notifier is given a
+// context with the values of the run's context, but not its cancellation, so
+// that runs that were cancelled or timed out are notified too.
+func NotifyOnFailure(logger Logger, n Notifier, recovery bool) JobWrapper {
+	return func(j Job) Job {
+		var (
+			mu     sync.Mutex
+			failed bool
+		)
+		return FuncContextJob(func(ctx context.Context) error {
+			clock := clockFromContext(ctx)
+			start := clock.Now()
+			err := RunJob(ctx, j)
+			mu.Lock()
+			wasFailed := failed
+			failed = err != nil
+			mu.Unlock()
+			if err == nil && !(recovery && wasFailed) {
+				return nil
+			}
+
+			note := Notification{Duration: clock.Now().Sub(start), Err: err, Recovered: err == nil}
+			if ri, ok := runFromContext(ctx); ok {
+				note.Entry, note.Name, note.Tags, note.Scheduled = ri.entry.ID, ri.entry.Name, ri.entry.Tags, ri.scheduled
+			}
+			// The run's context may be cancelled already, by CancelRun or a
+			// deadline, so notify on one that only keeps its values.
+			nctx, cancel := context.WithTimeout(detachedContext{ctx}, notifyTimeout)
+			defer cancel()
+			if nerr := n.Notify(nctx, note); nerr != nil {
+				logger.Error(nerr, "notify", "entry", note.Entry, "name", note.Name)
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type notifierFunc func(context.Context, Notification) error
+
+func (f notifierFunc) Notify(ctx context.Context, n Notification) error { return f(ctx, n) }
+
+func TestNotifyOnFailure(t *testing.T) {
+	var (
+		sent []Notification
+		fail = true
+		boom = errors.New("boom")
+	)
+	n := notifierFunc(func(ctx context.Context, note Notification) error {
+		sent = append(sent, note)
+		return nil
+	})
+	job := NewChain(NotifyOnFailure(DiscardLogger, n, true)).Then(FuncContextJob(func(context.Context) error {
+		if fail {
+			return boom
+		}
+		return nil
+	}))
+	ctx := context.WithValue(context.Background(), runKey, &runInfo{entry: Entry{ID: 1, Name: "sync", Tags: []string{"db"}}})
+
+	RunJob(ctx, job)
+	RunJob(ctx, job)
+	fail = false
+	RunJob(ctx, job)
+	RunJob(ctx, job)
+
+	if len(sent) != 3 {
+		t.Fatalf("expected 2 failures and 1 recovery, got %+v", sent)
+	}
+	if sent[0].Err != boom || sent[0].Name != "sync" || len(sent[0].Tags) != 1 || sent[0].Recovered {
+		t.Errorf("unexpected failure notification: %+v", sent[0])
+	}
+	if sent[2].Err != nil || !sent[2].Recovered {
+		t.Errorf("unexpected recovery notification: %+v", sent[2])
+	}
+}
+
+func TestNotifyOnFailureCancelledRun(t *testing.T) {
+	var (
+		notifyErr error
+		note      Notification
+	)
+	n := notifierFunc(func(ctx context.Context, n Notification) error {
+		note, notifyErr = n, ctx.Err()
+		return nil
+	})
+	job := NewChain(NotifyOnFailure(DiscardLogger, n, false)).Then(FuncContextJob(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), runKey, &runInfo{entry: Entry{ID: 1, Name: "sync"}}))
+	cancel()
+
+	RunJob(ctx, job)
+	if note.Err != context.Canceled || note.Name != "sync" {
+		t.Errorf("expected a notification of the cancelled run, got %+v", note)
+	}
+	if notifyErr != nil {
+		t.Errorf("expected the Notifier's context to be live, got %v", notifyErr)
+	}
+}
@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// NthSchedule passes through only every nth activation of a schedule,
+// counted from an anchor: the first activation after it, then the nth after
+// that, and so on. It lets a job that runs on the schedule's days also do
+// something rarer with the same spec:
+//
+//	mondays, _ := cron.ParseStandard("0 3 * * MON")
+//	c.Schedule(mondays, backup)
+//	c.Schedule(cron.EveryNth(mondays, 3), deepClean)
+type NthSchedule struct {
+	Schedule Schedule
+	N        int
+
+	// Anchor is the time after which activations are counted. If zero, it is
+	// set to the time given to the first call of Next, which is when the Cron
+	// schedules the entry.
+	Anchor time.Time
+
+	mu    sync.Mutex
+	last  time.Time // an activation, counted from the anchor
+	index int       // the index of last among the activations
+}
+
+// EveryNth returns a schedule that activates at the first activation of s,
+// and then at every nth. An n below 2 passes through every activation.
+func EveryNth(s Schedule, n int) *NthSchedule {
+	if n < 1 {
+		n = 1
+	}
+	return &NthSchedule{Schedule: s, N: n}
+}
+
+// Next returns the first activation after t whose index among the
+// activations after the anchor is a multiple of N.
+func (s *NthSchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Anchor.IsZero() {
+		s.Anchor = t
+	}
+	n := s.N
+	if n < 1 {
+		n = 1
+	}
+
+	// Count from the last activation seen if possible, rather than the
+	// anchor, so that following the schedule takes constant time.
+	next, index := s.Anchor, -1
+	if !s.last.IsZero() && !s.last.After(t) {
+		next, index = s.last, s.index
+	}
+	for {
+		next = s.Schedule.Next(next)
+		if next.IsZero() {
+			return next
+		}
+		index++
+		if index%n != 0 {
+			continue
+		}
+		s.last, s.index = next, index
+		if next.After(t) {
+			return next
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEveryNth(t *testing.T) {
+	mondays, _ := ParseStandard("0 3 * * MON")
+	s := EveryNth(mondays, 3)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	var got []string
+	for next := from; len(got) < 3; {
+		next = s.Next(next)
+		got = append(got, next.Format("2006-01-02"))
+	}
+	want := []string{"2024-03-04", "2024-03-25", "2024-04-15"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	// Previews from any time count from the same anchor.
+	if next := s.Next(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)); !next.Equal(time.Date(2024, 3, 25, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the 25th from the 5th, got %v", next)
+	}
+	if next := s.Next(from); !next.Equal(time.Date(2024, 3, 4, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the 4th from the anchor, got %v", next)
+	}
+}
+
+func TestEveryNthPassesThrough(t *testing.T) {
+	s := EveryNth(Every(time.Minute), 0)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if next := s.Next(from); !next.Equal(from.Add(time.Minute)) {
+		t.Errorf("expected every activation, got %v", next)
+	}
+}
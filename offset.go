@@ -0,0 +1,43 @@
+package cron
+
+import "time"
+
+// OffsetSchedule shifts every activation of a schedule by a fixed offset, so
+// that entries with identical specs in different services may be staggered
+// without changing the specs.
+type OffsetSchedule struct {
+	Schedule Schedule
+	Offset   time.Duration
+}
+
+// Next returns the next activation time of the schedule after t, shifted by
+// the offset.
+func (s OffsetSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t.Add(-s.Offset))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(s.Offset)
+}
+
+// Prev returns the previous activation time of the schedule before t,
+// shifted by the offset, or the zero time if the schedule is not a
+// PrevSchedule.
+func (s OffsetSchedule) Prev(t time.Time) time.Time {
+	prev, _ := Prev(s.Schedule, t.Add(-s.Offset))
+	if prev.IsZero() {
+		return prev
+	}
+	return prev.Add(s.Offset)
+}
+
+// WithOffset shifts every activation of the entry's schedule by d, so that
+// "0 * * * *" with an offset of 90 seconds runs at 1:30 past each hour.
+// Negative offsets run early.
+func WithOffset(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		if e.Schedule != nil && d != 0 {
+			e.Schedule = OffsetSchedule{Schedule: e.Schedule, Offset: d}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithOffset(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	late, _ := c.AddFunc("0 * * * *", func() {}, WithOffset(90*time.Second))
+	early, _ := c.AddFunc("0 * * * *", func() {}, WithOffset(-time.Minute))
+	e, err := NewEntry().Spec("0 * * * *").Options(WithOffset(15 * time.Minute)).Func(func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	built := c.ScheduleEntry(e)
+	c.Start()
+	defer c.Stop()
+
+	for _, test := range []struct {
+		id   EntryID
+		want []time.Time
+	}{
+		{late, []time.Time{time.Date(2024, 3, 1, 9, 1, 30, 0, time.UTC), time.Date(2024, 3, 1, 10, 1, 30, 0, time.UTC)}},
+		{early, []time.Time{time.Date(2024, 3, 1, 8, 59, 0, 0, time.UTC), time.Date(2024, 3, 1, 9, 59, 0, 0, time.UTC)}},
+		{built, []time.Time{time.Date(2024, 3, 1, 9, 15, 0, 0, time.UTC), time.Date(2024, 3, 1, 10, 15, 0, 0, time.UTC)}},
+	} {
+		times := c.Entry(test.id).NextN(2)
+		if len(times) != 2 || !times[0].Equal(test.want[0]) || !times[1].Equal(test.want[1]) {
+			t.Errorf("entry %d: expected %v, got %v", test.id, test.want, times)
+		}
+	}
+}
+
+func TestOffsetScheduleNever(t *testing.T) {
+	schedule, _ := ParseStandard("0 0 30 2 *")
+	s := OffsetSchedule{Schedule: schedule, Offset: time.Minute}
+	if next := s.Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected no activation, got %v", next)
+	}
+}
@@ -10,7 +10,7 @@ type Option func(*Cron)
 // WithLocation overrides the timezone of the cron instance.
 func WithLocation(loc *time.Location) Option {
 	return func(c *Cron) {
-		c.location = loc
+		c.location.Store(loc)
 	}
 }
 
@@ -43,3 +43,29 @@ func WithLogger(logger Logger) Option {
 		c.logger = logger
 	}
 }
+
+// EntryOption represents a modification to an individual entry, given when it
+// is added to a Cron.
+type EntryOption func(*Entry)
+
+// WithName assigns a name to the entry.
+func WithName(name string) EntryOption {
+	return func(e *Entry) {
+		e.Name = name
+	}
+}
+
+// WithTags assigns tags to the entry.
+func WithTags(tags ...string) EntryOption {
+	return func(e *Entry) {
+		e.Tags = append(e.Tags, tags...)
+	}
+}
+
+// WithPriority sets the priority of the entry. Among entries due at the
+// same time, those of higher priority fire first; see DefaultTieBreak.
+func WithPriority(priority int) EntryOption {
+	return func(e *Entry) {
+		e.Priority = priority
+	}
+}
@@ -9,8 +9,8 @@ import (
 
 func TestWithLocation(t *testing.T) {
 	c := New(WithLocation(time.UTC))
-	if c.location != time.UTC {
-		t.Errorf("expected UTC, got %v", c.location)
+	if c.Location() != time.UTC {
+		t.Errorf("expected UTC, got %v", c.Location())
 	}
 }
 
@@ -40,3 +40,12 @@ func TestWithVerboseLogger(t *testing.T) {
 		t.Error("expected to see some actions, got:", out)
 	}
 }
+
+func TestEntryOptions(t *testing.T) {
+	c := New()
+	id, _ := c.AddFunc("@every 1s", func() {}, WithName("sync"), WithTags("db", "nightly"))
+	e := c.Entry(id)
+	if e.Name != "sync" || len(e.Tags) != 2 || e.Tags[1] != "nightly" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+}
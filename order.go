@@ -0,0 +1,42 @@
+package cron
+
+// TieBreak orders entries due at the same time, reporting whether a fires
+// before b.
+type TieBreak func(a, b *Entry) bool
+
+// WithTieBreak sets the order in which entries due at the same time fire,
+// in place of DefaultTieBreak. The order decides which of them starts
+// first, is dispatched first and first gets a slot of WithConcurrencyLimit.
+// Jobs still run concurrently, so it orders the runs' starts rather than
+// their effects.
+func WithTieBreak(less TieBreak) Option {
+	return func(c *Cron) {
+		c.tieBreak = less
+	}
+}
+
+// DefaultTieBreak orders entries due at the same time by priority, highest
+// first, then by name, with unnamed entries last, then by ID. Since names
+// and priorities are set by the program rather than by the order entries
+// were added in, it gives the same order across restarts, so that jobs that
+// depend on each other without being chained behave the same every time.
+func DefaultTieBreak(a, b *Entry) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if (a.Name == "") != (b.Name == "") {
+		return b.Name == ""
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.ID < b.ID
+}
+
+// firingOrder returns the tie break of the Cron.
+func (c *Cron) firingOrder() TieBreak {
+	if c.tieBreak != nil {
+		return c.tieBreak
+	}
+	return DefaultTieBreak
+}
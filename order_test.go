@@ -0,0 +1,40 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFiringOrder(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	var order []string
+	// Entries are started, then rescheduled, in the order they fire in.
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC), WithEventListener(func(e Event) {
+		if e.Type == EventScheduled {
+			order = append(order, e.Name)
+		}
+	}))
+	// Added in an order unrelated to the one they fire in.
+	c.AddFunc("0 9 * * *", func() {})
+	c.AddFunc("0 9 * * *", func() {}, WithName("b"))
+	c.AddFunc("0 9 * * *", func() {}, WithName("urgent"), WithPriority(10))
+	c.AddFunc("0 9 * * *", func() {}, WithName("a"))
+	fire := func() {
+		order = nil
+		c.entries.update(func(e *Entry) { e.Next = now })
+		c.wake(now)
+		c.jobWaiter.Wait()
+	}
+
+	fire()
+	if want := []string{"urgent", "a", "b", ""}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected %q, got %q", want, order)
+	}
+
+	c.tieBreak = func(a, b *Entry) bool { return a.ID > b.ID }
+	fire()
+	if want := []string{"a", "urgent", "b", ""}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected the custom order %q, got %q", want, order)
+	}
+}
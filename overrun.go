@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// overrunMinSamples is the number of completed runs needed before an entry's
+// mean duration is compared with its interval.
+const overrunMinSamples = 5
+
+// WithOverrunWarning warns when the mean duration of an entry's recent runs
+// reaches ratio times the interval of its schedule, such as 0.8 for 80%, so
+// that a job growing slower is noticed before its runs overlap and wrappers
+// such as SkipIfStillRunning and DelayIfStillRunning start to skip or queue
+// them all the time. The interval is the time from a run's scheduled time to
+// the next activation after it.
+//
+// The warning is logged and reported to listeners as EventOverrunWarning when
+// an entry first reaches the ratio, and again if it falls below and reaches it
+// once more. The ratio of each entry is in its EntryStats as IntervalRatio.
+// Entries are only checked once they have completed 5 runs.
+func WithOverrunWarning(ratio float64) Option {
+	return func(c *Cron) {
+		c.overrun = ratio
+	}
+}
+
+// checkOverrun compares the mean duration of the entry's recent runs with
+// the interval after the run scheduled for scheduled, warning if it reached
+// the ratio given to WithOverrunWarning.
+func (c *Cron) checkOverrun(e Entry, scheduled time.Time, id string) {
+	next := e.Schedule.Next(scheduled)
+	if !next.After(scheduled) {
+		return
+	}
+	interval := next.Sub(scheduled)
+	mean, warn := c.stats.overrun(e.ID, interval, c.overrun)
+	if !warn {
+		return
+	}
+	c.logger.Info("overrun warning", "entry", e.ID, "run", id, "mean", mean, "interval", interval)
+	c.emit(Event{Type: EventOverrunWarning, Entry: e.ID, Name: e.Name, RunID: id, Scheduled: scheduled, Time: c.now(), Duration: mean})
+}
+
+// overrun records the ratio of the entry's mean duration to its interval,
+// returning the mean, and whether the ratio has just reached the threshold.
+func (rs *runStats) overrun(id EntryID, interval time.Duration, threshold float64) (time.Duration, bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	s, ok := rs.entries[id]
+	if !ok || len(s.durations.samples) < overrunMinSamples {
+		return 0, false
+	}
+	mean := s.durations.mean()
+	s.intervalRatio = float64(mean) / float64(interval)
+	over := s.intervalRatio >= threshold
+	warn := over && !s.overrunning
+	s.overrunning = over
+	return mean, warn
+}
+
+// inflightRuns counts the runs of each entry that have been started and not
+// yet completed, whether they are running or waiting to.
+type inflightRuns struct {
+	mu   sync.Mutex
+	runs map[EntryID]int
+}
+
+func (ir *inflightRuns) add(id EntryID, delta int) {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	if ir.runs == nil {
+		ir.runs = make(map[EntryID]int)
+	}
+	if ir.runs[id] += delta; ir.runs[id] <= 0 {
+		delete(ir.runs, id)
+	}
+}
+
+func (ir *inflightRuns) count(id EntryID) int {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	return ir.runs[id]
+}
+
+// checkSlot records how many runs of the entry are still in flight as it
+// fires, as its Overrun, logging it if there are any.
+func (c *Cron) checkSlot(e *Entry) {
+	e.Overrun = c.inflight.count(e.ID)
+	if e.Overrun == 0 {
+		return
+	}
+	c.stats.addOverrun(e.ID)
+	c.logger.Info("overrunning", "entry", e.ID, "scheduled", e.Next, "depth", e.Overrun)
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOverrunWarning(t *testing.T) {
+	clock := &movingClock{now: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	var warnings []Event
+	c := New(WithClock(clock), WithOverrunWarning(0.8), WithEventListener(func(e Event) {
+		if e.Type == EventOverrunWarning {
+			warnings = append(warnings, e)
+		}
+	}))
+	duration := 30 * time.Second
+	id, _ := c.AddFunc("@every 1m", func() { clock.now = clock.now.Add(duration) })
+	run := func(n int) {
+		for i := 0; i < n; i++ {
+			c.runEntry(c.Entry(id), clock.now)
+			clock.now = clock.now.Add(time.Minute)
+		}
+	}
+
+	run(overrunMinSamples)
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warning at half the interval, got %v", warnings)
+	}
+	if ratio := c.Stats()[0].IntervalRatio; ratio != 0.5 {
+		t.Errorf("expected an interval ratio of 0.5, got %v", ratio)
+	}
+
+	// As the runs grow to 55s, their mean passes 48s, 80% of the interval.
+	duration = 55 * time.Second
+	run(20)
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %d", len(warnings))
+	}
+	if w := warnings[0]; w.Entry != id || w.Duration < 48*time.Second || w.Duration >= time.Minute {
+		t.Errorf("unexpected warning %+v", w)
+	}
+
+	// Once the runs are fast again the warning clears, and may recur.
+	duration = time.Second
+	run(100)
+	duration = 59 * time.Second
+	run(100)
+	if len(warnings) != 2 {
+		t.Errorf("expected the warning to recur, got %d", len(warnings))
+	}
+}
+
+func TestSlotOverrun(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)))
+	release := make(chan struct{})
+	id, _ := c.AddFunc("@every 1m", func() { <-release })
+	fire := func() Entry {
+		c.entries.update(func(e *Entry) { e.Next = now })
+		c.wake(now)
+		return c.Entry(id)
+	}
+
+	for want := 0; want < 3; want++ {
+		if e := fire(); e.Overrun != want {
+			t.Errorf("firing %d: expected an overrun of %d, got %d", want+1, want, e.Overrun)
+		}
+	}
+	if st := c.Stats()[0]; st.Overrun != 2 || st.Overruns != 2 {
+		t.Errorf("expected an overrun of 2 in 2 firings, got %d in %d", st.Overrun, st.Overruns)
+	}
+
+	close(release)
+	c.jobWaiter.Wait()
+	if e := fire(); e.Overrun != 0 {
+		t.Errorf("expected no overrun once the runs completed, got %d", e.Overrun)
+	}
+	c.jobWaiter.Wait()
+}
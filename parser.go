@@ -1,11 +1,11 @@
 package cron
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Configuration options for creating a parser. Most options specify which
@@ -24,6 +24,8 @@ const (
 	Dow                                    // Day of week field, default *
 	DowOptional                            // Optional day of week field, default *
 	Descriptor                             // Allow descriptors such as @monthly, @weekly, etc.
+	Year                                   // Year field, after the day of week, default *
+	YearOptional                           // Optional year field, default *
 )
 
 var places = []ParseOption{
@@ -33,6 +35,7 @@ var places = []ParseOption{
 	Dom,
 	Month,
 	Dow,
+	Year,
 }
 
 var defaults = []string{
@@ -42,11 +45,15 @@ var defaults = []string{
 	"*",
 	"*",
 	"*",
+	"*",
 }
 
 // A custom Parser that can be configured.
 type Parser struct {
 	options ParseOption
+	limits  Limits
+	missing MissingDayPolicy
+	seed    string
 }
 
 // NewParser creates a Parser with custom options.
@@ -64,10 +71,9 @@ type Parser struct {
 //  specParser := NewParser(Dom | Month | Dow)
 //  sched, err := specParser.Parse("15 */3 *")
 //
-//  // Same as above, just makes Dow optional
-//  specParser := NewParser(Dom | Month | DowOptional)
-//  sched, err := specParser.Parse("15 */3")
-//
+//	// Same as above, just makes Dow optional
+//	specParser := NewParser(Dom | Month | DowOptional)
+//	sched, err := specParser.Parse("15 */3")
 func NewParser(options ParseOption) Parser {
 	optionals := 0
 	if options&DowOptional > 0 {
@@ -76,10 +82,13 @@ func NewParser(options ParseOption) Parser {
 	if options&SecondOptional > 0 {
 		optionals++
 	}
+	if options&YearOptional > 0 {
+		optionals++
+	}
 	if optionals > 1 {
 		panic("multiple optionals may not be configured")
 	}
-	return Parser{options}
+	return Parser{options: options}
 }
 
 // Parse returns a new crontab schedule representing the given spec.
@@ -87,28 +96,36 @@ func NewParser(options ParseOption) Parser {
 // It accepts crontab specs and features configured by NewParser.
 func (p Parser) Parse(spec string) (Schedule, error) {
 	if len(spec) == 0 {
-		return nil, fmt.Errorf("empty spec string")
+		return nil, parseError("empty spec string")
+	}
+	if err := p.limits.checkLength(spec); err != nil {
+		return nil, err
 	}
 
 	// Extract timezone if present
 	// 时区
 	var loc = time.Local
-	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+	if name, rest, ok := splitTimeZone(spec); ok {
 		var err error
-		i := strings.Index(spec, " ")
-		eq := strings.Index(spec, "=")
-		if loc, err = time.LoadLocation(spec[eq+1 : i]); err != nil {
-			return nil, fmt.Errorf("provided bad location %s: %v", spec[eq+1:i], err)
+		if loc, err = time.LoadLocation(name); err != nil {
+			return nil, parseError("provided bad location %s: %v", name, err)
 		}
-		spec = strings.TrimSpace(spec[i:])
+		spec = rest
 	}
 
 	// 简短cronexpr表达式
 	if strings.HasPrefix(spec, "@") {
 		if p.options&Descriptor == 0 {
-			return nil, fmt.Errorf("parser does not accept descriptors: %v", spec)
+			return nil, parseError("parser does not accept descriptors: %v", spec)
 		}
-		return parseDescriptor(spec, loc)
+		schedule, err := parseDescriptor(spec, loc)
+		if err == nil {
+			err = p.limits.checkInterval(schedule)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return schedule, nil
 	}
 
 	// 通过空格分割
@@ -118,6 +135,15 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 	var err error
 	// 补充不存在的时间段
 	fields, err = normalizeFields(fields, p.options)
+	if err == nil {
+		fields, err = hashFields(fields, p.seed)
+	}
+	if err == nil {
+		err = p.limits.checkFields(fields)
+	}
+	if err == nil {
+		err = p.limits.checkCost(fields)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +170,14 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 		return nil, err
 	}
 
-	return &SpecSchedule{
+	var years []int
+	if len(fields) > 6 {
+		if years, err = getYears(fields[6]); err != nil {
+			return nil, err
+		}
+	}
+
+	var schedule Schedule = &SpecSchedule{
 		Second:   second,
 		Minute:   minute,
 		Hour:     hour,
@@ -152,11 +185,33 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 		Month:    month,
 		Dow:      dayofweek,
 		Location: loc,
-	}, nil
+	}
+	if p.missing != MissingDaySkip {
+		schedule = MissingDaySchedule{Schedule: schedule.(*SpecSchedule), Policy: p.missing}
+	}
+	if years != nil {
+		schedule = YearSchedule{Schedule: schedule, Years: years}
+	}
+	return schedule, nil
+}
+
+// splitTimeZone splits a "TZ=" or "CRON_TZ=" prefix off the spec, returning
+// the zone's name and the rest of the spec. The rest is empty if the spec has
+// nothing after the zone.
+func splitTimeZone(spec string) (name, rest string, ok bool) {
+	if !strings.HasPrefix(spec, "TZ=") && !strings.HasPrefix(spec, "CRON_TZ=") {
+		return "", spec, false
+	}
+	i := strings.IndexFunc(spec, unicode.IsSpace)
+	if i < 0 {
+		i = len(spec)
+	}
+	return spec[strings.Index(spec, "=")+1 : i], strings.TrimSpace(spec[i:]), true
 }
 
 // normalizeFields takes a subset set of the time fields and returns the full set
-// with defaults (zeroes) populated for unset fields.
+// with defaults (zeroes) populated for unset fields. The year is included only
+// if the options have one.
 //
 // As part of performing this function, it also validates that the provided
 // fields are compatible with the configured options.
@@ -171,8 +226,12 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 		options |= Dow
 		optionals++
 	}
+	if options&YearOptional > 0 {
+		options |= Year
+		optionals++
+	}
 	if optionals > 1 {
-		return nil, fmt.Errorf("multiple optionals may not be configured")
+		return nil, parseError("multiple optionals may not be configured")
 	}
 
 	// Figure out how many fields we need
@@ -187,20 +246,25 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 	// Validate number of fields
 	if count := len(fields); count < min || count > max {
 		if min == max {
-			return nil, fmt.Errorf("expected exactly %d fields, found %d: %s", min, count, fields)
+			return nil, parseError("expected exactly %d fields, found %d: %s", min, count, fields)
 		}
-		return nil, fmt.Errorf("expected %d to %d fields, found %d: %s", min, max, count, fields)
+		return nil, parseError("expected %d to %d fields, found %d: %s", min, max, count, fields)
 	}
 
 	// Populate the optional field if not provided
 	if min < max && len(fields) == min {
 		switch {
+		case options&DowOptional > 0 && options&Year > 0:
+			// The day of week comes before the year.
+			fields = append(fields[:min-1:min-1], defaults[5], fields[min-1])
 		case options&DowOptional > 0:
 			fields = append(fields, defaults[5]) // TODO: improve access to default
+		case options&YearOptional > 0:
+			fields = append(fields, defaults[6])
 		case options&SecondOptional > 0:
 			fields = append([]string{defaults[0]}, fields...)
 		default:
-			return nil, fmt.Errorf("unknown optional field")
+			return nil, parseError("unknown optional field")
 		}
 	}
 
@@ -214,6 +278,10 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 			n++
 		}
 	}
+	if options&Year == 0 {
+		// Without a year, the fields are those of classic cron.
+		expandedFields = expandedFields[:len(places)-1]
+	}
 	return expandedFields, nil
 }
 
@@ -228,7 +296,8 @@ var standardParser = NewParser(
 //
 // It accepts
 //   - Standard crontab specs, e.g. "* * * * ?"
-//   - Descriptors, e.g. "@midnight", "@every 1h30m"
+//   - Descriptors, e.g. "@midnight", "@every 1h30m", "@every 3mo",
+//     "@at 2025-07-01T09:00:00Z"
 func ParseStandard(standardSpec string) (Schedule, error) {
 	return standardParser.Parse(standardSpec)
 }
@@ -252,21 +321,33 @@ func getField(field string, r bounds) (uint64, error) {
 }
 
 // getRange returns the bits indicated by the given expression:
-//   number | number "-" number [ "/" number ]
-// or error parsing range
+//
+//	number | number "-" number [ "/" number ]
+//
+// or one of the Quartz modifiers of getModifier, or error parsing range
 // 返回一个位运算后的值
 // 可以查看parse_test.go:12
 func getRange(expr string, r bounds) (uint64, error) {
+	if bits, ok, err := getModifier(expr, r); ok {
+		return bits, err
+	}
+	start, end, step, extra, err := parseRange(expr, r)
+	if err != nil {
+		return 0, err
+	}
+	return getBits(start, end, step) | extra, nil
+}
+
+// parseRange returns the start, end and step of the range of getRange, and
+// starBit as extra if it is a star without a step larger than 1.
+func parseRange(expr string, r bounds) (start, end, step uint, extra uint64, err error) {
 	var (
-		start, end, step uint
-		rangeAndStep     = strings.Split(expr, "/")            //  2-10/10  // 分割步长
-		lowAndHigh       = strings.Split(rangeAndStep[0], "-") // 分割最小和最大
-		singleDigit      = len(lowAndHigh) == 1
-		err              error
+		rangeAndStep = strings.Split(expr, "/")            //  2-10/10  // 分割步长
+		lowAndHigh   = strings.Split(rangeAndStep[0], "-") // 分割最小和最大
+		singleDigit  = len(lowAndHigh) == 1
 	)
 
 	// 计算start和end
-	var extra uint64
 	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
 		start = r.min
 		end = r.max
@@ -276,24 +357,23 @@ func getRange(expr string, r bounds) (uint64, error) {
 		// 解析字符串或者周、月为数字，只有月和周r.names才不为nil
 		start, err = parseIntOrName(lowAndHigh[0], r.names)
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, 0, err
 		}
 		switch len(lowAndHigh) {
 		case 1:
 			// 字段无范围
 			// end等于start
 			end = start
-			fmt.Println(expr, end)
 		case 2:
 			// 字段有范围
 			// 有范围则解析第二哥字段，
 
 			end, err = parseIntOrName(lowAndHigh[1], r.names)
 			if err != nil {
-				return 0, err
+				return 0, 0, 0, 0, err
 			}
 		default:
-			return 0, fmt.Errorf("too many hyphens: %s", expr)
+			return 0, 0, 0, 0, parseError("too many hyphens: %s", expr)
 		}
 	}
 
@@ -306,36 +386,35 @@ func getRange(expr string, r bounds) (uint64, error) {
 		// rangeAndStep的第二个参数是步长
 		step, err = mustParseInt(rangeAndStep[1])
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, 0, err
 		}
 
 		// Special handling: "N/step" means "N-max/step".
 		//
 		if singleDigit { // 为 true表示没有设置范围 即表达式类似 10/1
 			end = r.max
-			fmt.Println(expr, end)
 		}
 		if step > 1 { // 步长大于1 extra设置为0
 			extra = 0
 		}
 	default:
-		return 0, fmt.Errorf("too many slashes: %s", expr)
+		return 0, 0, 0, 0, parseError("too many slashes: %s", expr)
 	}
 
 	if start < r.min {
-		return 0, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
+		return 0, 0, 0, 0, parseError("beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
 	}
 	if end > r.max {
-		return 0, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
+		return 0, 0, 0, 0, parseError("end of range (%d) above maximum (%d): %s", end, r.max, expr)
 	}
 	if start > end {
-		return 0, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+		return 0, 0, 0, 0, parseError("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
 	}
 	if step == 0 {
-		return 0, fmt.Errorf("step of range should be a positive number: %s", expr)
+		return 0, 0, 0, 0, parseError("step of range should be a positive number: %s", expr)
 	}
 
-	return getBits(start, end, step) | extra, nil
+	return start, end, step, extra, nil
 }
 
 // parseIntOrName returns the (possibly-named) integer contained in expr.
@@ -344,6 +423,13 @@ func parseIntOrName(expr string, names map[string]uint) (uint, error) {
 		if namedInt, ok := names[strings.ToLower(expr)]; ok {
 			return namedInt, nil
 		}
+		if s, ok := suggestName(expr, names); ok {
+			return 0, &ParseError{
+				Format:     "unrecognized name: %s, did you mean %s?",
+				Args:       []interface{}{expr, s},
+				Suggestion: s,
+			}
+		}
 	}
 	return mustParseInt(expr)
 }
@@ -352,17 +438,16 @@ func parseIntOrName(expr string, names map[string]uint) (uint, error) {
 func mustParseInt(expr string) (uint, error) {
 	num, err := strconv.Atoi(expr)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse int from %s: %s", expr, err)
+		return 0, parseError("failed to parse int from %s: %s", expr, err)
 	}
 	if num < 0 {
-		return 0, fmt.Errorf("negative number (%d) not allowed: %s", num, expr)
+		return 0, parseError("negative number (%d) not allowed: %s", num, expr)
 	}
 
 	return uint(num), nil
 }
 
 // getBits sets all bits in the range [min, max], modulo the given step size.
-//
 func getBits(min, max, step uint) uint64 {
 	var bits uint64
 		// & 与运算
@@ -454,12 +539,21 @@ func parseDescriptor(descriptor string, loc *time.Location) (Schedule, error) {
 
 	const every = "@every "
 	if strings.HasPrefix(descriptor, every) {
-		duration, err := time.ParseDuration(descriptor[len(every):])
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse duration %s: %s", descriptor, err)
-		}
-		return Every(duration), nil
+		return parseEvery(descriptor[len(every):])
+	}
+
+	const at = "@at "
+	if strings.HasPrefix(descriptor, at) {
+		return parseAt(descriptor[len(at):])
 	}
 
-	return nil, fmt.Errorf("unrecognized descriptor: %s", descriptor)
+	word := strings.Fields(descriptor)[0]
+	if s, ok := suggest(word, descriptorNames); ok {
+		return nil, &ParseError{
+			Format:     "unrecognized descriptor: %s, did you mean %s?",
+			Args:       []interface{}{descriptor, s},
+			Suggestion: s,
+		}
+	}
+	return nil, parseError("unrecognized descriptor: %s", descriptor)
 }
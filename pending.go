@@ -0,0 +1,295 @@
+package cron
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Pending describes a run that is due but waiting to execute.
+type Pending struct {
+	// Entry and Name identify the entry.
+	Entry EntryID
+	Name  string
+
+	// RunID identifies the run, as reported by RunIDFromContext.
+	RunID string
+
+	// Scheduled is the time the run was scheduled for.
+	Scheduled time.Time
+
+	// Since is when the run started waiting, and Wait how long it has waited.
+	Since time.Time
+	Wait  time.Duration
+
+	// Reason is why the run is waiting: "concurrency limit" if it is waiting
+	// for a slot under WithConcurrencyLimit, "bulkhead" if it is waiting for
+	// a slot under WithBulkhead, "still running" if it was
+	// delayed by DelayIfStillRunning, "blackout" if it was deferred by a
+	// Blackout, or "outside window" if it was deferred by OnlyDuring.
+	Reason string
+}
+
+// Pending returns the runs that are waiting to execute, longest waiting
+// first, so that growth of the backlog is visible.
+func (c *Cron) Pending() []Pending {
+	now := c.clock.Now()
+	c.pending.mu.Lock()
+	pending := make([]Pending, 0, len(c.pending.runs))
+	for _, pr := range c.pending.runs {
+		pending = append(pending, Pending{
+			Entry:     pr.ri.entry.ID,
+			Name:      pr.ri.entry.Name,
+			RunID:     pr.ri.id,
+			Scheduled: pr.ri.scheduled,
+			Since:     pr.since,
+			Wait:      now.Sub(pr.since),
+			Reason:    pr.reason,
+		})
+	}
+	c.pending.mu.Unlock()
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Since.Before(pending[j].Since)
+	})
+	return pending
+}
+
+// WithConcurrencyLimit limits the number of jobs that may run at once. Runs
+// that are due while the limit is reached wait, in the order they became due
+// unless WithFairQueueing or WithPriorityQueueing is given, and are listed by
+// Pending.
+func WithConcurrencyLimit(n int) Option {
+	return func(c *Cron) {
+		c.limiter = &limiter{limit: n, pending: &c.pending, queueing: c.queueing, reason: "concurrency limit"}
+	}
+}
+
+// WithFairQueueing makes runs waiting under WithConcurrencyLimit take turns
+// by tenant, as returned by the given func, rather than wait in the order
+// they became due: each freed slot goes to the next tenant with a waiting
+// run, round-robin, so that one tenant's burst of runs cannot starve the
+// others. Each tenant's runs still start in the order they became due.
+//
+//	cron.New(
+//		cron.WithConcurrencyLimit(8),
+//		cron.WithFairQueueing(cron.FirstTag))
+func WithFairQueueing(tenant func(Entry) string) Option {
+	return func(c *Cron) {
+		q := c.queueing
+		q.tenant = tenant
+		c.setQueueing(q)
+	}
+}
+
+// WithPriorityQueueing makes runs waiting under WithConcurrencyLimit start
+// in order of their entries' priority, set with WithPriority, rather than in
+// the order they became due. So that low-priority runs are not starved under
+// sustained high-priority load, a waiting run gains one point of priority for
+// every aging it has waited; an aging of zero disables this. Runs of equal
+// priority start in the order they became due. With WithFairQueueing, the
+// order applies within each tenant's turn.
+func WithPriorityQueueing(aging time.Duration) Option {
+	return func(c *Cron) {
+		q := c.queueing
+		q.priority, q.aging = true, aging
+		c.setQueueing(q)
+	}
+}
+
+// queueing configures the order in which runs waiting for a slot start.
+type queueing struct {
+	tenant   func(Entry) string
+	priority bool
+	aging    time.Duration
+}
+
+// FirstTag returns the entry's first tag, or "" if it has none, for use as a
+// tenant with WithFairQueueing.
+func FirstTag(e Entry) string {
+	if len(e.Tags) == 0 {
+		return ""
+	}
+	return e.Tags[0]
+}
+
+// pendingRuns tracks the runs that are waiting to execute.
+type pendingRuns struct {
+	mu   sync.Mutex
+	runs map[string]pendingRun
+}
+
+type pendingRun struct {
+	ri     *runInfo
+	since  time.Time
+	reason string
+}
+
+func (pr *pendingRuns) add(ri *runInfo, reason string) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.runs == nil {
+		pr.runs = make(map[string]pendingRun)
+	}
+	pr.runs[ri.id] = pendingRun{ri, ri.clock().Now(), reason}
+}
+
+func (pr *pendingRuns) remove(ri *runInfo) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	delete(pr.runs, ri.id)
+}
+
+// limiter hands out a fixed number of slots to runs, queueing the rest. Each
+// tenant has its own queue, and freed slots go to the queues in turn.
+type limiter struct {
+	queueing
+	mu      sync.Mutex
+	limit   int
+	running int
+	queues  map[string][]*waiter
+	turns   []string // tenants with queued runs, in the order they take turns
+	turn    int      // index in turns of the tenant whose turn is next
+	pending *pendingRuns
+	reason  string // why queued runs are pending
+}
+
+// waiter is a run queued for a slot. Its ready channel is closed when the
+// slot is handed to it.
+type waiter struct {
+	ri     *runInfo
+	tenant string
+	since  time.Time
+	ready  chan struct{}
+}
+
+// acquire blocks until the run has a slot or ctx is done.
+func (l *limiter) acquire(ctx context.Context, ri *runInfo) error {
+	l.mu.Lock()
+	if l.running < l.limit {
+		l.running++
+		l.mu.Unlock()
+		return nil
+	}
+	w := &waiter{ri: ri, since: ri.clock().Now(), ready: make(chan struct{})}
+	if l.tenant != nil {
+		w.tenant = l.tenant(ri.entry)
+	}
+	l.enqueue(w)
+	l.mu.Unlock()
+
+	l.pending.add(ri, l.reason)
+	defer l.pending.remove(ri)
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.dequeue(w) {
+			return ctx.Err()
+		}
+		// The slot was handed over as ctx was done, so pass it on.
+		l.releaseLocked()
+		return ctx.Err()
+	}
+}
+
+// enqueue adds the waiter to the end of its tenant's queue. A tenant new to
+// the turns waits for all the others.
+func (l *limiter) enqueue(w *waiter) {
+	if l.queues == nil {
+		l.queues = make(map[string][]*waiter)
+	}
+	q, ok := l.queues[w.tenant]
+	if !ok {
+		// Insert before the tenant whose turn is next, so that it is last.
+		l.turns = append(l.turns, "")
+		copy(l.turns[l.turn+1:], l.turns[l.turn:])
+		l.turns[l.turn] = w.tenant
+		l.turn = (l.turn + 1) % len(l.turns)
+	}
+	l.queues[w.tenant] = append(q, w)
+}
+
+// dequeue removes the waiter from its tenant's queue, returning false if it
+// is not queued.
+func (l *limiter) dequeue(w *waiter) bool {
+	q := l.queues[w.tenant]
+	for i, qw := range q {
+		if qw == w {
+			l.setQueue(w.tenant, append(q[:i], q[i+1:]...))
+			return true
+		}
+	}
+	return false
+}
+
+// setQueue replaces the tenant's queue, dropping the tenant from the turns if
+// it is empty.
+func (l *limiter) setQueue(tenant string, q []*waiter) {
+	if len(q) > 0 {
+		l.queues[tenant] = q
+		return
+	}
+	delete(l.queues, tenant)
+	for i, t := range l.turns {
+		if t == tenant {
+			l.turns = append(l.turns[:i], l.turns[i+1:]...)
+			if i < l.turn {
+				l.turn--
+			}
+			break
+		}
+	}
+	if l.turn >= len(l.turns) {
+		l.turn = 0
+	}
+}
+
+// release gives up a slot, handing it to the next queued run, if any.
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseLocked()
+}
+
+func (l *limiter) releaseLocked() {
+	if len(l.turns) == 0 {
+		l.running--
+		return
+	}
+	tenant := l.turns[l.turn]
+	q := l.queues[tenant]
+	i := l.next(q)
+	w := q[i]
+	l.turn = (l.turn + 1) % len(l.turns)
+	l.setQueue(tenant, append(q[:i], q[i+1:]...))
+	close(w.ready)
+}
+
+// next returns the index in the queue of the run to start next: the first,
+// or the first of the highest priority with WithPriorityQueueing.
+func (l *limiter) next(q []*waiter) int {
+	if !l.priority {
+		return 0
+	}
+	now := q[0].ri.clock().Now()
+	best, bestPriority := 0, l.effectivePriority(q[0], now)
+	for i, w := range q[1:] {
+		if p := l.effectivePriority(w, now); p > bestPriority {
+			best, bestPriority = i+1, p
+		}
+	}
+	return best
+}
+
+// effectivePriority returns the priority of the waiting run's entry, raised
+// by how long it has waited.
+func (l *limiter) effectivePriority(w *waiter, now time.Time) int {
+	p := w.ri.entry.Priority
+	if l.aging > 0 {
+		p += int(now.Sub(w.since) / l.aging)
+	}
+	return p
+}
@@ -0,0 +1,171 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	c := New(WithConcurrencyLimit(1))
+	started, done := make(chan string, 3), make(chan error, 3)
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	first := <-started
+
+	// The second run waits for the first to finish.
+	go c.runEntry(blockingEntry(2, started, done), time.Now())
+	var pending []Pending
+	for deadline := time.Now().Add(time.Second); len(pending) == 0; pending = c.Pending() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a pending run")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if p := pending[0]; p.Entry != 2 || p.Reason != "concurrency limit" || p.Since.IsZero() {
+		t.Errorf("unexpected pending run: %+v", p)
+	}
+	select {
+	case <-started:
+		t.Fatal("expected the second run to wait")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	c.CancelRun(first)
+	<-done
+	second := <-started
+	if len(c.Pending()) != 0 {
+		t.Errorf("expected no pending runs, got %v", c.Pending())
+	}
+	c.CancelRun(second)
+	<-done
+}
+
+// Cancelling a pending run removes it from the queue without running it.
+func TestConcurrencyLimitCancelPending(t *testing.T) {
+	c := New(WithConcurrencyLimit(1))
+	started, done := make(chan string, 2), make(chan error, 2)
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	first := <-started
+
+	finished := make(chan struct{})
+	go func() {
+		c.runEntry(blockingEntry(2, started, done), time.Now())
+		close(finished)
+	}()
+	for len(c.Pending()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if !c.CancelRun(c.Pending()[0].RunID) {
+		t.Fatal("expected to find the pending run")
+	}
+	<-finished
+
+	c.CancelRun(first)
+	<-done
+	if len(started) != 0 {
+		t.Error("expected the cancelled run never to start")
+	}
+}
+
+func TestDelayIfStillRunningPending(t *testing.T) {
+	c := New()
+	release := make(chan struct{})
+	job := NewChain(DelayIfStillRunning(DiscardLogger)).Then(FuncContextJob(func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+	e := Entry{ID: 1, WrappedJob: job}
+	go c.runEntry(e, time.Now())
+	go c.runEntry(e, time.Now())
+
+	for deadline := time.Now().Add(time.Second); len(c.Pending()) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a delayed run to be pending")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if p := c.Pending()[0]; p.Reason != "still running" {
+		t.Errorf("unexpected pending run: %+v", p)
+	}
+	close(release)
+}
+
+// Runs waiting under WithFairQueueing take turns by tenant.
+func TestFairQueueing(t *testing.T) {
+	c := New(WithConcurrencyLimit(1), WithFairQueueing(FirstTag))
+	l := c.limiter
+	if err := l.acquire(context.Background(), &runInfo{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tenant a bursts before b and c have anything due.
+	started := make(chan string, 6)
+	for i, tenant := range []string{"a", "a", "a", "b", "c", "b"} {
+		ri := &runInfo{id: strconv.Itoa(i), entry: Entry{Tags: []string{tenant}}}
+		go func() {
+			l.acquire(context.Background(), ri)
+			started <- ri.entry.Tags[0]
+		}()
+		for len(c.Pending()) <= i {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var order string
+	for i := 0; i < 6; i++ {
+		l.release()
+		order += <-started
+	}
+	if order != "abcaba" {
+		t.Errorf("expected turns %q, got %q", "abcaba", order)
+	}
+}
+
+// Runs waiting under WithPriorityQueueing start highest priority first.
+func TestPriorityQueueing(t *testing.T) {
+	c := New(WithPriorityQueueing(0), WithConcurrencyLimit(1))
+	l := c.limiter
+	if err := l.acquire(context.Background(), &runInfo{}); err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan int, 4)
+	for i, priority := range []int{0, 5, 1, 5} {
+		ri := &runInfo{id: strconv.Itoa(i), entry: Entry{ID: EntryID(i), Priority: priority}}
+		go func() {
+			l.acquire(context.Background(), ri)
+			started <- int(ri.entry.ID)
+		}()
+		for len(c.Pending()) <= i {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var order []int
+	for i := 0; i < 4; i++ {
+		l.release()
+		order = append(order, <-started)
+	}
+	if fmt.Sprint(order) != "[1 3 2 0]" {
+		t.Errorf("expected runs 1, 3, 2 and 0, got %v", order)
+	}
+}
+
+// A waiting run gains priority as it ages, until it overtakes newer runs.
+func TestPriorityAging(t *testing.T) {
+	now := time.Now()
+	q := []*waiter{
+		{ri: &runInfo{entry: Entry{Priority: 0}}, since: now.Add(-10 * time.Second)},
+		{ri: &runInfo{entry: Entry{Priority: 5}}, since: now},
+	}
+	l := &limiter{queueing: queueing{priority: true}}
+	if i := l.next(q); i != 1 {
+		t.Errorf("expected the higher priority run without aging, got %d", i)
+	}
+	l.aging = time.Second
+	if i := l.next(q); i != 0 {
+		t.Errorf("expected the aged run, got %d", i)
+	}
+}
@@ -0,0 +1,128 @@
+package cron
+
+import "time"
+
+// Piece is a part of a PiecewiseSchedule: a schedule followed during the
+// windows.
+type Piece struct {
+	Windows  []TimeWindow
+	Schedule Schedule
+}
+
+// PiecewiseSchedule follows different schedules at different times of the
+// day or week, such as every minute during business hours and every 15
+// minutes otherwise, for polling with tighter deadlines at busy times:
+//
+//	every15m, _ := cron.ParseStandard("*/15 * * * *")
+//	everyMinute, _ := cron.ParseStandard("* * * * *")
+//	c.Schedule(cron.Piecewise(every15m, cron.Piece{
+//		Windows:  []cron.TimeWindow{{Days: cron.Weekdays, Start: 9 * time.Hour, End: 18 * time.Hour}},
+//		Schedule: everyMinute,
+//	}), job)
+//
+// At any time, the first piece with a window containing it is followed, or
+// Otherwise if none does. Windows are evaluated in the time zone of the times
+// the schedule is given, which is the Cron's. A relative schedule, such as
+// Every's, counts from when its piece starts being followed.
+type PiecewiseSchedule struct {
+	Pieces []Piece
+
+	// Otherwise is followed outside the windows of all pieces, or nil to not
+	// activate then.
+	Otherwise Schedule
+}
+
+// Piecewise returns a PiecewiseSchedule of the pieces, following otherwise
+// outside their windows.
+func Piecewise(otherwise Schedule, pieces ...Piece) PiecewiseSchedule {
+	return PiecewiseSchedule{Pieces: pieces, Otherwise: otherwise}
+}
+
+// Next returns the first activation time after t of the schedule followed at
+// that time, or the zero time if there is none within five years.
+func (s PiecewiseSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	consider := func(i int, schedule Schedule) {
+		if n := s.nextFollowed(i, schedule, t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	for i, p := range s.Pieces {
+		consider(i, p.Schedule)
+	}
+	if s.Otherwise != nil {
+		consider(-1, s.Otherwise)
+	}
+	return next
+}
+
+// followed returns the index of the piece followed at t, or -1 for
+// Otherwise.
+func (s PiecewiseSchedule) followed(t time.Time) int {
+	for i, p := range s.Pieces {
+		for _, w := range p.Windows {
+			if w.contains(t) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// nextFollowed returns the first activation time of the schedule of piece i,
+// or of Otherwise if i is -1, after t and at a time it is followed.
+func (s PiecewiseSchedule) nextFollowed(i int, schedule Schedule, t time.Time) time.Time {
+	limit := t.AddDate(5, 0, 0)
+	for {
+		n := schedule.Next(t)
+		if n.IsZero() || n.After(limit) {
+			return time.Time{}
+		}
+		if s.followed(n) == i {
+			return n
+		}
+		// Nothing changes until a window opens or closes, so look from then.
+		change := s.nextChange(n)
+		if change.IsZero() {
+			return time.Time{}
+		}
+		t = change.Add(-time.Nanosecond)
+	}
+}
+
+// nextChange returns the first time after t that a window of any piece opens
+// or closes, or the zero time if none ever does.
+func (s PiecewiseSchedule) nextChange(t time.Time) time.Time {
+	var first time.Time
+	for _, p := range s.Pieces {
+		for _, w := range p.Windows {
+			if c := w.nextChange(t); !c.IsZero() && (first.IsZero() || c.Before(first)) {
+				first = c
+			}
+		}
+	}
+	return first
+}
+
+// nextChange returns the first start or end of the window after t, or the
+// zero time if it has no days.
+func (w TimeWindow) nextChange(t time.Time) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	var first time.Time
+	for i := -1; i <= 7; i++ {
+		day := midnight.AddDate(0, 0, i)
+		if !w.onDay(day.Weekday()) {
+			continue
+		}
+		end := day.Add(w.End)
+		if w.End <= w.Start {
+			end = day.AddDate(0, 0, 1).Add(w.End)
+		}
+		for _, c := range []time.Time{day.Add(w.Start), end} {
+			if c.After(t) && (first.IsZero() || c.Before(first)) {
+				first = c
+			}
+		}
+	}
+	return first
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPiecewiseSchedule(t *testing.T) {
+	every15m, _ := ParseStandard("*/15 * * * *")
+	everyMinute, _ := ParseStandard("* * * * *")
+	s := Piecewise(every15m, Piece{
+		Windows:  []TimeWindow{{Days: Weekdays, Start: 9 * time.Hour, End: 18 * time.Hour}},
+		Schedule: everyMinute,
+	})
+	tests := []struct {
+		from, want string
+	}{
+		{"Fri 2024-03-01 08:40:00", "Fri 2024-03-01 08:45:00"},
+		{"Fri 2024-03-01 08:50:00", "Fri 2024-03-01 09:00:00"}, // the window opens
+		{"Fri 2024-03-01 09:00:00", "Fri 2024-03-01 09:01:00"},
+		{"Fri 2024-03-01 12:34:30", "Fri 2024-03-01 12:35:00"},
+		{"Fri 2024-03-01 17:59:00", "Fri 2024-03-01 18:00:00"}, // the window closes
+		{"Fri 2024-03-01 18:00:00", "Fri 2024-03-01 18:15:00"},
+		{"Sat 2024-03-02 10:00:00", "Sat 2024-03-02 10:15:00"},
+	}
+	for _, test := range tests {
+		from, _ := time.ParseInLocation("Mon 2006-01-02 15:04:05", test.from, time.UTC)
+		if got := s.Next(from).Format("Mon 2006-01-02 15:04:05"); got != test.want {
+			t.Errorf("%s: expected %s, got %s", test.from, test.want, got)
+		}
+	}
+}
+
+func TestPiecewiseScheduleWithoutOtherwise(t *testing.T) {
+	// Hourly, only overnight, with the window running past midnight.
+	hourly, _ := ParseStandard("0 * * * *")
+	s := Piecewise(nil, Piece{Windows: []TimeWindow{{Start: 22 * time.Hour, End: 2 * time.Hour}}, Schedule: hourly})
+	from := time.Date(2024, 3, 1, 2, 30, 0, 0, time.UTC)
+	var got []int
+	for i := 0; i < 5; i++ {
+		from = s.Next(from)
+		got = append(got, from.Hour())
+	}
+	if want := []int{22, 23, 0, 1, 22}; !equalInts(got, want) {
+		t.Errorf("expected hours %v, got %v", want, got)
+	}
+
+	if next := Piecewise(nil, Piece{Schedule: hourly}).Next(from); !next.IsZero() {
+		t.Errorf("expected no activations without windows, got %v", next)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
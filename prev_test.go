@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpecSchedulePrev(t *testing.T) {
+	tests := []struct {
+		spec string
+		at   string
+		want string
+	}{
+		{"0 9 * * *", "2024-03-10T09:00:00Z", "2024-03-09T09:00:00Z"},
+		{"0 9 * * *", "2024-03-10T09:00:00.5Z", "2024-03-10T09:00:00Z"},
+		{"*/15 * * * *", "2024-03-10T09:07:00Z", "2024-03-10T09:00:00Z"},
+		{"0 0 1 1 *", "2024-01-01T00:00:00Z", "2023-01-01T00:00:00Z"},
+		{"0 0 29 2 *", "2025-03-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"0 0 L * *", "2024-03-15T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"0 9 15W * *", "2024-06-20T00:00:00Z", "2024-06-14T09:00:00Z"},
+		{"0 9 * * MON#2", "2024-02-01T00:00:00Z", "2024-01-08T09:00:00Z"},
+		{"30 2 * * *", "2024-03-10T12:00:00-04:00", "2024-03-10T02:30:00-04:00"},
+		{"TZ=America/New_York 0 9 * * MON-FRI", "2024-03-11T12:00:00Z", "2024-03-08T09:00:00-05:00"},
+		{"TZ=America/New_York 30 2 * * *", "2024-03-10T12:00:00Z", "2024-03-09T02:30:00-05:00"},
+		{"0 0 30 2 *", "2024-03-01T00:00:00Z", ""},
+	}
+	for _, tt := range tests {
+		s, err := ParseStandard(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		at, _ := time.Parse(time.RFC3339Nano, tt.at)
+		got := s.(*SpecSchedule).Prev(at)
+		if tt.want == "" {
+			if !got.IsZero() {
+				t.Errorf("%s before %s: expected the zero time, got %v", tt.spec, tt.at, got)
+			}
+			continue
+		}
+		want, _ := time.Parse(time.RFC3339, tt.want)
+		if !got.Equal(want) {
+			t.Errorf("%s before %s: expected %v, got %v", tt.spec, tt.at, want, got)
+		}
+	}
+}
+
+// TestPrevMirrorsNext checks that Prev finds the activation just before each
+// of those that Next finds, across DST changes.
+func TestPrevMirrorsNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	specs := []string{
+		"* * * * * *",
+		"0 30 * * * *",
+		"0 0 2 * * *",
+		"0 30 1 * * *",
+		"15 45 */5 * * MON,WED",
+		"0 0 0 L * *",
+		"0 0 12 1W * *",
+		"0 0 6 * * FRI#1,SUN#3",
+		"0 0 0 29 2 *",
+	}
+	for _, spec := range specs {
+		s, err := secondParser.Parse(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		ss := s.(*SpecSchedule)
+		ss.Location = ny
+		for _, from := range []time.Time{
+			time.Date(2024, 2, 25, 0, 0, 0, 0, ny),
+			time.Date(2024, 3, 10, 1, 59, 0, 0, ny),
+			time.Date(2024, 11, 3, 0, 59, 0, 0, ny),
+		} {
+			prev := ss.Next(from)
+			for i := 0; i < 200; i++ {
+				next := ss.Next(prev)
+				if next.IsZero() {
+					break
+				}
+				if got := ss.Prev(next); !got.Equal(prev) {
+					t.Fatalf("%s: expected the activation before %v to be %v, got %v", spec, next, prev, got)
+				}
+				prev = next
+			}
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	at := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	spec, _ := ParseStandard("0 9 * * *")
+	tests := []struct {
+		schedule Schedule
+		want     time.Time
+		ok       bool
+	}{
+		{spec, at.AddDate(0, 0, -1), true},
+		{OffsetSchedule{Schedule: spec, Offset: time.Hour}, at.Add(-23 * time.Hour), true},
+		{At(at.Add(-time.Minute)), at.Add(-time.Minute), true},
+		{At(at), time.Time{}, true},
+		{Every(time.Hour), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := Prev(tt.schedule, at)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("%T: expected %v, %v, got %v, %v", tt.schedule, tt.want, tt.ok, got, ok)
+		}
+	}
+
+	p := NewParser(Minute | Hour | Dom | Month | Dow | Year).WithMissingDays(MissingDayLast)
+	s, _ := p.Parse("0 9 31 * * 2023")
+	if got, want := s.(PrevSchedule).Prev(at), time.Date(2023, 12, 31, 9, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	s, _ = p.Parse("0 9 31 * * 2024")
+	if got, want := s.(PrevSchedule).Prev(time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)), time.Date(2024, 4, 30, 9, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("expected the last day of April, %v, got %v", want, got)
+	}
+}
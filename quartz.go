@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The Quartz modifiers of the day fields are kept in bits of Dom and Dow
+// beyond those of their values, as the star bit is.
+const (
+	// lastDomBit in Dom is "L", the last day of the month. Days of the month
+	// start at 1, so its bit 0 is free.
+	lastDomBit = 1 << 0
+
+	// weekdayDomBits in Dom are "1W" to "31W", the weekday nearest each day,
+	// with that of day d at bit weekdayDomShift+d.
+	weekdayDomShift = 31
+	weekdayDomBits  = (1<<31 - 1) << (weekdayDomShift + 1)
+
+	// nthDowBits in Dow are "SUN#1" to "SAT#5", the nth of a weekday in the
+	// month, with that of weekday w at bit nthDowShift+(n-1)*7+w.
+	nthDowShift = 8
+	nthDowBits  = (1<<35 - 1) << nthDowShift
+)
+
+// sameBounds reports whether a and b are the bounds of the same field.
+func sameBounds(a, b bounds) bool {
+	return a.min == b.min && a.max == b.max && len(a.names) == len(b.names)
+}
+
+// getModifier returns the bits of a Quartz modifier, and true, if the
+// expression is one:
+//
+//	"L" in the day of month, the last day of the month
+//	"15W" in the day of month, the weekday nearest the 15th
+//	"MON#2" in the day of week, the second Monday of the month
+func getModifier(expr string, r bounds) (uint64, bool, error) {
+	if sameBounds(r, dow) {
+		return getNthWeekday(expr, r)
+	}
+	if !sameBounds(r, dom) {
+		return 0, false, nil
+	}
+	if expr == "L" {
+		return lastDomBit, true, nil
+	}
+	if day := strings.TrimSuffix(expr, "W"); day != expr && day != "" && strings.Trim(day, "0123456789") == "" {
+		d, err := mustParseInt(day)
+		switch {
+		case err != nil:
+			return 0, true, err
+		case d < r.min:
+			return 0, true, parseError("beginning of range (%d) below minimum (%d): %s", d, r.min, expr)
+		case d > r.max:
+			return 0, true, parseError("end of range (%d) above maximum (%d): %s", d, r.max, expr)
+		}
+		return 1 << (weekdayDomShift + d), true, nil
+	}
+	return 0, false, nil
+}
+
+// getNthWeekday returns the bits of an nth weekday, such as "MON#2" or "1#2",
+// and true, if the expression is one.
+func getNthWeekday(expr string, r bounds) (uint64, bool, error) {
+	i := strings.Index(expr, "#")
+	if i < 0 {
+		return 0, false, nil
+	}
+	day, err := parseIntOrName(expr[:i], r.names)
+	if err != nil {
+		return 0, true, err
+	}
+	switch {
+	case day < r.min:
+		return 0, true, parseError("beginning of range (%d) below minimum (%d): %s", day, r.min, expr)
+	case day > r.max:
+		return 0, true, parseError("end of range (%d) above maximum (%d): %s", day, r.max, expr)
+	}
+	n, err := mustParseInt(expr[i+1:])
+	if err != nil {
+		return 0, true, err
+	}
+	if n < 1 || n > 5 {
+		return 0, true, parseError("nth weekday (%d) outside 1 to 5: %s", n, expr)
+	}
+	return 1 << (nthDowShift + (n-1)*7 + day), true, nil
+}
+
+// modifierDomMatches reports whether the day of t matches a Quartz modifier
+// of the schedule's day of month.
+func (s *SpecSchedule) modifierDomMatches(t time.Time) bool {
+	if s.Dom&lastDomBit != 0 && t.Day() == daysIn(t) {
+		return true
+	}
+	if s.Dom&weekdayDomBits == 0 {
+		return false
+	}
+	for d := 1; d <= daysIn(t); d++ {
+		if s.Dom&(1<<uint(weekdayDomShift+d)) != 0 && nearestWeekday(t, d) == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the weekday nearest day d of the month of t, as
+// Quartz has it: the Friday before a Saturday and the Monday after a Sunday,
+// without leaving the month, so that a Saturday the 1st gives Monday the 3rd
+// and a Sunday the last gives the Friday before.
+func nearestWeekday(t time.Time, d int) int {
+	switch time.Date(t.Year(), t.Month(), d, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if d == 1 {
+			return 3
+		}
+		return d - 1
+	case time.Sunday:
+		if d == daysIn(t) {
+			return d - 2
+		}
+		return d + 1
+	}
+	return d
+}
+
+// modifierDowMatches reports whether the day of t matches a Quartz modifier
+// of the schedule's day of week.
+func (s *SpecSchedule) modifierDowMatches(t time.Time) bool {
+	if s.Dow&nthDowBits == 0 {
+		return false
+	}
+	n := (t.Day()-1)/7 + 1
+	return s.Dow&(1<<uint(nthDowShift+(n-1)*7+int(t.Weekday()))) != 0
+}
+
+// domModifiers returns the Quartz modifiers of the bits of a day of month,
+// as getModifier reads them.
+func domModifiers(bits uint64) []string {
+	var modifiers []string
+	for d := 1; d <= 31; d++ {
+		if bits&(1<<uint(weekdayDomShift+d)) != 0 {
+			modifiers = append(modifiers, strconv.Itoa(d)+"W")
+		}
+	}
+	if bits&lastDomBit != 0 {
+		modifiers = append(modifiers, "L")
+	}
+	return modifiers
+}
+
+// dowModifiers returns the Quartz modifiers of the bits of a day of week, as
+// getModifier reads them.
+func dowModifiers(bits uint64) []string {
+	var modifiers []string
+	for n := 1; n <= 5; n++ {
+		for w := 0; w <= 6; w++ {
+			if bits&(1<<uint(nthDowShift+(n-1)*7+w)) != 0 {
+				modifiers = append(modifiers, strconv.Itoa(w)+"#"+strconv.Itoa(n))
+			}
+		}
+	}
+	return modifiers
+}
@@ -0,0 +1,149 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastDayOfMonth(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want []string
+	}{
+		{"0 0 L * *", "2024-01-15T00:00:00Z", []string{
+			"2024-01-31T00:00:00Z", "2024-02-29T00:00:00Z", "2024-03-31T00:00:00Z", "2024-04-30T00:00:00Z",
+		}},
+		{"0 0 L 2 *", "2023-01-01T00:00:00Z", []string{
+			"2023-02-28T00:00:00Z", "2024-02-29T00:00:00Z", "2025-02-28T00:00:00Z",
+		}},
+		{"30 12 1,15,L * *", "2024-04-10T00:00:00Z", []string{
+			"2024-04-15T12:30:00Z", "2024-04-30T12:30:00Z", "2024-05-01T12:30:00Z",
+		}},
+	}
+	for _, tt := range tests {
+		checkNexts(t, tt.spec, tt.from, tt.want)
+	}
+}
+
+func TestLastDayOfMonthErrors(t *testing.T) {
+	for _, spec := range []string{
+		"0 0 L/2 * *",
+		"0 0 1-L * *",
+		"0 L * * *",
+		"0 0 * L *",
+		"0 0 * * L",
+	} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestNearestWeekday(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want []string
+	}{
+		// 2024-06-15 is a Saturday, 2024-09-15 a Sunday, 2024-07-15 a Monday.
+		{"0 9 15W * *", "2024-06-01T00:00:00Z", []string{
+			"2024-06-14T09:00:00Z", "2024-07-15T09:00:00Z", "2024-08-15T09:00:00Z", "2024-09-16T09:00:00Z",
+		}},
+		// 2024-06-01 is a Saturday, so the nearest weekday in June is the 3rd.
+		{"0 9 1W 6 *", "2024-05-01T00:00:00Z", []string{"2024-06-03T09:00:00Z"}},
+		// 2024-03-31 is a Sunday, so the nearest weekday in March is the 29th.
+		{"0 9 31W 3 *", "2024-03-01T00:00:00Z", []string{"2024-03-29T09:00:00Z"}},
+		// Months without a 31st are skipped.
+		{"0 9 31W * *", "2024-04-01T00:00:00Z", []string{"2024-05-31T09:00:00Z", "2024-07-31T09:00:00Z"}},
+		{"0 9 1,15W * *", "2024-06-02T00:00:00Z", []string{
+			"2024-06-14T09:00:00Z", "2024-07-01T09:00:00Z", "2024-07-15T09:00:00Z",
+		}},
+	}
+	for _, tt := range tests {
+		checkNexts(t, tt.spec, tt.from, tt.want)
+	}
+}
+
+func TestNearestWeekdayErrors(t *testing.T) {
+	for _, spec := range []string{
+		"0 0 W * *",
+		"0 0 0W * *",
+		"0 0 32W * *",
+		"0 0 1-15W * *",
+		"0 0 15W/2 * *",
+		"0 15W * * *",
+	} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+// checkNexts checks the activation times of the standard spec after from.
+func checkNexts(t *testing.T, spec, from string, want []string) {
+	t.Helper()
+	s, err := ParseStandard(spec)
+	if err != nil {
+		t.Fatalf("%s: %v", spec, err)
+	}
+	next, _ := time.Parse(time.RFC3339, from)
+	for _, w := range want {
+		next = s.Next(next)
+		if got := next.UTC().Format(time.RFC3339); got != w {
+			t.Errorf("%s: got %s, want %s", spec, got, w)
+		}
+	}
+}
+
+func TestNthWeekday(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want []string
+	}{
+		{"0 9 * * MON#2", "2024-01-01T00:00:00Z", []string{
+			"2024-01-08T09:00:00Z", "2024-02-12T09:00:00Z", "2024-03-11T09:00:00Z",
+		}},
+		// Only some months have a fifth Friday.
+		{"0 9 * * 5#5", "2024-01-01T00:00:00Z", []string{
+			"2024-03-29T09:00:00Z", "2024-05-31T09:00:00Z", "2024-08-30T09:00:00Z",
+		}},
+		{"0 9 * * SUN#1,SUN#3", "2024-09-01T00:00:00Z", []string{
+			"2024-09-01T09:00:00Z", "2024-09-15T09:00:00Z", "2024-10-06T09:00:00Z",
+		}},
+		{"0 9 * * MON#1,FRI", "2024-09-01T00:00:00Z", []string{
+			"2024-09-02T09:00:00Z", "2024-09-06T09:00:00Z", "2024-09-13T09:00:00Z",
+		}},
+	}
+	for _, tt := range tests {
+		checkNexts(t, tt.spec, tt.from, tt.want)
+	}
+
+	// With seconds, as Quartz writes it.
+	s, err := secondParser.Parse("0 0 9 * * MON#2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := s.Next(from), time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNthWeekdayErrors(t *testing.T) {
+	for _, spec := range []string{
+		"0 0 * * MON#0",
+		"0 0 * * MON#6",
+		"0 0 * * 7#1",
+		"0 0 * * MON#",
+		"0 0 * * #2",
+		"0 0 * * MON#1-3",
+		"0 0 * * MON-FRI#2",
+		"0 0 1#2 * *",
+	} {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
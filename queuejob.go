@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Queue is a message queue, such as an SQS queue, that a QueueJob sends
+// messages to.
+type Queue interface {
+	Enqueue(ctx context.Context, body []byte) error
+}
+
+// QueueJob is a Job that enqueues a message each time it runs, instead of
+// doing any work in-process. It turns the Cron into a pure trigger source for
+// consumers of the queue, such as serverless functions.
+type QueueJob struct {
+	Queue Queue
+
+	// Body is the message to enqueue. If nil, the message is the JSON
+	// encoding of a Dispatch describing the run.
+	Body []byte
+}
+
+// NewQueueJob returns a QueueJob that enqueues a Dispatch for every run.
+func NewQueueJob(q Queue) *QueueJob {
+	return &QueueJob{Queue: q}
+}
+
+// Run enqueues the message, discarding any error.
+func (j *QueueJob) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext enqueues the message and returns the queue's error, if any.
+func (j *QueueJob) RunContext(ctx context.Context) error {
+	body := j.Body
+	if body == nil {
+		var d Dispatch
+		if ri, ok := runFromContext(ctx); ok {
+			d = Dispatch{Entry: ri.entry.ID, Name: ri.entry.Name, Scheduled: ri.scheduled}
+		}
+		var err error
+		if body, err = json.Marshal(d); err != nil {
+			return err
+		}
+	}
+	return j.Queue.Enqueue(ctx, body)
+}
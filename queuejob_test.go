@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type queueFunc func(ctx context.Context, body []byte) error
+
+func (f queueFunc) Enqueue(ctx context.Context, body []byte) error { return f(ctx, body) }
+
+func TestQueueJob(t *testing.T) {
+	var sent [][]byte
+	q := queueFunc(func(ctx context.Context, body []byte) error {
+		sent = append(sent, body)
+		return nil
+	})
+
+	scheduled := time.Date(2020, 8, 28, 10, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), runKey, &runInfo{
+		entry:     Entry{ID: 7, Name: "sync"},
+		scheduled: scheduled,
+	})
+	if err := NewQueueJob(q).RunContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	(&QueueJob{Queue: q, Body: []byte("hello")}).Run()
+
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(sent))
+	}
+	var d Dispatch
+	if err := json.Unmarshal(sent[0], &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Entry != 7 || d.Name != "sync" || !d.Scheduled.Equal(scheduled) {
+		t.Errorf("unexpected message: %+v", d)
+	}
+	if string(sent[1]) != "hello" {
+		t.Errorf("expected the fixed body, got %q", sent[1])
+	}
+}
+
+func TestQueueJobError(t *testing.T) {
+	boom := errors.New("boom")
+	j := NewQueueJob(queueFunc(func(context.Context, []byte) error { return boom }))
+	if err := j.RunContext(context.Background()); err != boom {
+		t.Errorf("expected the queue's error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrorReporter sends errors to an error tracking service such as Sentry,
+// annotated with tags.
+//
+// For example, with github.com/getsentry/sentry-go:
+//
+//	type sentryReporter struct{}
+//
+//	func (sentryReporter) CaptureError(ctx context.Context, err error, tags map[string]string) {
+//		hub := sentry.CurrentHub().Clone()
+//		hub.ConfigureScope(func(s *sentry.Scope) { s.SetTags(tags) })
+//		hub.CaptureException(err)
+//	}
+type ErrorReporter interface {
+	CaptureError(ctx context.Context, err error, tags map[string]string)
+}
+
+// ReportErrors reports errors returned by the wrapped job, and panics raised
+// by it, to r. Each report is tagged with the entry's ID, name and spec and
+// the run's ID and scheduled time. Panics are re-raised after being reported,
+// so ReportErrors should be installed inside Recover.
+func ReportErrors(r ErrorReporter) JobWrapper {
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			defer func() {
+				if rec := recover(); rec != nil {
+					err, ok := rec.(error)
+					if !ok {
+						err = fmt.Errorf("%v", rec)
+					}
+					r.CaptureError(ctx, err, reportTags(ctx, "panic"))
+					panic(rec)
+				}
+			}()
+			err := RunJob(ctx, j)
+			if err != nil {
+				r.CaptureError(ctx, err, reportTags(ctx, "error"))
+			}
+			return err
+		})
+	}
+}
+
+// reportTags returns the tags describing the run carried by ctx.
+func reportTags(ctx context.Context, kind string) map[string]string {
+	tags := map[string]string{"cron.failure": kind}
+	if ri, ok := runFromContext(ctx); ok {
+		tags["cron.run"] = ri.id
+		tags["cron.entry"] = strconv.Itoa(int(ri.entry.ID))
+		tags["cron.name"] = ri.entry.Name
+		tags["cron.spec"] = ri.entry.Spec
+		tags["cron.scheduled"] = ri.scheduled.Format(time.RFC3339)
+	}
+	return tags
+}
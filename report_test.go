@@ -0,0 +1,57 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type reporterFunc func(context.Context, error, map[string]string)
+
+func (f reporterFunc) CaptureError(ctx context.Context, err error, tags map[string]string) {
+	f(ctx, err, tags)
+}
+
+func TestReportErrors(t *testing.T) {
+	var reports []map[string]string
+	r := reporterFunc(func(ctx context.Context, err error, tags map[string]string) {
+		tags["error"] = err.Error()
+		reports = append(reports, tags)
+	})
+	scheduled := time.Date(2020, 8, 28, 10, 0, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), runKey, &runInfo{
+		entry:     Entry{ID: 2, Name: "sync", Spec: "@hourly"},
+		scheduled: scheduled,
+	})
+
+	failing := NewChain(ReportErrors(r)).Then(FuncContextJob(func(context.Context) error {
+		return errors.New("boom")
+	}))
+	RunJob(ctx, failing)
+
+	panicking := NewChain(Recover(DiscardLogger), ReportErrors(r)).Then(FuncJob(func() {
+		panic("YOLO")
+	}))
+	RunJob(ctx, panicking)
+
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %v", reports)
+	}
+	want := map[string]string{
+		"cron.failure":   "error",
+		"cron.entry":     "2",
+		"cron.name":      "sync",
+		"cron.spec":      "@hourly",
+		"cron.scheduled": "2020-08-28T10:00:00Z",
+		"error":          "boom",
+	}
+	for k, v := range want {
+		if reports[0][k] != v {
+			t.Errorf("expected %s=%q, got %q", k, v, reports[0][k])
+		}
+	}
+	if reports[1]["cron.failure"] != "panic" || reports[1]["error"] != "YOLO" {
+		t.Errorf("unexpected panic report: %v", reports[1])
+	}
+}
@@ -0,0 +1,136 @@
+package cron
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// activeRuns tracks the runs that are currently executing.
+type activeRuns struct {
+	mu   sync.Mutex
+	runs map[string]*runInfo
+}
+
+func (ar *activeRuns) add(ri *runInfo) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	if ar.runs == nil {
+		ar.runs = make(map[string]*runInfo)
+	}
+	ar.runs[ri.id] = ri
+}
+
+func (ar *activeRuns) remove(ri *runInfo) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	delete(ar.runs, ri.id)
+}
+
+// Execution describes a run that is currently executing.
+type Execution struct {
+	// Entry and Name identify the entry being run.
+	Entry EntryID
+	Name  string
+
+	// RunID identifies the run, as reported by RunIDFromContext.
+	RunID string
+
+	// Scheduled is the time the run was scheduled for.
+	Scheduled time.Time
+
+	// Start is when the run started, and Elapsed how long it has been running.
+	Start   time.Time
+	Elapsed time.Duration
+
+	// Stuck is whether the run has been marked stuck, see WithHeartbeatTimeout
+	// and WithStuckDetection.
+	Stuck bool
+}
+
+// Running returns the runs that are currently executing, oldest first.
+func (c *Cron) Running() []Execution {
+	now := c.now()
+	c.active.mu.Lock()
+	executions := make([]Execution, 0, len(c.active.runs))
+	for _, ri := range c.active.runs {
+		executions = append(executions, Execution{
+			Entry:     ri.entry.ID,
+			Name:      ri.entry.Name,
+			RunID:     ri.id,
+			Scheduled: ri.scheduled,
+			Start:     ri.start,
+			Elapsed:   now.Sub(ri.start),
+			Stuck:     ri.isStuck(),
+		})
+	}
+	c.active.mu.Unlock()
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].Start.Before(executions[j].Start)
+	})
+	return executions
+}
+
+// CancelRun cancels the context of the executing or pending run with the
+// given ID, as reported by RunIDFromContext. It returns false if no such run
+// is executing or pending. The job must observe its context for the
+// cancellation to stop it.
+func (c *Cron) CancelRun(runID string) bool {
+	c.active.mu.Lock()
+	ri, ok := c.active.runs[runID]
+	c.active.mu.Unlock()
+	if !ok {
+		c.pending.mu.Lock()
+		var pr pendingRun
+		pr, ok = c.pending.runs[runID]
+		ri = pr.ri
+		c.pending.mu.Unlock()
+	}
+	if ok {
+		c.logger.Info("cancel", "entry", ri.entry.ID, "run", runID)
+		ri.cancel()
+	}
+	return ok
+}
+
+// CancelEntryRuns cancels the contexts of all executing runs of the given
+// entry, and returns how many there were.
+func (c *Cron) CancelEntryRuns(id EntryID) int {
+	c.active.mu.Lock()
+	var runs []*runInfo
+	for _, ri := range c.active.runs {
+		if ri.entry.ID == id {
+			runs = append(runs, ri)
+		}
+	}
+	c.active.mu.Unlock()
+	for _, ri := range runs {
+		c.logger.Info("cancel", "entry", id, "run", ri.id)
+		ri.cancel()
+	}
+	return len(runs)
+}
+
+// jobGroup is a sync.WaitGroup that also counts its members, so that the
+// Cron can report when it is idle.
+type jobGroup struct {
+	sync.WaitGroup
+	n int32 // accessed atomically
+}
+
+func (g *jobGroup) Add(delta int) {
+	atomic.AddInt32(&g.n, int32(delta))
+	g.WaitGroup.Add(delta)
+}
+
+func (g *jobGroup) Done() {
+	g.Add(-1)
+}
+
+// Idle reports whether the Cron has no jobs running, waiting to run or being
+// dispatched. Together with a fake Clock, it lets tests wait for the effects
+// of advancing time.
+func (c *Cron) Idle() bool {
+	return atomic.LoadInt32(&c.jobWaiter.n) == 0
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// blockingEntry returns an entry whose runs report their IDs on started and
+// block until cancelled, reporting the context's error on done.
+func blockingEntry(id EntryID, started chan<- string, done chan<- error) Entry {
+	return Entry{ID: id, WrappedJob: FuncContextJob(func(ctx context.Context) error {
+		runID, _ := RunIDFromContext(ctx)
+		started <- runID
+		<-ctx.Done()
+		done <- ctx.Err()
+		return ctx.Err()
+	})}
+}
+
+func TestCancelRun(t *testing.T) {
+	c := New()
+	started, done := make(chan string, 1), make(chan error, 1)
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	runID := <-started
+
+	if c.CancelRun("unknown") {
+		t.Error("expected an unknown run not to be found")
+	}
+	if !c.CancelRun(runID) {
+		t.Fatal("expected the run to be found")
+	}
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected cancellation, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the run to be cancelled")
+	}
+}
+
+func TestCancelEntryRuns(t *testing.T) {
+	c := New()
+	started, done := make(chan string, 3), make(chan error, 3)
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	go c.runEntry(blockingEntry(2, started, done), time.Now())
+	for i := 0; i < 3; i++ {
+		<-started
+	}
+
+	if n := c.CancelEntryRuns(1); n != 2 {
+		t.Errorf("expected 2 runs cancelled, got %d", n)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected the entry's runs to be cancelled")
+		}
+	}
+	c.CancelEntryRuns(2)
+	<-done
+}
+
+func TestRunning(t *testing.T) {
+	c := New()
+	if r := c.Running(); len(r) != 0 {
+		t.Fatalf("expected nothing running, got %v", r)
+	}
+	started, done := make(chan string, 2), make(chan error, 2)
+	go c.runEntry(blockingEntry(1, started, done), time.Now())
+	first := <-started
+	time.Sleep(5 * time.Millisecond)
+	e := blockingEntry(2, started, done)
+	e.Name = "sync"
+	go c.runEntry(e, time.Now())
+	second := <-started
+
+	r := c.Running()
+	if len(r) != 2 {
+		t.Fatalf("expected 2 runs, got %v", r)
+	}
+	if r[0].RunID != first || r[0].Entry != 1 || r[1].RunID != second || r[1].Name != "sync" {
+		t.Errorf("unexpected executions: %+v", r)
+	}
+	if r[0].Elapsed < 5*time.Millisecond || r[0].Start.After(r[1].Start) || r[0].Stuck {
+		t.Errorf("unexpected timing: %+v", r[0])
+	}
+
+	c.CancelEntryRuns(1)
+	c.CancelEntryRuns(2)
+	<-done
+	<-done
+	for deadline := time.Now().Add(time.Second); len(c.Running()) != 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected nothing running, got %v", c.Running())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
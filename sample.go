@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// WithProbability runs only a fraction p of the entry's firings, chosen at
+// random, for sampling jobs such as randomized audits or canary tasks. The
+// choices follow from seed, so that a test, or a fleet of processes sharing
+// the seed, makes the same ones. Firings not chosen are skipped and logged.
+func WithProbability(p float64, seed int64) EntryOption {
+	return func(e *Entry) {
+		e.Sampler = NewSampler(p, seed)
+	}
+}
+
+// Sampler chooses which firings of an entry run. It is safe for concurrent
+// use.
+type Sampler struct {
+	p   float64
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSampler returns a Sampler that chooses a fraction p of firings, in the
+// order given by seed. A p of 0 or less chooses none, and 1 or more chooses
+// all.
+func NewSampler(p float64, seed int64) *Sampler {
+	return &Sampler{p: p, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Sample reports whether the next firing runs.
+func (s *Sampler) Sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64() < s.p
+}
+
+// Probability returns the fraction of firings the sampler chooses.
+func (s *Sampler) Probability() float64 {
+	return s.p
+}
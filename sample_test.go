@@ -0,0 +1,35 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithProbability(t *testing.T) {
+	c := New()
+	var runs int
+	id, _ := c.AddFunc("@every 1h", func() { runs++ }, WithProbability(0.25, 1))
+	for i := 0; i < 1000; i++ {
+		c.runEntry(c.Entry(id), time.Now())
+	}
+	if runs < 200 || runs > 300 {
+		t.Errorf("expected about a quarter of 1000 firings to run, got %d", runs)
+	}
+
+	// The same seed makes the same choices.
+	a, b := NewSampler(0.5, 42), NewSampler(0.5, 42)
+	for i := 0; i < 100; i++ {
+		if a.Sample() != b.Sample() {
+			t.Fatalf("expected samplers with the same seed to agree, differed at %d", i)
+		}
+	}
+}
+
+func TestSamplerBounds(t *testing.T) {
+	never, always := NewSampler(0, 1), NewSampler(1, 1)
+	for i := 0; i < 100; i++ {
+		if never.Sample() || !always.Sample() {
+			t.Fatal("expected probabilities of 0 and 1 to choose none and all")
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package cron
+
+// Sharder splits entries between a group of processes running the same
+// schedule, so that each firing is executed by the one process that owns its
+// entry. Ownership is checked each time an entry is due, so changes in the
+// group's membership take effect at the next firing.
+//
+// See the cronshard package for a consistent-hash ring over entry names.
+type Sharder interface {
+	Owns(e Entry) bool
+}
+
+// WithSharder only fires the entries that the given Sharder reports this
+// process owns.
+func WithSharder(s Sharder) Option {
+	return func(c *Cron) {
+		c.sharder = s
+	}
+}
+
+// owns reports whether this Cron's shard includes the entry.
+func (c *Cron) owns(e *Entry) bool {
+	return c.sharder == nil || c.sharder.Owns(*e)
+}
@@ -0,0 +1,26 @@
+package cron
+
+import (
+	"testing"
+)
+
+type sharderFunc func(e Entry) bool
+
+func (f sharderFunc) Owns(e Entry) bool { return f(e) }
+
+func TestWithSharder(t *testing.T) {
+	cron := New(WithSharder(sharderFunc(func(e Entry) bool {
+		return e.Name == "mine"
+	})))
+	mine, _ := cron.AddFunc("@every 1h", func() {}, WithName("mine"))
+	theirs, _ := cron.AddFunc("@every 1h", func() {}, WithName("theirs"))
+	if e := cron.Entry(mine); !cron.owns(&e) {
+		t.Error("expected the entry to be owned")
+	}
+	if e := cron.Entry(theirs); cron.owns(&e) {
+		t.Error("expected the entry not to be owned")
+	}
+	if !New().owns(&Entry{}) {
+		t.Error("expected every entry owned without a sharder")
+	}
+}
@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// errLocked is returned by lockFile when another process holds the lock.
+var errLocked = errors.New("lock is held by another process")
+
+// WithSingleton ensures that only one process on this host fires jobs at a
+// time, by holding an OS-level advisory lock on the file at path. A Cron that
+// cannot take the lock keeps computing its schedule but skips every firing
+// until the lock is released, e.g. because the process holding it exited.
+//
+// This is intended for multiple copies of the same binary running on one host,
+// for example while a deploy overlaps the old and new processes.
+func WithSingleton(path string) Option {
+	return func(c *Cron) {
+		c.singleton = &fileLock{path: path}
+	}
+}
+
+// fileLock is an advisory lock held on a file for as long as the file is open.
+type fileLock struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// tryLock takes the lock if it is not already held, without blocking.
+// It returns false if another process holds the lock.
+func (l *fileLock) tryLock() (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f != nil {
+		return true, nil
+	}
+	f, err := lockFile(l.path)
+	if err == errLocked {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	l.f = f
+	return true, nil
+}
+
+// held reports whether the lock is held, without taking it.
+func (l *fileLock) held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f != nil
+}
+
+// unlock releases the lock, if held.
+func (l *fileLock) unlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f != nil {
+		l.f.Close()
+		l.f = nil
+	}
+}
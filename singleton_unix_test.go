@@ -0,0 +1,94 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package cron
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cron.lock")
+	l1, l2 := &fileLock{path: path}, &fileLock{path: path}
+
+	if ok, err := l1.tryLock(); !ok || err != nil {
+		t.Fatalf("expected first lock to succeed, got %v, %v", ok, err)
+	}
+	if ok, err := l2.tryLock(); ok || err != nil {
+		t.Fatalf("expected second lock to fail, got %v, %v", ok, err)
+	}
+	l1.unlock()
+	if ok, err := l2.tryLock(); !ok || err != nil {
+		t.Fatalf("expected lock after release to succeed, got %v, %v", ok, err)
+	}
+	l2.unlock()
+}
+
+// Only one of two Crons sharing a lock file runs its jobs.
+func TestWithSingleton(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cron.lock")
+	var runs1, runs2 int64
+	c1 := New(WithParser(secondParser), WithSingleton(path))
+	c2 := New(WithParser(secondParser), WithSingleton(path))
+	c1.AddFunc("* * * * * ?", func() { atomic.AddInt64(&runs1, 1) })
+	c2.AddFunc("* * * * * ?", func() { atomic.AddInt64(&runs2, 1) })
+
+	c1.Start()
+	time.Sleep(OneSecond)
+	c2.Start()
+	time.Sleep(OneSecond)
+	c1.Stop()
+	c2.Stop()
+
+	if atomic.LoadInt64(&runs1) == 0 {
+		t.Error("expected the lock holder to run its job")
+	}
+	if n := atomic.LoadInt64(&runs2); n != 0 {
+		t.Errorf("expected the second cron to be locked out, ran %d times", n)
+	}
+}
+
+// A stopped Cron refuses to trigger entries rather than take its singleton
+// lock, which only the scheduler releases; a running one triggers them from
+// any goroutine.
+func TestTriggerSingleton(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cron.lock")
+	c := New(WithSingleton(path))
+	id, _ := c.AddFunc("@yearly", func() {})
+	if err := c.Trigger(id); err != ErrNotFiring {
+		t.Errorf("expected a stopped cron to refuse the trigger, got %v", err)
+	}
+	if c.singleton.held() {
+		t.Error("expected the trigger not to take the lock")
+	}
+
+	c.Start()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Trigger(id); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	<-c.Stop().Done()
+	for deadline := time.Now().Add(OneSecond); c.singleton.held(); {
+		if time.Now().After(deadline) {
+			t.Fatal("expected Stop to release the lock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	other := &fileLock{path: path}
+	if ok, err := other.tryLock(); !ok || err != nil {
+		t.Fatalf("expected the lock to be free after Stop, got %v, %v", ok, err)
+	}
+	other.unlock()
+}
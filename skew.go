@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"runtime"
+	"time"
+)
+
+// WithSkewCompensation makes the scheduler wake lead early for each firing
+// and then spin until the exact instant, so that jobs start within
+// microseconds of their scheduled time rather than after the timer's
+// latency. It costs up to lead of busy CPU per firing, so lead should be
+// small, such as a millisecond, and the Cron should run only
+// latency-sensitive schedules. It is meant for the real clock.
+//
+// Without it, the skew between the scheduled and actual start of runs is
+// still measured, and reported by Stats.
+func WithSkewCompensation(lead time.Duration) Option {
+	return func(c *Cron) {
+		c.lead = lead
+	}
+}
+
+// spinUntil busy-waits from now until target, for no longer than the lead,
+// and returns the time it stopped.
+func (c *Cron) spinUntil(target, now time.Time) time.Time {
+	limit := time.Now().Add(c.lead)
+	for now.Before(target) && time.Now().Before(limit) {
+		runtime.Gosched()
+		now = c.now()
+	}
+	return now
+}
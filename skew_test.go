@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkewStats(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)))
+	id, _ := c.AddFunc("@hourly", func() {})
+	entry := c.Entry(id)
+	for i := 1; i <= 4; i++ {
+		c.runEntry(entry, now.Add(-time.Duration(i)*time.Millisecond))
+	}
+	st := c.Stats()[0]
+	if st.SkewP50 != 2*time.Millisecond || st.SkewP99 != 4*time.Millisecond {
+		t.Errorf("expected skews of 2ms and 4ms, got %v and %v", st.SkewP50, st.SkewP99)
+	}
+}
+
+func TestSkewCompensation(t *testing.T) {
+	c := New(WithSkewCompensation(50 * time.Millisecond))
+	target := time.Now().Add(5 * time.Millisecond)
+	if now := c.spinUntil(target, time.Now()); now.Before(target) || now.Sub(target) > 5*time.Millisecond {
+		t.Errorf("expected to stop at %v, stopped at %v", target, now)
+	}
+
+	// It never spins longer than the lead.
+	start := time.Now()
+	c.spinUntil(start.Add(time.Hour), start)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("expected to give up after the lead, spun for %v", d)
+	}
+}
+
+func TestSkewCompensationRun(t *testing.T) {
+	started := make(chan time.Time, 1)
+	c := New(WithSeconds(), WithSkewCompensation(20*time.Millisecond))
+	c.AddFunc("* * * * * *", func() {
+		select {
+		case started <- time.Now():
+		default:
+		}
+	})
+	c.Start()
+	defer c.Stop()
+	select {
+	case at := <-started:
+		if at.Nanosecond() > int(50*time.Millisecond) {
+			t.Errorf("expected a start just after the second, got %v", at)
+		}
+	case <-time.After(OneSecond):
+		t.Error("expected a run")
+	}
+}
@@ -0,0 +1,33 @@
+package cron
+
+import "time"
+
+// slowMinSamples is the number of completed runs needed before an entry's
+// runs are checked against its history.
+const slowMinSamples = 10
+
+// WithStuckDetection marks a run stuck once it has been running for longer
+// than factor times the 95th percentile duration of its entry's recent runs,
+// catching hangs such as deadlocked database transactions. Stuck runs are
+// logged and reported to listeners as EventStuck, and are cancelled if their
+// entry was added with WithHeartbeatTimeout(..., true).
+//
+// Entries are only checked once they have completed 10 runs.
+func WithStuckDetection(factor float64) Option {
+	return func(c *Cron) {
+		c.slowFactor = factor
+	}
+}
+
+// slowLimit returns how long a run of the entry may take before it is
+// considered stuck, or false if runs of the entry are not checked.
+func (c *Cron) slowLimit(id EntryID) (time.Duration, bool) {
+	if c.slowFactor <= 0 {
+		return 0, false
+	}
+	p95, ok := c.stats.percentile(id, 0.95, slowMinSamples)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(float64(p95) * c.slowFactor), true
+}
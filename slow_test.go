@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStuckDetection(t *testing.T) {
+	stuck := make(chan Event, 1)
+	c := New(WithStuckDetection(3), WithEventListener(func(ev Event) {
+		if ev.Type == EventStuck {
+			stuck <- ev
+		}
+	}))
+
+	delay := 5 * time.Millisecond
+	e := Entry{ID: 1, Name: "sync", WrappedJob: FuncJob(func() { time.Sleep(delay) })}
+	for i := 0; i < slowMinSamples; i++ {
+		c.runEntry(e, time.Now())
+	}
+	select {
+	case ev := <-stuck:
+		t.Fatalf("unexpected stuck event while building history: %+v", ev)
+	default:
+	}
+
+	delay = 100 * time.Millisecond
+	c.runEntry(e, time.Now())
+	select {
+	case ev := <-stuck:
+		if ev.Name != "sync" || ev.Duration < 15*time.Millisecond {
+			t.Errorf("unexpected stuck event: %+v", ev)
+		}
+	default:
+		t.Error("expected the slow run to be reported stuck")
+	}
+}
+
+func TestStuckDetectionDisabled(t *testing.T) {
+	c := New()
+	for i := 0; i < slowMinSamples; i++ {
+		c.stats.add(1, time.Second, nil)
+	}
+	if _, ok := c.slowLimit(1); ok {
+		t.Error("expected no limit without WithStuckDetection")
+	}
+}
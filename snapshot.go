@@ -0,0 +1,140 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Snapshot is the runtime state of a Cron, taken with Cron.Snapshot and put
+// back with Cron.Restore, so that a scheduler can be checkpointed and resumed,
+// or handed over between processes in a blue/green deploy. It is encoded to
+// JSON as it is. Jobs and configuration are not part of it: the Cron that
+// restores it is set up with the same named entries first.
+type Snapshot struct {
+	// Time is when the snapshot was taken.
+	Time    time.Time       `json:"time"`
+	Entries []EntrySnapshot `json:"entries"`
+}
+
+// EntrySnapshot is the state of an entry in a Snapshot.
+type EntrySnapshot struct {
+	ID   EntryID `json:"id"`
+	Name string  `json:"name,omitempty"`
+	Spec string  `json:"spec,omitempty"`
+
+	// Next and Prev are the entry's next and previous run times.
+	Next time.Time `json:"next"`
+	Prev time.Time `json:"prev"`
+
+	// Pending are the scheduled times of the entry's runs that were waiting
+	// to execute, as listed by Pending.
+	Pending []time.Time `json:"pending,omitempty"`
+}
+
+// Snapshot returns the runtime state of the Cron: the activation times of
+// its entries and the runs waiting to execute. Runs that are executing are
+// not included.
+//
+// To hand over to another process, take the snapshot, cancel the pending
+// runs so that they are not run twice, and stop the Cron:
+//
+//	snap := c.Snapshot()
+//	for _, p := range c.Pending() {
+//		c.CancelRun(p.RunID)
+//	}
+//	<-c.Stop().Done()
+//	json.NewEncoder(w).Encode(snap)
+func (c *Cron) Snapshot() Snapshot {
+	pending := make(map[EntryID][]time.Time)
+	for _, p := range c.Pending() {
+		pending[p.Entry] = append(pending[p.Entry], p.Scheduled)
+	}
+	snap := Snapshot{Time: c.now()}
+	for _, e := range c.Entries() {
+		snap.Entries = append(snap.Entries, EntrySnapshot{
+			ID:      e.ID,
+			Name:    e.Name,
+			Spec:    e.Spec,
+			Next:    e.Next,
+			Prev:    e.Prev,
+			Pending: pending[e.ID],
+		})
+	}
+	return snap
+}
+
+// Restore puts back the state of a snapshot, matching its entries to this
+// Cron's by name; entries without names cannot be matched. Each matched
+// entry takes the snapshot's previous and next run times, so that a next run
+// time that passed while the state was being handed over fires at once, and
+// the runs that were pending in the snapshot are started again.
+//
+// If the Cron is not running, the state is put back when it starts. Restore
+// returns an error naming the snapshot's entries that this Cron lacks, after
+// restoring the rest.
+func (c *Cron) Restore(s Snapshot) error {
+	byName := make(map[string]EntrySnapshot)
+	for _, es := range s.Entries {
+		if es.Name != "" {
+			byName[es.Name] = es
+		}
+	}
+	have := make(map[string]bool)
+	for _, e := range c.Entries() {
+		have[e.Name] = true
+	}
+	var missing []string
+	for name := range byName {
+		if !have[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		c.restore(byName, c.now())
+		// Wake the scheduler to sleep until the new earliest time.
+		select {
+		case c.poke <- struct{}{}:
+		default:
+		}
+	} else {
+		c.restored = byName
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("cron: snapshot entries not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// restore puts back the state of the snapshot's entries, by name, and starts
+// their pending runs.
+func (c *Cron) restore(byName map[string]EntrySnapshot, now time.Time) {
+	type run struct {
+		entry     Entry
+		scheduled time.Time
+	}
+	var runs []run
+	c.entries.update(func(entry *Entry) {
+		es, ok := byName[entry.Name]
+		if !ok || entry.Name == "" {
+			return
+		}
+		entry.Prev = es.Prev
+		if !es.Next.IsZero() {
+			entry.Next = es.Next
+		}
+		for _, scheduled := range es.Pending {
+			runs = append(runs, run{*entry, scheduled})
+		}
+		c.logger.Info("restored", "now", now, "entry", entry.ID, "next", entry.Next, "pending", len(es.Pending))
+		c.emit(Event{Type: EventScheduled, Entry: entry.ID, Name: entry.Name, Scheduled: entry.Next, Time: now})
+	})
+	for _, r := range runs {
+		c.startJob(&r.entry, r.scheduled)
+	}
+}
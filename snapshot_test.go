@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	old := New(WithClock(fixedClock(now)), WithLocation(time.UTC), WithConcurrencyLimit(1))
+	release := make(chan struct{})
+	blocker, _ := old.AddFunc("@every 1m", func() { <-release }, WithName("blocker"))
+	report, _ := old.AddFunc("0 9 * * *", func() {}, WithName("report"))
+	old.AddFunc("@hourly", func() {})
+	old.Start()
+	prev := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	old.entries.update(func(e *Entry) {
+		if e.ID == report {
+			e.Prev = prev
+		}
+	})
+	old.Trigger(blocker)
+	for len(old.Running()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	old.Trigger(report)
+	for len(old.Pending()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	snap := old.Snapshot()
+	for _, p := range old.Pending() {
+		old.CancelRun(p.RunID)
+	}
+	close(release)
+	<-old.Stop().Done()
+
+	// The snapshot survives encoding, as when handed to another process.
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	later := now.Add(10 * time.Minute)
+	ran := make(chan time.Time, 1)
+	c := New(WithClock(fixedClock(later)), WithLocation(time.UTC))
+	id, _ := c.AddScheduledFunc("0 9 * * *", func(scheduled time.Time) error {
+		ran <- scheduled
+		return nil
+	}, WithName("report"))
+	if err := c.Restore(decoded); err == nil || err.Error() != "cron: snapshot entries not found: blocker" {
+		t.Errorf("expected an error naming the missing entry, got %v", err)
+	}
+	c.Start()
+	defer c.Stop()
+
+	e := c.Entry(id)
+	if !e.Prev.Equal(prev) || !e.Next.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the entry's times restored, got prev %v and next %v", e.Prev, e.Next)
+	}
+	select {
+	case scheduled := <-ran:
+		if !scheduled.Equal(now) {
+			t.Errorf("expected the pending run scheduled at %v, got %v", now, scheduled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected the pending run to be started")
+	}
+}
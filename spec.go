@@ -56,6 +56,12 @@ const (
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	return s.next(t, MissingDaySkip)
+}
+
+// next is Next, following the policy in months that lack a day of month the
+// schedule names.
+func (s *SpecSchedule) next(t time.Time, missing MissingDayPolicy) time.Time {
 	// General approach
 	//
 	// For Month, Day, Hour, Minute, Second:
@@ -100,7 +106,7 @@ WRAP:
 	// 寻找月份
 	// s.month == [...]
 	// 如果与运算后值为0，则需要增大日期后再次查找直接与运算和不为0
-	for 1<<uint(t.Month())&s.Month == 0 {
+	for !s.monthMatches(t, missing) {
 		// If we have to add a month, reset the other parts to 0.
 		// 如果没有初始化，则将比月份小的时间字段修改为0
 		if !added {
@@ -125,7 +131,7 @@ WRAP:
 	// not exist.  For example: Sao Paulo has DST that transforms midnight on
 	// 11/3 into 1am. Handle that by noticing when the Hour ends up != 0.
 	// 匹配某个月的天数
-	for !dayMatches(s, t) {
+	for !dayMatches(s, t, missing) {
 		if !added {
 			added = true
 			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
@@ -185,16 +191,92 @@ WRAP:
 	return t.In(origLocation)
 }
 
+// Prev returns the latest time the schedule activates before t, or the zero
+// time if it does not within five years.
+func (s *SpecSchedule) Prev(t time.Time) time.Time {
+	return s.prev(t, MissingDaySkip)
+}
+
+// prev is Prev, following the policy in months that lack a day of month the
+// schedule names.
+//
+// It is next in reverse: a field that doesn't match moves back to the last
+// second of the unit before, which is the latest time of all smaller fields,
+// so that no field needs resetting.
+func (s *SpecSchedule) prev(t time.Time, missing MissingDayPolicy) time.Time {
+	origLocation := t.Location()
+	loc := s.Location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	if s.Location != time.Local {
+		t = t.In(s.Location)
+	}
+
+	// Start at the latest possible time (the second before t).
+	t = t.Add(-time.Nanosecond).Truncate(time.Second)
+
+	// If no time is found within five years, return zero.
+	yearLimit := t.Year() - 5
+
+WRAP:
+	if t.Year() < yearLimit {
+		return time.Time{}
+	}
+
+	for !s.monthMatches(t, missing) {
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Second)
+		if t.Month() == time.December {
+			goto WRAP
+		}
+	}
+
+	for !dayMatches(s, t, missing) {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Second)
+		if t.Day() == daysIn(t) {
+			goto WRAP
+		}
+	}
+
+	// The hour and minute move back by what the clock shows of them, rather
+	// than to a time.Date, so that an hour repeated by DST is not skipped.
+	for 1<<uint(t.Hour())&s.Hour == 0 {
+		t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second()+1)*time.Second)
+		if t.Hour() == 23 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.Minute == 0 {
+		t = t.Add(-time.Duration(t.Second()+1) * time.Second)
+		if t.Minute() == 59 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.Second == 0 {
+		t = t.Add(-time.Second)
+		if t.Second() == 59 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLocation)
+}
+
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 // 匹配星期或者日期
-func dayMatches(s *SpecSchedule, t time.Time) bool {
+func dayMatches(s *SpecSchedule, t time.Time, missing MissingDayPolicy) bool {
+	// Under MissingDayNext, the month may match only for a missing day of
+	// the month before, in which case nothing else of it matches.
+	inMonth := 1<<uint(t.Month())&s.Month > 0
 	var (
-		domMatch bool = 1<<uint(t.Day())&s.Dom > 0
-		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0
+		domMatch bool = inMonth && (1<<uint(t.Day())&s.Dom > 0 || s.modifierDomMatches(t)) || s.missingDayMatches(t, missing)
+		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0 || s.modifierDowMatches(t)
 	)
 	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
 		return domMatch && dowMatch
 	}
-	return domMatch || dowMatch
+	return domMatch || inMonth && dowMatch
 }
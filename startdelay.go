@@ -0,0 +1,33 @@
+package cron
+
+import "time"
+
+// WithStartDelay keeps the entry from firing until d has passed since the
+// Cron started, or since the entry was added if the Cron was already running,
+// so that a deployment does not set off a stampede of first runs. The entry's
+// first activation is the first of its schedule after the delay; later ones
+// follow the schedule as usual.
+func WithStartDelay(d time.Duration) EntryOption {
+	return func(e *Entry) {
+		e.StartDelay = d
+	}
+}
+
+// firstNext returns the first activation time of the entry, when it is
+// scheduled at now.
+func firstNext(e *Entry, now time.Time) time.Time {
+	if e.StartDelay > 0 {
+		return e.Schedule.Next(now.Add(e.StartDelay))
+	}
+	return e.Schedule.Next(now)
+}
+
+// rescheduledNext returns the next activation time of the entry when its
+// schedule or time zone changes at now: its first, keeping its start delay,
+// if it has yet to run.
+func rescheduledNext(e *Entry, now time.Time) time.Time {
+	if e.Prev.IsZero() {
+		return firstNext(e, now)
+	}
+	return e.Schedule.Next(now)
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartDelay(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 55, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	hourly, _ := c.AddFunc("0 * * * *", func() {}, WithStartDelay(10*time.Minute))
+	undelayed, _ := c.AddFunc("0 * * * *", func() {})
+	c.Start()
+	defer c.Stop()
+
+	if next := c.Entry(hourly).Next; !next.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the 09:00 activation skipped, got %v", next)
+	}
+	if next := c.Entry(undelayed).Next; !next.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the undelayed entry at 09:00, got %v", next)
+	}
+
+	added, _ := c.AddFunc("@every 1m", func() {}, WithStartDelay(time.Hour))
+	if next := c.Entry(added).Next; !next.Equal(now.Add(time.Hour + time.Minute)) {
+		t.Errorf("expected the entry added while running delayed from when it was added, got %v", next)
+	}
+}
+
+// Changing the time zone does not let a delayed entry fire early.
+func TestStartDelaySetLocation(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 55, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	hourly, _ := c.AddFunc("30 * * * *", func() {}, WithStartDelay(10*time.Minute))
+	c.Start()
+	defer c.Stop()
+
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skip(err)
+	}
+	// It is 14:25 in Kolkata, so 14:30 there is within the delay.
+	c.SetLocation(kolkata)
+	if next := c.Entry(hourly).Next; !next.Equal(time.Date(2024, 3, 1, 15, 30, 0, 0, kolkata)) {
+		t.Errorf("expected the delay kept in the new time zone, got %v", next)
+	}
+}
+
+// Reloading the time zones does not let a delayed entry fire early.
+func TestStartDelayReloadTimeZones(t *testing.T) {
+	updated := time.FixedZone("Test/Zone", 2*60*60)
+	defer func(f func(string) (*time.Location, error)) { loadLocation = f }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) { return updated, nil }
+
+	now := time.Date(2024, 3, 1, 8, 55, 0, 0, time.UTC)
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	s, _ := standardParser.Parse("0 * * * *")
+	s.(*SpecSchedule).Location = time.FixedZone("Test/Zone", 0)
+	hourly := c.Schedule(s, FuncJob(func() {}), WithStartDelay(10*time.Minute))
+	c.Start()
+	defer c.Stop()
+
+	// It is 10:55 in the updated zone, so 11:00 there is within the delay.
+	if err := c.ReloadTimeZones(); err != nil {
+		t.Fatal(err)
+	}
+	if next := c.Entry(hourly).Next; !next.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, updated)) {
+		t.Errorf("expected the delay kept in the reloaded time zone, got %v", next)
+	}
+}
@@ -0,0 +1,238 @@
+package cron
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// durationSamples is the number of recent runs kept for each entry, unless
+// WithHistorySize gives another.
+const durationSamples = 100
+
+// WithHistorySize sets how many of each entry's recent runs are kept for
+// statistics: the percentiles of Stats, the reports of Drift, and the
+// history WithStuckDetection and WithOverrunWarning compare runs with. The
+// default is 100. More runs give steadier statistics at the cost of memory
+// and of time to compute them.
+func WithHistorySize(n int) Option {
+	return func(c *Cron) {
+		if n > 0 {
+			c.stats.size = n
+		}
+	}
+}
+
+// durationStats holds the most recent run durations of an entry in a ring.
+type durationStats struct {
+	samples []time.Duration
+	next    int
+}
+
+// add adds a sample, keeping the most recent size of them.
+func (s *durationStats) add(d time.Duration, size int) {
+	if len(s.samples) < size {
+		s.samples = append(s.samples, d)
+		return
+	}
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % size
+}
+
+// mean returns the mean of the samples.
+func (s *durationStats) mean() time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range s.samples {
+		total += d
+	}
+	return total / time.Duration(len(s.samples))
+}
+
+// percentile returns the duration below which the fraction p of the samples
+// fall, using the nearest-rank method.
+func (s *durationStats) percentile(p float64) time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(s.samples))
+	copy(sorted, s.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// entryStats holds the run history of an entry.
+type entryStats struct {
+	durations durationStats
+	skews     durationStats
+	starts    startHistory
+	runs      int
+	failures  int
+	overruns  int
+	lastError string
+
+	// intervalRatio is the mean duration over the interval, and overrunning
+	// whether it reached the ratio of WithOverrunWarning.
+	intervalRatio float64
+	overrunning   bool
+}
+
+// runStats tracks the run history of every entry.
+type runStats struct {
+	mu      sync.Mutex
+	entries map[EntryID]*entryStats
+	size    int // runs kept per entry, or 0 for durationSamples
+}
+
+// history returns how many runs are kept per entry.
+func (rs *runStats) history() int {
+	if rs.size > 0 {
+		return rs.size
+	}
+	return durationSamples
+}
+
+// entry returns the stats of the entry, adding them if there are none. The
+// lock must be held.
+func (rs *runStats) entry(id EntryID) *entryStats {
+	if rs.entries == nil {
+		rs.entries = make(map[EntryID]*entryStats)
+	}
+	s, ok := rs.entries[id]
+	if !ok {
+		s = &entryStats{}
+		rs.entries[id] = s
+	}
+	return s
+}
+
+// add records a completed run of the entry, and its error, if it failed.
+func (rs *runStats) add(id EntryID, d time.Duration, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	s := rs.entry(id)
+	s.durations.add(d, rs.history())
+	s.runs++
+	if err != nil {
+		s.failures++
+		s.lastError = err.Error()
+	}
+}
+
+// addStart records that a run of the entry scheduled for scheduled started
+// at start.
+func (rs *runStats) addStart(id EntryID, scheduled, start time.Time) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	s := rs.entry(id)
+	s.skews.add(start.Sub(scheduled), rs.history())
+	s.starts.add(runStart{scheduled, start}, rs.history())
+}
+
+// addOverrun records that the entry fired while runs of it were in flight.
+func (rs *runStats) addOverrun(id EntryID) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.entry(id).overruns++
+}
+
+// percentile returns the entry's duration percentile, or false if fewer than
+// min durations have been recorded.
+func (rs *runStats) percentile(id EntryID, p float64, min int) (time.Duration, bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	s, ok := rs.entries[id]
+	if !ok || len(s.durations.samples) < min {
+		return 0, false
+	}
+	return s.durations.percentile(p), true
+}
+
+// remove forgets the entry's durations.
+func (rs *runStats) remove(id EntryID) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	delete(rs.entries, id)
+}
+
+// EntryStats summarizes an entry's schedule and run history. It is encoded
+// to JSON with durations in nanoseconds.
+type EntryStats struct {
+	Entry EntryID `json:"entry"`
+	Name  string  `json:"name,omitempty"`
+	Spec  string  `json:"spec,omitempty"`
+
+	// Next and Prev are the entry's next and previous run times.
+	Next time.Time `json:"next"`
+	Prev time.Time `json:"prev"`
+
+	// Runs and Failures count the entry's completed runs, and SuccessRate is
+	// the fraction of them that succeeded, or 0 if there were none.
+	Runs        int     `json:"runs"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"success_rate"`
+
+	// Overrun is the entry's Overrun, the depth of its pile-up when it last
+	// fired, and Overruns counts the firings that found runs of it still in
+	// flight.
+	Overrun  int `json:"overrun"`
+	Overruns int `json:"overruns"`
+
+	// LastError is the error of the most recent failed run.
+	LastError string `json:"last_error,omitempty"`
+
+	// The percentiles of the durations of the entry's recent runs.
+	DurationP50 time.Duration `json:"duration_p50"`
+	DurationP95 time.Duration `json:"duration_p95"`
+	DurationP99 time.Duration `json:"duration_p99"`
+
+	// The percentiles of how long after their scheduled times the entry's
+	// recent runs started.
+	SkewP50 time.Duration `json:"skew_p50"`
+	SkewP95 time.Duration `json:"skew_p95"`
+	SkewP99 time.Duration `json:"skew_p99"`
+
+	// IntervalRatio is the mean duration of the entry's recent runs over the
+	// interval of its schedule, as WithOverrunWarning computes it. It is zero
+	// unless the Cron was given that option.
+	IntervalRatio float64 `json:"interval_ratio,omitempty"`
+}
+
+// Stats returns a summary of the schedule and run history of every entry.
+// Only runs since the entry was added to this Cron are counted, and duration
+// and skew percentiles cover the most recent 100 runs, or as many as
+// WithHistorySize gives.
+func (c *Cron) Stats() []EntryStats {
+	entries := c.Entries()
+	stats := make([]EntryStats, len(entries))
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+	for i, e := range entries {
+		st := EntryStats{Entry: e.ID, Name: e.Name, Spec: e.Spec, Next: e.Next, Prev: e.Prev, Overrun: e.Overrun}
+		if s, ok := c.stats.entries[e.ID]; ok {
+			st.Runs, st.Failures, st.LastError = s.runs, s.failures, s.lastError
+			st.Overruns = s.overruns
+			if s.runs > 0 {
+				st.SuccessRate = float64(s.runs-s.failures) / float64(s.runs)
+			}
+			st.DurationP50 = s.durations.percentile(0.50)
+			st.DurationP95 = s.durations.percentile(0.95)
+			st.DurationP99 = s.durations.percentile(0.99)
+			st.SkewP50 = s.skews.percentile(0.50)
+			st.SkewP95 = s.skews.percentile(0.95)
+			st.SkewP99 = s.skews.percentile(0.99)
+			st.IntervalRatio = s.intervalRatio
+		}
+		stats[i] = st
+	}
+	return stats
+}
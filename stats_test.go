@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDurationPercentile(t *testing.T) {
+	var s durationStats
+	for i := 1; i <= 20; i++ {
+		s.add(time.Duration(i)*time.Second, durationSamples)
+	}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Second},
+		{0.5, 10 * time.Second},
+		{0.95, 19 * time.Second},
+		{1, 20 * time.Second},
+	}
+	for _, test := range tests {
+		if got := s.percentile(test.p); got != test.want {
+			t.Errorf("p%v: expected %v, got %v", test.p*100, test.want, got)
+		}
+	}
+}
+
+func TestDurationStatsRing(t *testing.T) {
+	var s durationStats
+	for i := 0; i < durationSamples; i++ {
+		s.add(time.Hour, durationSamples)
+	}
+	for i := 0; i < durationSamples; i++ {
+		s.add(time.Second, durationSamples)
+	}
+	if got := s.percentile(1); got != time.Second {
+		t.Errorf("expected old samples to be replaced, got max %v", got)
+	}
+}
+
+func TestRunStatsMinSamples(t *testing.T) {
+	var rs runStats
+	rs.add(1, time.Second, nil)
+	if _, ok := rs.percentile(1, 0.95, 2); ok {
+		t.Error("expected too few samples")
+	}
+	rs.add(1, time.Second, nil)
+	if d, ok := rs.percentile(1, 0.95, 2); !ok || d != time.Second {
+		t.Errorf("expected 1s, got %v, %v", d, ok)
+	}
+	rs.remove(1)
+	if _, ok := rs.percentile(1, 0.95, 0); ok {
+		t.Error("expected removed entry to have no stats")
+	}
+}
+
+func TestStats(t *testing.T) {
+	c := New()
+	id, _ := c.AddFunc("@hourly", func() {}, WithName("sync"))
+	c.AddFunc("@daily", func() {})
+	for i := 1; i <= 4; i++ {
+		var err error
+		if i == 2 {
+			err = errors.New("boom")
+		}
+		c.stats.add(id, time.Duration(i)*time.Second, err)
+	}
+
+	stats := c.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(stats))
+	}
+	want := EntryStats{
+		Entry:       id,
+		Name:        "sync",
+		Spec:        "@hourly",
+		Runs:        4,
+		Failures:    1,
+		SuccessRate: 0.75,
+		LastError:   "boom",
+		DurationP50: 2 * time.Second,
+		DurationP95: 4 * time.Second,
+		DurationP99: 4 * time.Second,
+	}
+	if stats[0] != want {
+		t.Errorf("expected %+v, got %+v", want, stats[0])
+	}
+	if stats[1].Runs != 0 || stats[1].SuccessRate != 0 {
+		t.Errorf("expected no history, got %+v", stats[1])
+	}
+}
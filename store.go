@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by Store.Get for keys that have no value.
+var ErrNotFound = errors.New("cron: key not found")
+
+// Store is a key-value store that schedulers use to keep state, such as
+// checkpoints of completed runs, that must survive restarts or be shared by
+// a group of processes running the same schedule.
+//
+// NewMemoryStore returns an in-process Store, and the cronraft package
+// replicates one across a cluster. NewEncryptedStore encrypts the values of
+// another.
+type Store interface {
+	// Get returns the value of key, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Put sets the value of key.
+	Put(ctx context.Context, key string, value []byte) error
+
+	// CompareAndSwap sets the value of key to new if its current value is old,
+	// where a nil old means that key must have no value, and reports whether
+	// it did.
+	CompareAndSwap(ctx context.Context, key string, old, new []byte) (bool, error)
+
+	// Delete removes key. Deleting a key with no value is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys with the given prefix, in sorted order.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// MemoryStore is a Store that keeps values in memory.
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string][]byte)}
+}
+
+// Get returns the value of key, or ErrNotFound.
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), v...), nil
+}
+
+// Put sets the value of key.
+func (s *MemoryStore) Put(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// CompareAndSwap sets the value of key to new if its current value is old.
+func (s *MemoryStore) CompareAndSwap(ctx context.Context, key string, old, new []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if ok != (old != nil) || !bytes.Equal(v, old) {
+		return false, nil
+	}
+	s.values[key] = append([]byte(nil), new...)
+	return true, nil
+}
+
+// Delete removes key.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+// List returns the keys with the given prefix, in sorted order.
+func (s *MemoryStore) List(ctx context.Context, prefix string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for k := range s.values {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
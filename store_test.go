@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	if _, err := s.Get(ctx, "a"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "a", []byte("x"), []byte("1")); ok {
+		t.Error("expected swap of a missing key against a value to fail")
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "a", nil, []byte("1")); !ok {
+		t.Error("expected swap of a missing key against nil to succeed")
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "a", nil, []byte("2")); ok {
+		t.Error("expected swap of a present key against nil to fail")
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "a", []byte("1"), []byte("2")); !ok {
+		t.Error("expected swap against the current value to succeed")
+	}
+	s.Put(ctx, "b/1", []byte{})
+	s.Put(ctx, "b/0", []byte("x"))
+	if v, err := s.Get(ctx, "a"); err != nil || string(v) != "2" {
+		t.Errorf("expected 2, got %q, %v", v, err)
+	}
+	if ok, _ := s.CompareAndSwap(ctx, "b/1", nil, []byte("y")); ok {
+		t.Error("expected an empty value to count as present")
+	}
+	if keys, _ := s.List(ctx, "b/"); !reflect.DeepEqual(keys, []string{"b/0", "b/1"}) {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+	s.Delete(ctx, "a")
+	if _, err := s.Get(ctx, "a"); err != ErrNotFound {
+		t.Errorf("expected the key deleted, got %v", err)
+	}
+}
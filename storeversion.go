@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// StoreVersion is the version of the encoding of the values that a Cron keeps
+// in a Store: checkpoints, write-ahead log records, deduplication claims and
+// fencing tokens. Values are prefixed with a header giving their version, so
+// that values written by older versions are upgraded when read, rather than
+// taken for corrupt. Values without a header are version 1.
+//
+// Older releases cannot read values of newer versions, so processes sharing
+// a Store should be upgraded together.
+const StoreVersion = 2
+
+// storeHeader begins the header of a versioned value, which is followed by
+// the version and a newline.
+const storeHeader = "cron/"
+
+// Migration upgrades a value that a Cron kept in a Store from version to
+// version+1. Kind is what the value is: "checkpoint", "wal", "dedup" or
+// "fencing".
+type Migration func(kind string, version int, data []byte) ([]byte, error)
+
+// migrations are the upgrades of values between versions, indexed by the
+// version they upgrade from.
+var migrations = []Migration{
+	// Version 1 values had no header, and are otherwise the same.
+	1: nil,
+}
+
+// WithStoreMigration has the Cron upgrade the values it reads from Stores
+// with m too, after each step of its own upgrades, so that callers may
+// rewrite their parts of the values, such as the payloads of PayloadJobs in
+// write-ahead log records, as their encodings change.
+func WithStoreMigration(m Migration) Option {
+	return func(c *Cron) {
+		c.migration = m
+	}
+}
+
+// encodeValue returns data as a value of the current version.
+func encodeValue(data []byte) []byte {
+	header := storeHeader + strconv.Itoa(StoreVersion) + "\n"
+	return append([]byte(header), data...)
+}
+
+// decodeValue returns the data of a value of the kind, upgrading it to the
+// current version with the migrations and then m, if it is not nil.
+func decodeValue(kind string, value []byte, m Migration) ([]byte, error) {
+	version, data := 1, value
+	if bytes.HasPrefix(value, []byte(storeHeader)) {
+		i := bytes.IndexByte(value, '\n')
+		if i < 0 {
+			return nil, fmt.Errorf("cron: %s value has a truncated header", kind)
+		}
+		v, err := strconv.Atoi(string(value[len(storeHeader):i]))
+		if err != nil || v < 1 {
+			return nil, fmt.Errorf("cron: %s value has a bad header %q", kind, value[:i])
+		}
+		version, data = v, value[i+1:]
+	}
+	if version > StoreVersion {
+		return nil, fmt.Errorf("cron: %s value is version %d, newer than %d", kind, version, StoreVersion)
+	}
+	for ; version < StoreVersion; version++ {
+		var err error
+		if up := migrations[version]; up != nil {
+			if data, err = up(kind, version, data); err != nil {
+				return nil, err
+			}
+		}
+		if m != nil {
+			if data, err = m(kind, version, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
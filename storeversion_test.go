@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeValue(t *testing.T) {
+	data, err := decodeValue("fencing", encodeValue([]byte("7")), nil)
+	if err != nil || string(data) != "7" {
+		t.Errorf("expected the value back, got %q, %v", data, err)
+	}
+
+	// Values from before the header are version 1.
+	var calls []string
+	m := func(kind string, version int, data []byte) ([]byte, error) {
+		calls = append(calls, kind)
+		if version != 1 {
+			t.Errorf("expected an upgrade from version 1, got %d", version)
+		}
+		return append(data, '0'), nil
+	}
+	if data, err := decodeValue("fencing", []byte("7"), m); err != nil || string(data) != "70" || len(calls) != 1 {
+		t.Errorf("expected the migration to upgrade the value, got %q, %v after %v", data, err, calls)
+	}
+
+	for _, value := range []string{"cron/3\n7", "cron/x\n7", "cron/2"} {
+		if _, err := decodeValue("fencing", []byte(value), nil); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+// Checkpoints written before versioning are still restored.
+func TestCheckpointVersion1(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	s.Put(ctx, checkpointPrefix+"sync", []byte("2024-03-01T09:00:00Z"))
+	c := New(WithCheckpoints(s), WithLocation(time.UTC))
+	id, _ := c.AddFunc("@hourly", func() {}, WithName("sync"))
+	c.Start()
+	defer c.Stop()
+	if prev := c.Entry(id).Prev; !prev.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the old checkpoint restored, got %v", prev)
+	}
+
+	c.runEntry(c.Entry(id), time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	value, _ := s.Get(ctx, checkpointPrefix+"sync")
+	if !strings.HasPrefix(string(value), "cron/2\n") {
+		t.Errorf("expected a versioned checkpoint, got %q", value)
+	}
+}
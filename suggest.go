@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"sort"
+	"strings"
+)
+
+// descriptorNames are the descriptors that parseDescriptor recognizes.
+var descriptorNames = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly", "@every", "@at"}
+
+// suggest returns the candidate closest to word by edit distance, ignoring
+// case, if it is close enough to be a likely typo: one edit for short words,
+// or up to a third of the candidate's length for longer ones.
+func suggest(word string, candidates []string) (string, bool) {
+	word = strings.ToLower(word)
+	best, bestDistance := "", len(word)+1
+	for _, c := range candidates {
+		if d := editDistance(word, c); d < bestDistance || (d == bestDistance && c < best) {
+			best, bestDistance = c, d
+		}
+	}
+	if bestDistance == 0 || (bestDistance > 1 && bestDistance > len(best)/3) {
+		return "", false
+	}
+	return best, true
+}
+
+// suggestName returns the name in names closest to expr, as for suggest, in
+// upper case if expr is.
+func suggestName(expr string, names map[string]uint) (string, bool) {
+	candidates := make([]string, 0, len(names))
+	for name := range names {
+		candidates = append(candidates, name)
+	}
+	sort.Strings(candidates)
+	name, ok := suggest(expr, candidates)
+	if ok && expr == strings.ToUpper(expr) {
+		name = strings.ToUpper(name)
+	}
+	return name, ok
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters that turn a into b.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,51 @@
+package cron
+
+import "testing"
+
+func TestSuggestions(t *testing.T) {
+	tests := []struct {
+		spec, err, suggestion string
+	}{
+		{"@dialy", "unrecognized descriptor: @dialy, did you mean @daily?", "@daily"},
+		{"@hourl", "unrecognized descriptor: @hourl, did you mean @hourly?", "@hourly"},
+		{"@evrey 5m", "unrecognized descriptor: @evrey 5m, did you mean @every?", "@every"},
+		{"@fortnightly", "unrecognized descriptor: @fortnightly", ""},
+		{"0 0 * * mno", "unrecognized name: mno, did you mean mon?", "mon"},
+		{"0 0 * JNA *", "unrecognized name: JNA, did you mean JAN?", "JAN"},
+		{"0 0 * * xyz", `failed to parse int from xyz: strconv.Atoi: parsing "xyz": invalid syntax`, ""},
+	}
+	for _, test := range tests {
+		_, err := ParseStandard(test.spec)
+		pe, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%q: expected a ParseError, got %v", test.spec, err)
+			continue
+		}
+		if pe.Error() != test.err || pe.Suggestion != test.suggestion {
+			t.Errorf("%q: expected %q suggesting %q, got %q suggesting %q", test.spec, test.err, test.suggestion, pe.Error(), pe.Suggestion)
+		}
+	}
+
+	_, err := ParseStandard("@dialy")
+	if got, want := Localize(err, "zh"), "无法识别的简写表达式: @dialy，您是指 @daily 吗？"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"mon", "mon", 0},
+		{"mno", "mon", 1},
+		{"@dialy", "@daily", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	} {
+		if got := editDistance(test.a, test.b); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", test.a, test.b, got, test.want)
+		}
+	}
+}
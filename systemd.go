@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// WithSystemd integrates the Cron with systemd's service notifications, for
+// running it as a Type=notify service. Start reports READY=1 and Stop reports
+// STOPPING=1. If the unit sets WatchdogSec, the Cron also sends WATCHDOG=1
+// twice per watchdog interval for as long as its scheduling loop is
+// responsive, as checked by Health, so that systemd restarts the process if
+// the loop wedges.
+//
+// It does nothing when the process was not started by systemd with
+// NOTIFY_SOCKET set.
+func WithSystemd() Option {
+	return func(c *Cron) {
+		socket := os.Getenv("NOTIFY_SOCKET")
+		if socket == "" {
+			return
+		}
+		n := &sdNotifier{socket: socket}
+		pid, err := strconv.Atoi(os.Getenv("WATCHDOG_PID"))
+		if usec, _ := strconv.Atoi(os.Getenv("WATCHDOG_USEC")); usec > 0 && (err != nil || pid == os.Getpid()) {
+			n.interval = time.Duration(usec) * time.Microsecond / 2
+		}
+		c.systemd = n
+	}
+}
+
+// sdNotifier holds the state of the systemd integration.
+type sdNotifier struct {
+	socket   string
+	interval time.Duration // between watchdog pings, or zero for none
+	done     chan struct{} // closed to stop the watchdog
+}
+
+// sdNotify sends the state, such as "READY=1", to systemd.
+func (c *Cron) sdNotify(state string) {
+	addr := &net.UnixAddr{Name: c.systemd.socket, Net: "unixgram"}
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err == nil {
+		_, err = conn.Write([]byte(state))
+		conn.Close()
+	}
+	if err != nil {
+		c.logger.Error(err, "systemd notify", "state", state)
+	}
+}
+
+// sdStarted reports that c is ready and starts its watchdog, if enabled.
+func (c *Cron) sdStarted() {
+	c.sdNotify("READY=1")
+	if n := c.systemd; n.interval > 0 {
+		n.done = make(chan struct{})
+		go c.sdWatchdog(n.interval, n.done)
+	}
+}
+
+// sdStopping reports that c is stopping and stops its watchdog.
+func (c *Cron) sdStopping() {
+	if n := c.systemd; n.done != nil {
+		close(n.done)
+		n.done = nil
+	}
+	c.sdNotify("STOPPING=1")
+}
+
+// sdWatchdog pings systemd while the scheduling loop is responsive, until
+// done is closed.
+func (c *Cron) sdWatchdog(interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if c.Health(interval).Responsive {
+				c.sdNotify("WATCHDOG=1")
+			} else {
+				c.logger.Info("watchdog", "responsive", false)
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWithSystemd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", path)
+	t.Setenv("WATCHDOG_USEC", "100000")
+	t.Setenv("WATCHDOG_PID", "")
+
+	expect := func(state string) {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		buf := make([]byte, 64)
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("expected %s: %v", state, err)
+		}
+		if got := string(buf[:n]); got != state {
+			t.Errorf("expected %s, got %s", state, got)
+		}
+	}
+	c := New(WithSystemd())
+	c.Start()
+	expect("READY=1")
+	expect("WATCHDOG=1")
+	expect("WATCHDOG=1")
+	c.Stop()
+	expect("STOPPING=1")
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 64)); err == nil {
+		t.Error("expected no pings once stopped")
+	}
+}
+
+func TestWithSystemdUnset(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if c := New(WithSystemd()); c.systemd != nil {
+		t.Error("expected no notifications without NOTIFY_SOCKET")
+	}
+}
@@ -0,0 +1,266 @@
+package cron
+
+import (
+	"container/heap"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithEntryShards spreads the entries across n shards, each with its own lock
+// and index of next activation times, so that goroutines adding and removing
+// entries at the same time rarely wait for each other. The default is one
+// shard per CPU (GOMAXPROCS). It must be given to New before any entries are
+// added.
+func WithEntryShards(n int) Option {
+	return func(c *Cron) {
+		if n < 1 {
+			n = 1
+		}
+		c.entries = newEntryTable(n)
+	}
+}
+
+// entryTable holds the entries of a Cron, sharded by ID. Each shard keeps its
+// entries in a heap ordered by next activation time, and publishes a copy of
+// them after each change so that reading them never waits for a lock.
+type entryTable struct {
+	nextID   int64 // accessed atomically
+	deadline int64 // UnixNano the scheduler sleeps until, accessed atomically
+	shards   []*entryShard
+	due      []*Entry // the entries being fired, owned by the scheduler
+}
+
+type entryShard struct {
+	mu        sync.Mutex
+	heap      entryHeap
+	views     map[EntryID]*Entry
+	published atomic.Value // []*Entry
+	dirty     bool
+}
+
+func newEntryTable(n int) *entryTable {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	t := &entryTable{shards: make([]*entryShard, n)}
+	for i := range t.shards {
+		s := &entryShard{
+			heap:  entryHeap{index: make(map[EntryID]int)},
+			views: make(map[EntryID]*Entry),
+		}
+		s.publish()
+		t.shards[i] = s
+	}
+	return t
+}
+
+// newID returns the ID for a new entry.
+func (t *entryTable) newID() EntryID {
+	return EntryID(atomic.AddInt64(&t.nextID, 1))
+}
+
+func (t *entryTable) shard(id EntryID) *entryShard {
+	return t.shards[int(id)%len(t.shards)]
+}
+
+// insert adds the entry to its shard.
+func (t *entryTable) insert(e *Entry) {
+	s := t.shard(e.ID)
+	s.mu.Lock()
+	heap.Push(&s.heap, e)
+	s.publish()
+	s.mu.Unlock()
+}
+
+// remove deletes the entry with the given ID, and reports whether there was
+// one.
+func (t *entryTable) remove(id EntryID) bool {
+	s := t.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.heap.index[id]
+	if !ok {
+		return false
+	}
+	heap.Remove(&s.heap, i)
+	delete(s.views, id)
+	s.publish()
+	return true
+}
+
+// each calls fn for every entry, holding the lock of its shard.
+func (t *entryTable) each(fn func(e *Entry)) {
+	for _, s := range t.shards {
+		s.mu.Lock()
+		for _, e := range s.heap.items {
+			fn(e)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// update calls fn for every entry, which may change any of its fields, and
+// reorders the entries afterwards.
+func (t *entryTable) update(fn func(e *Entry)) {
+	for _, s := range t.shards {
+		s.mu.Lock()
+		for _, e := range s.heap.items {
+			fn(e)
+			// Publish a new copy, as fn may have changed more than the
+			// times that publish compares.
+			delete(s.views, e.ID)
+		}
+		heap.Init(&s.heap)
+		s.publish()
+		s.mu.Unlock()
+	}
+}
+
+// earliest returns the soonest next activation time of any entry, or false
+// if no entry has one, and records it as the time the scheduler sleeps until.
+func (t *entryTable) earliest() (time.Time, bool) {
+	// Until the result is known, entries added concurrently must wake the
+	// scheduler, in case they were added to a shard already looked at.
+	atomic.StoreInt64(&t.deadline, math.MaxInt64)
+	var next time.Time
+	for _, s := range t.shards {
+		s.mu.Lock()
+		if len(s.heap.items) > 0 {
+			if n := s.heap.items[0].Next; !n.IsZero() && (next.IsZero() || n.Before(next)) {
+				next = n
+			}
+		}
+		s.mu.Unlock()
+	}
+	if next.IsZero() {
+		return next, false
+	}
+	atomic.StoreInt64(&t.deadline, next.UnixNano())
+	return next, true
+}
+
+// sooner reports whether next is before the time the scheduler sleeps until.
+func (t *entryTable) sooner(next time.Time) bool {
+	return !next.IsZero() && next.UnixNano() < atomic.LoadInt64(&t.deadline)
+}
+
+// lockDue locks every shard and takes out the entries due at now, ordered by
+// next activation time, and by less among entries due at the same time. The
+// caller may change their times, and must then call unlockDue to put them
+// back.
+func (t *entryTable) lockDue(now time.Time, less TieBreak) []*Entry {
+	t.due = t.due[:0]
+	for _, s := range t.shards {
+		s.mu.Lock()
+		for len(s.heap.items) > 0 {
+			e := s.heap.items[0]
+			if e.Next.After(now) || e.Next.IsZero() {
+				break
+			}
+			heap.Pop(&s.heap)
+			s.dirty = true
+			t.due = append(t.due, e)
+		}
+	}
+	sort.Slice(t.due, func(i, j int) bool {
+		a, b := t.due[i], t.due[j]
+		if !a.Next.Equal(b.Next) {
+			return a.Next.Before(b.Next)
+		}
+		return less(a, b)
+	})
+	return t.due
+}
+
+// unlockDue puts back the entries taken out by lockDue, and unlocks every
+// shard.
+func (t *entryTable) unlockDue() {
+	for _, e := range t.due {
+		heap.Push(&t.shard(e.ID).heap, e)
+	}
+	for _, s := range t.shards {
+		if s.dirty {
+			s.publish()
+			s.dirty = false
+		}
+		s.mu.Unlock()
+	}
+}
+
+// snapshot returns a copy of the published entries, ordered by next
+// activation time.
+func (t *entryTable) snapshot() []Entry {
+	var views []*Entry
+	for _, s := range t.shards {
+		views = append(views, s.published.Load().([]*Entry)...)
+	}
+	sort.Sort(byTime(views))
+	entries := make([]Entry, len(views))
+	for i, e := range views {
+		entries[i] = *e
+	}
+	return entries
+}
+
+// get returns a copy of the published entry with the given ID.
+func (t *entryTable) get(id EntryID) (Entry, bool) {
+	for _, e := range t.shard(id).published.Load().([]*Entry) {
+		if e.ID == id {
+			return *e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// publish makes a copy of the shard's entries available to readers. The
+// shard's lock must be held.
+//
+// Entries that have not changed since the last publication share its copy,
+// so publishing allocates little more than the slice of pointers.
+func (s *entryShard) publish() {
+	snapshot := make([]*Entry, len(s.heap.items))
+	for i, e := range s.heap.items {
+		v := s.views[e.ID]
+		if v == nil || !v.Next.Equal(e.Next) || !v.Prev.Equal(e.Prev) {
+			cp := *e
+			v = &cp
+			s.views[e.ID] = v
+		}
+		snapshot[i] = v
+	}
+	s.published.Store(snapshot)
+}
+
+// entryHeap orders entries by next activation time, with zero times last,
+// and tracks the position of each so that any may be removed.
+type entryHeap struct {
+	items []*Entry
+	index map[EntryID]int
+}
+
+func (h *entryHeap) Len() int           { return len(h.items) }
+func (h *entryHeap) Less(i, j int) bool { return byTime(h.items).Less(i, j) }
+func (h *entryHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].ID] = i
+	h.index[h.items[j].ID] = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*Entry)
+	h.index[e.ID] = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	n := len(h.items) - 1
+	e := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	delete(h.index, e.ID)
+	return e
+}
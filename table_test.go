@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEntryTableDue(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	table := newEntryTable(3)
+	for i, offset := range []time.Duration{5, 1, 3, 2, 4} {
+		table.insert(&Entry{ID: EntryID(i + 1), Next: base.Add(offset * time.Minute)})
+	}
+	table.insert(&Entry{ID: 6}) // never runs
+
+	if next, ok := table.earliest(); !ok || !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected the earliest entry at 00:01, got %v", next)
+	}
+	due := table.lockDue(base.Add(3*time.Minute), DefaultTieBreak)
+	var ids []EntryID
+	for _, e := range due {
+		ids = append(ids, e.ID)
+		e.Next = e.Next.Add(time.Hour)
+	}
+	table.unlockDue()
+	if len(ids) != 3 || ids[0] != 2 || ids[1] != 4 || ids[2] != 3 {
+		t.Errorf("expected entries 2, 4 and 3 due in order, got %v", ids)
+	}
+	if next, _ := table.earliest(); !next.Equal(base.Add(4 * time.Minute)) {
+		t.Errorf("expected the earliest entry at 00:04 after firing, got %v", next)
+	}
+
+	entries := table.snapshot()
+	if len(entries) != 6 || entries[0].ID != 5 || entries[5].ID != 6 {
+		t.Errorf("expected the entries ordered by next time, got %v", entries)
+	}
+	if !table.remove(5) || table.remove(5) {
+		t.Error("expected entry 5 removed once")
+	}
+	if _, ok := table.get(5); ok {
+		t.Error("expected entry 5 gone")
+	}
+}
+
+func TestConcurrentMutation(t *testing.T) {
+	var runs int32
+	cron := New(WithParser(secondParser), WithEntryShards(4))
+	cron.AddFunc("* * * * * ?", func() { atomic.AddInt32(&runs, 1) })
+	cron.Start()
+	defer cron.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id, _ := cron.AddFunc("@every 1h", func() {})
+				if j%2 == 0 {
+					cron.Remove(id)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(cron.Entries()); n != 401 {
+		t.Errorf("expected 401 entries, got %d", n)
+	}
+
+	time.Sleep(OneSecond)
+	if atomic.LoadInt32(&runs) == 0 {
+		t.Error("expected the scheduler to keep running")
+	}
+}
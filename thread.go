@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"runtime"
+	"sync"
+)
+
+// WithLockOSThread runs the entry's jobs on an OS thread of their own, for
+// jobs that depend on thread-local state, such as cgo libraries that must be
+// called from the thread that initialized them, or a signal mask. The thread
+// is started with the entry's first run and is used for all its runs, one at
+// a time: a run due while another is in progress waits for it. The thread
+// exits when the entry is removed.
+func WithLockOSThread() EntryOption {
+	return func(e *Entry) {
+		e.LockOSThread = true
+	}
+}
+
+// osThreads holds the dedicated threads of entries run with WithLockOSThread.
+type osThreads struct {
+	mu      sync.Mutex
+	threads map[EntryID]*osThread
+}
+
+// osThread is a goroutine locked to its thread, making the calls sent to it.
+type osThread struct {
+	mu     sync.Mutex // held while handing a call over
+	calls  chan func()
+	closed bool
+}
+
+// run calls fn on the entry's thread, starting it if needed, and returns its
+// error.
+func (t *osThreads) run(id EntryID, fn func() error) error {
+	t.mu.Lock()
+	if t.threads == nil {
+		t.threads = make(map[EntryID]*osThread)
+	}
+	th, ok := t.threads[id]
+	if !ok {
+		th = &osThread{calls: make(chan func())}
+		t.threads[id] = th
+		go th.loop()
+	}
+	t.mu.Unlock()
+
+	done := make(chan error, 1)
+	call := func() { done <- fn() }
+	th.mu.Lock()
+	if th.closed {
+		// The entry was removed meanwhile, so use a thread just for this run.
+		go func() {
+			runtime.LockOSThread()
+			call()
+		}()
+	} else {
+		th.calls <- call
+	}
+	th.mu.Unlock()
+	return <-done
+}
+
+// loop makes the calls on the current goroutine's thread, locked to it, until
+// the channel is closed. The thread is discarded on return rather than reused
+// for other goroutines, since the calls may have changed it.
+func (th *osThread) loop() {
+	runtime.LockOSThread()
+	for call := range th.calls {
+		call()
+	}
+}
+
+// remove stops the entry's thread once any run waiting for it has been
+// handed over, without waiting itself.
+func (t *osThreads) remove(id EntryID) {
+	t.mu.Lock()
+	th, ok := t.threads[id]
+	delete(t.threads, id)
+	t.mu.Unlock()
+	if ok {
+		go th.stop()
+	}
+}
+
+func (th *osThread) stop() {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.closed = true
+	close(th.calls)
+}
@@ -0,0 +1,51 @@
+//go:build linux
+// +build linux
+
+package cron
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLockOSThread(t *testing.T) {
+	c := New()
+	tids := make(chan int, 3)
+	id, _ := c.AddFunc("@hourly", func() {
+		time.Sleep(time.Millisecond) // let the scheduler move other goroutines
+		tids <- syscall.Gettid()
+	}, WithLockOSThread())
+	entry := c.Entry(id)
+	if !entry.LockOSThread {
+		t.Fatal("expected the entry to lock its thread")
+	}
+	for i := 0; i < 3; i++ {
+		c.runEntry(entry, time.Now())
+	}
+	first := <-tids
+	for i := 0; i < 2; i++ {
+		if tid := <-tids; tid != first {
+			t.Errorf("expected every run on thread %d, got %d", first, tid)
+		}
+	}
+	if tid := syscall.Gettid(); tid == first {
+		t.Errorf("expected a thread of the entry's own, got the test's %d", tid)
+	}
+
+	// After the entry is removed, a straggling run still runs.
+	c.Remove(id)
+	c.runEntry(entry, time.Now())
+	<-tids
+}
+
+func TestLockOSThreadError(t *testing.T) {
+	c := New()
+	boom := errors.New("boom")
+	e := Entry{ID: 1, LockOSThread: true, WrappedJob: FuncContextJob(func(context.Context) error { return boom })}
+	if err := c.runEntryID(e, time.Now(), "run"); err != boom {
+		t.Errorf("expected %v, got %v", boom, err)
+	}
+}
@@ -0,0 +1,44 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// Timeout limits how long the wrapped job may run, in two phases. Once the
+// soft deadline passes, a warning is logged and reported to listeners as
+// EventSoftTimeout, giving operators an early signal. Once the hard deadline
+// passes, the run's context is cancelled and EventTimeout is reported. Either
+// deadline may be zero to disable it.
+//
+// Jobs must observe their context for the hard deadline to stop them.
+func Timeout(logger Logger, soft, hard time.Duration) JobWrapper {
+	return func(j Job) Job {
+		return FuncContextJob(func(ctx context.Context) error {
+			ri, _ := runFromContext(ctx)
+			if hard > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				defer cancel()
+				stop := afterFunc(ri.clock(), hard, func() {
+					logger.Info("timeout", "deadline", hard)
+					if ri != nil {
+						ri.emit(EventTimeout, hard)
+					}
+					cancel()
+				})
+				defer stop()
+			}
+			if soft > 0 {
+				stop := afterFunc(ri.clock(), soft, func() {
+					logger.Info("soft timeout", "deadline", soft)
+					if ri != nil {
+						ri.emit(EventSoftTimeout, soft)
+					}
+				})
+				defer stop()
+			}
+			return RunJob(ctx, j)
+		})
+	}
+}
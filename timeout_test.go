@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []EventType
+	)
+	c := New(WithEventListener(func(ev Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if ev.Type == EventSoftTimeout || ev.Type == EventTimeout {
+			events = append(events, ev.Type)
+		}
+	}))
+	var err error
+	job := NewChain(Timeout(DiscardLogger, 10*time.Millisecond, 30*time.Millisecond)).
+		Then(FuncContextJob(func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-time.After(time.Second):
+				t.Error("expected the run to be cancelled")
+			}
+			return err
+		}))
+	c.runEntry(Entry{ID: 1, WrappedJob: job}, time.Now())
+
+	if err != context.Canceled {
+		t.Errorf("expected the context to be cancelled, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 || events[0] != EventSoftTimeout || events[1] != EventTimeout {
+		t.Errorf("expected a soft timeout then a timeout, got %v", events)
+	}
+}
+
+func TestTimeoutNotReached(t *testing.T) {
+	job := NewChain(Timeout(DiscardLogger, time.Hour, time.Hour)).
+		Then(FuncContextJob(func(ctx context.Context) error { return ctx.Err() }))
+	if err := RunJob(context.Background(), job); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package cron
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TokenKind classifies a token of a spec.
+type TokenKind int
+
+const (
+	TokenTimeZone   TokenKind = iota // A "TZ=" or "CRON_TZ=" prefix
+	TokenDescriptor                  // A descriptor, such as "@daily", "@every" or "@at"
+	TokenDuration                    // The duration of "@every", such as "1h30m" or "3mo"
+	TokenNumber                      // A number, such as "15"
+	TokenName                        // A month or day name, such as "JAN" or "mon"
+	TokenWildcard                    // "*" or "?"
+	TokenRange                       // The "-" of a range
+	TokenStep                        // The "/" of a step
+	TokenList                        // The "," between the items of a list
+	TokenInvalid                     // Text that is not part of the syntax
+	TokenHash                        // The "#" of an nth weekday, such as "MON#2"
+	TokenParen                       // The "(" or ")" of the range of an H, such as "H(0-29)"
+	TokenTime                        // The time of "@at", such as "2025-07-01T09:00:00Z"
+)
+
+var tokenKindNames = []string{
+	"time zone",
+	"descriptor",
+	"duration",
+	"number",
+	"name",
+	"wildcard",
+	"range",
+	"step",
+	"list",
+	"invalid",
+	"hash",
+	"paren",
+	"time",
+}
+
+func (k TokenKind) String() string {
+	if int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+	return "unknown"
+}
+
+// Token is a lexical element of a spec, for editors and web UIs that
+// highlight specs and validate them as they are typed.
+type Token struct {
+	Kind TokenKind
+
+	// Text is the token as written, and Pos its byte offset in the spec, so
+	// that it spans spec[Pos:Pos+len(Text)].
+	Text string
+	Pos  int
+
+	// Field is the field the token is in, such as Minute or Dow, or zero for
+	// a time zone, a descriptor, or text beyond the last field.
+	Field ParseOption
+
+	// Err is why the token is invalid, or nil. An invalid range, such as
+	// "5-70" in the minutes, sets it on each of its tokens.
+	Err error
+}
+
+// Tokenize splits a standard spec into tokens, as ParseStandard reads it.
+func Tokenize(standardSpec string) []Token {
+	return standardParser.Tokenize(standardSpec)
+}
+
+// Tokenize splits the spec into tokens, as Parse reads it, reporting the
+// errors Parse would find on the tokens they concern. Unlike Parse, it goes
+// on after an error, so that every mistake may be shown at once. Whitespace
+// is not returned.
+//
+//	for _, tok := range p.Tokenize(spec) {
+//		highlight(tok.Pos, tok.Pos+len(tok.Text), tok.Kind, tok.Err)
+//	}
+func (p Parser) Tokenize(spec string) []Token {
+	words := words(spec)
+	var tokens []Token
+	loc := time.Local
+	if len(words) > 0 && (strings.HasPrefix(words[0].Text, "TZ=") || strings.HasPrefix(words[0].Text, "CRON_TZ=")) {
+		tok := words[0]
+		tok.Kind = TokenTimeZone
+		name := tok.Text[strings.Index(tok.Text, "=")+1:]
+		var err error
+		if loc, err = time.LoadLocation(name); err != nil {
+			tok.Err = parseError("provided bad location %s: %v", name, err)
+		}
+		tokens = append(tokens, tok)
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return tokens
+	}
+
+	if strings.HasPrefix(words[0].Text, "@") {
+		return append(tokens, p.tokenizeDescriptor(spec, words, loc)...)
+	}
+
+	fields := make([]string, len(words))
+	for i, w := range words {
+		fields[i] = w.Text
+	}
+	places, err := fieldPlaces(p.options, fields)
+	for i, w := range words {
+		var field ParseOption
+		if i < len(places) {
+			field = places[i]
+		}
+		fieldTokens := tokenizeField(w, field)
+		if err != nil && (i >= len(places) || len(words) < len(places) && i == len(words)-1) {
+			// Flag the fields beyond the last, or the last of too few.
+			for j := range fieldTokens {
+				fieldTokens[j].Err = err
+			}
+		}
+		tokens = append(tokens, fieldTokens...)
+	}
+	return tokens
+}
+
+// tokenizeDescriptor returns the tokens of a spec that is a descriptor.
+func (p Parser) tokenizeDescriptor(spec string, words []Token, loc *time.Location) []Token {
+	tok := words[0]
+	tok.Kind = TokenDescriptor
+	tokens := []Token{tok}
+	rest := words[1:]
+	switch {
+	case p.options&Descriptor == 0:
+		tokens[0].Err = parseError("parser does not accept descriptors: %v", spec)
+	case tok.Text == "@every" && len(rest) > 0:
+		d := rest[0]
+		d.Kind = TokenDuration
+		if _, err := parseEvery(d.Text); err != nil {
+			d.Err = err
+		}
+		tokens = append(tokens, d)
+		rest = rest[1:]
+	case tok.Text == "@at" && len(rest) > 0:
+		at := rest[0]
+		at.Kind = TokenTime
+		if _, err := parseAt(at.Text); err != nil {
+			at.Err = err
+		}
+		tokens = append(tokens, at)
+		rest = rest[1:]
+	default:
+		if _, err := parseDescriptor(tok.Text, loc); err != nil {
+			tokens[0].Err = err
+		}
+	}
+	for _, w := range rest {
+		w.Kind = TokenInvalid
+		w.Err = parseError("unrecognized descriptor: %s", tok.Text+" "+w.Text)
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// words returns the whitespace-separated words of the spec as tokens, with
+// their positions.
+func words(spec string) []Token {
+	var words []Token
+	start := -1
+	for i, r := range spec {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				words = append(words, Token{Text: spec[start:i], Pos: start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, Token{Text: spec[start:], Pos: start})
+	}
+	return words
+}
+
+// fieldPlaces returns the fields that the words of a spec give, in order, as
+// normalizeFields reads them. If there are too few or too many words for the
+// options, it returns the most fields there may be, and the error.
+func fieldPlaces(options ParseOption, fields []string) ([]ParseOption, error) {
+	count := len(fields)
+	if options&SecondOptional > 0 {
+		options |= Second
+	}
+	if options&DowOptional > 0 {
+		options |= Dow
+	}
+	if options&YearOptional > 0 {
+		options |= Year
+	}
+	var places []ParseOption
+	for _, place := range []ParseOption{Second, Minute, Hour, Dom, Month, Dow, Year} {
+		if options&place > 0 {
+			places = append(places, place)
+		}
+	}
+	max := len(places)
+	min := max
+	if options&(SecondOptional|DowOptional|YearOptional) > 0 {
+		min--
+	}
+	switch {
+	case count < min || count > max:
+		if min == max {
+			return places, parseError("expected exactly %d fields, found %d: %s", min, count, fields)
+		}
+		return places, parseError("expected %d to %d fields, found %d: %s", min, max, count, fields)
+	case count == min && min < max && options&SecondOptional > 0:
+		return places[1:], nil
+	case count == min && min < max && options&DowOptional > 0 && options&Year > 0:
+		return append(places[:min-1:min-1], Year), nil
+	case count == min && min < max:
+		return places[:min], nil
+	}
+	return places, nil
+}
+
+// tokenizeField splits the word of a field into tokens, checking each item of
+// its list as getRange, or getYears for the year, does, reading an H as Parse
+// does.
+func tokenizeField(word Token, field ParseOption) []Token {
+	var tokens []Token
+	text := word.Text
+	for i := 0; i < len(text); {
+		c := text[i]
+		kind, j := TokenInvalid, i+1
+		switch {
+		case c >= '0' && c <= '9':
+			kind = TokenNumber
+			for j < len(text) && text[j] >= '0' && text[j] <= '9' {
+				j++
+			}
+		case isLetter(c):
+			kind = TokenName
+			for j < len(text) && isLetter(text[j]) {
+				j++
+			}
+		case c == '*' || c == '?':
+			kind = TokenWildcard
+		case c == '-':
+			kind = TokenRange
+		case c == '/':
+			kind = TokenStep
+		case c == ',':
+			kind = TokenList
+		case c == '#':
+			kind = TokenHash
+		case c == '(' || c == ')':
+			kind = TokenParen
+		default:
+			for j < len(text) && !strings.ContainsRune("*?-/,#()", rune(text[j])) && !isLetter(text[j]) && (text[j] < '0' || text[j] > '9') {
+				j++
+			}
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text[i:j], Pos: word.Pos + i, Field: field})
+		i = j
+	}
+
+	r, ok := fieldBounds[field]
+	check := func(expr string) error {
+		expr, err := hashRange(expr, r, "", 0)
+		if err == nil {
+			_, err = getRange(expr, r)
+		}
+		return err
+	}
+	switch {
+	case field == Year:
+		check = func(expr string) error {
+			_, err := getYears(expr)
+			return err
+		}
+	case !ok:
+		return tokens
+	}
+	// Check each item of the list, flagging all of its tokens.
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && tokens[i].Kind != TokenList {
+			continue
+		}
+		if i > start {
+			item := tokens[start:i]
+			from, to := item[0].Pos-word.Pos, item[len(item)-1].Pos-word.Pos+len(item[len(item)-1].Text)
+			if err := check(text[from:to]); err != nil {
+				for j := range item {
+					if item[j].Err == nil {
+						item[j].Err = err
+					}
+				}
+			}
+		}
+		start = i + 1
+	}
+	return tokens
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// fieldBounds are the bounds of each field.
+var fieldBounds = map[ParseOption]bounds{
+	Second: seconds,
+	Minute: minutes,
+	Hour:   hours,
+	Dom:    dom,
+	Month:  months,
+	Dow:    dow,
+}
@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// describe renders tokens as "kind:text" with a "!" for errors.
+func describe(tokens []Token) string {
+	var parts []string
+	for _, tok := range tokens {
+		s := tok.Kind.String() + ":" + tok.Text
+		if tok.Err != nil {
+			s += "!"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		spec, want string
+	}{
+		{"*/15 9-17 * JAN,mar MON", "wildcard:* step:/ number:15 number:9 range:- number:17 wildcard:* name:JAN list:, name:mar name:MON"},
+		{"TZ=UTC @every 5m", "time zone:TZ=UTC descriptor:@every duration:5m"},
+		{"@every 5x", "descriptor:@every duration:5x!"},
+		{"@dialy", "descriptor:@dialy!"},
+		{"@daily now", "descriptor:@daily invalid:now!"},
+		{"@at 2025-07-01T09:00:00Z", "descriptor:@at time:2025-07-01T09:00:00Z"},
+		{"@at tomorrow", "descriptor:@at time:tomorrow!"},
+		{"TZ=Nowhere/Never 0 * * * *", "time zone:TZ=Nowhere/Never! number:0 wildcard:* wildcard:* wildcard:* wildcard:*"},
+		{"0,5-70 * * * mno", "number:0 list:, number:5! range:-! number:70! wildcard:* wildcard:* wildcard:* name:mno!"},
+		{"0 * * * * 1", "number:0 wildcard:* wildcard:* wildcard:* wildcard:* number:1!"},
+		{"0 * *", "number:0 wildcard:* wildcard:*!"},
+		{"1#2 * * * *", "number:1! hash:#! number:2! wildcard:* wildcard:* wildcard:* wildcard:*"},
+		{"H(0-29)/10 H * * *", "name:H paren:( number:0 range:- number:29 paren:) step:/ number:10 name:H wildcard:* wildcard:* wildcard:*"},
+		{"H(0-60 * * * *", "name:H! paren:(! number:0! range:-! number:60! wildcard:* wildcard:* wildcard:* wildcard:*"},
+		{"0 0 15W,L * MON#2,fri#6", "number:0 number:0 number:15 name:W list:, name:L wildcard:* name:MON hash:# number:2 list:, name:fri! hash:#! number:6!"},
+	}
+	for _, test := range tests {
+		tokens := Tokenize(test.spec)
+		if got := describe(tokens); got != test.want {
+			t.Errorf("%q: expected\n%s\ngot\n%s", test.spec, test.want, got)
+		}
+		for _, tok := range tokens {
+			if test.spec[tok.Pos:tok.Pos+len(tok.Text)] != tok.Text {
+				t.Errorf("%q: token %q at wrong position %d", test.spec, tok.Text, tok.Pos)
+			}
+		}
+	}
+}
+
+// Tokens flag exactly the errors Parse reports.
+func TestTokenizeAgreesWithParse(t *testing.T) {
+	for _, spec := range []string{"0 0 * * *", "0 0 32 * *", "@every", "0 0 * * 7/0", "CRON_TZ=UTC 0 0 1 1 *"} {
+		var tokenErr error
+		for _, tok := range Tokenize(spec) {
+			if tok.Err != nil {
+				tokenErr = tok.Err
+				break
+			}
+		}
+		_, err := ParseStandard(spec)
+		if fmt.Sprint(tokenErr) != fmt.Sprint(err) {
+			t.Errorf("%q: expected %v, got %v", spec, err, tokenErr)
+		}
+	}
+}
+
+func TestTokenizeFields(t *testing.T) {
+	p := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow)
+	var fields []ParseOption
+	for _, tok := range p.Tokenize("0 1 2 3 4") {
+		fields = append(fields, tok.Field)
+	}
+	if want := []ParseOption{Minute, Hour, Dom, Month, Dow}; fmt.Sprint(fields) != fmt.Sprint(want) {
+		t.Errorf("expected fields %v, got %v", want, fields)
+	}
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// PayloadJob is implemented by jobs that carry a payload, such as TypedJob.
+// The payload is sent with dispatched runs and recorded in the write-ahead
+// log, so that the run it was meant for gets it back.
+type PayloadJob interface {
+	Job
+
+	// MarshalPayload encodes the job's payload.
+	MarshalPayload() ([]byte, error)
+
+	// WithPayload returns a copy of the job that runs with the encoded
+	// payload instead of its own.
+	WithPayload(data []byte) (Job, error)
+}
+
+// TypedJob is a job that passes its payload to Func on every run. The
+// payload is encoded as JSON when it is persisted or dispatched.
+type TypedJob[T any] struct {
+	Payload T
+	Func    func(ctx context.Context, p T) error
+}
+
+// NewTypedJob returns a job that runs fn with payload.
+func NewTypedJob[T any](payload T, fn func(ctx context.Context, p T) error) *TypedJob[T] {
+	return &TypedJob[T]{Payload: payload, Func: fn}
+}
+
+// AddTypedJob adds a job to the Cron that runs fn with payload on the given
+// schedule. It is AddJob for a TypedJob; generic methods are not allowed in
+// Go, so it is a function.
+func AddTypedJob[T any](c *Cron, spec string, payload T, fn func(ctx context.Context, p T) error, opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, NewTypedJob(payload, fn), opts...)
+}
+
+// Run runs the job, discarding any error.
+func (j *TypedJob[T]) Run() {
+	j.RunContext(context.Background())
+}
+
+// RunContext runs Func with the payload and returns its error.
+func (j *TypedJob[T]) RunContext(ctx context.Context) error {
+	return j.Func(ctx, j.Payload)
+}
+
+// MarshalPayload encodes the payload as JSON.
+func (j *TypedJob[T]) MarshalPayload() ([]byte, error) {
+	return json.Marshal(j.Payload)
+}
+
+// WithPayload returns a copy of the job with the payload decoded from JSON.
+func (j *TypedJob[T]) WithPayload(data []byte) (Job, error) {
+	var p T
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &TypedJob[T]{Payload: p, Func: j.Func}, nil
+}
+
+// DecodePayload decodes the payload sent with a dispatched run.
+func DecodePayload[T any](d Dispatch) (T, error) {
+	var p T
+	err := json.Unmarshal(d.Payload, &p)
+	return p, err
+}
+
+// marshalPayload encodes the payload of the entry's job, if it has one.
+func marshalPayload(e *Entry) (json.RawMessage, error) {
+	pj, ok := e.Job.(PayloadJob)
+	if !ok {
+		return nil, nil
+	}
+	return pj.MarshalPayload()
+}
@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type report struct {
+	Region string `json:"region"`
+	Limit  int    `json:"limit"`
+}
+
+func TestAddTypedJob(t *testing.T) {
+	c := New()
+	var got report
+	id, err := AddTypedJob(c, "@every 1h", report{"eu", 10}, func(ctx context.Context, r report) error {
+		got = r
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.runEntry(c.Entry(id), time.Now())
+	if got != (report{"eu", 10}) {
+		t.Errorf("expected the payload, got %+v", got)
+	}
+}
+
+func TestTypedJobDispatch(t *testing.T) {
+	dispatched := make(chan Dispatch, 1)
+	c := New(WithDispatcher(dispatchFunc(func(d Dispatch) error {
+		dispatched <- d
+		return nil
+	})))
+	id, _ := AddTypedJob(c, "@every 1h", report{"us", 3}, func(ctx context.Context, r report) error {
+		return nil
+	}, WithName("report"))
+	e := c.Entry(id)
+	c.startJob(&e, time.Now())
+
+	d := <-dispatched
+	r, err := DecodePayload[report](d)
+	if err != nil || r != (report{"us", 3}) {
+		t.Errorf("expected the payload dispatched, got %+v, %v", r, err)
+	}
+
+	// A worker decodes it into its own copy of the job.
+	var got report
+	job, err := NewTypedJob(report{}, func(ctx context.Context, r report) error {
+		got = r
+		return nil
+	}).WithPayload(d.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job.Run()
+	if got != r {
+		t.Errorf("expected the worker to run with the payload, got %+v", got)
+	}
+}
+
+func TestTypedJobWALReplay(t *testing.T) {
+	store := NewMemoryStore()
+	store.Put(context.Background(), walPrefix+"run1",
+		[]byte(`{"name":"report","scheduled":"2020-01-01T00:00:00Z","payload":{"region":"ap","limit":7}}`))
+
+	ran := make(chan report, 1)
+	c := New(WithWAL(store))
+	AddTypedJob(c, "@every 1h", report{"eu", 10}, func(ctx context.Context, r report) error {
+		ran <- r
+		return nil
+	}, WithName("report"))
+	c.Start()
+	defer c.Stop()
+
+	select {
+	case r := <-ran:
+		if r != (report{"ap", 7}) {
+			t.Errorf("expected the logged payload replayed, got %+v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the logged run replayed")
+	}
+}
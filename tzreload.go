@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// loadLocation is time.LoadLocation, replaced in tests.
+var loadLocation = time.LoadLocation
+
+// ReloadTimeZones reloads the time zones the Cron uses from the time zone
+// database, so that a long-running process picks up changes to it, such as
+// new daylight saving rules, without restarting. Run it after the system's
+// tzdata package is updated, or on a schedule of its own.
+//
+// The schedules of entries with their own time zone, set with CRON_TZ, are
+// replaced with copies in the reloaded zone, through OffsetSchedule,
+// MissingDaySchedule and NthSchedule; an NthSchedule keeps its anchor. The
+// Cron's own location is reloaded too. If the Cron is running, the next
+// activation times of the affected entries are recomputed at once, as
+// SetLocation does. Schedules of other types, UTC and time.Local are left as
+// they are: Go reads the local time zone only once, at startup.
+//
+// A zone that fails to load is kept as it was, and the first such error is
+// returned after the rest are reloaded.
+func (c *Cron) ReloadTimeZones() error {
+	var firstErr error
+	loaded := make(map[*time.Location]*time.Location)
+	reload := func(loc *time.Location) *time.Location {
+		if loc == time.Local || loc == time.UTC {
+			return nil
+		}
+		if l, ok := loaded[loc]; ok {
+			return l
+		}
+		l, err := loadLocation(loc.String())
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("cron: reloading time zone %s: %v", loc, err)
+			}
+			l = nil
+		}
+		loaded[loc] = l
+		return l
+	}
+
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	cronLoc := false
+	if l := reload(c.Location()); l != nil {
+		c.location.Store(l)
+		cronLoc = true
+	}
+	now := c.now()
+	reloaded := 0
+	c.entries.update(func(entry *Entry) {
+		s, ok := relocate(entry.Schedule, reload)
+		if ok {
+			entry.Schedule = s
+			reloaded++
+		}
+		if !c.running || !ok && !(cronLoc && usesLocation(entry.Schedule)) {
+			return
+		}
+		entry.Next = rescheduledNext(entry, now)
+		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+		c.emit(Event{Type: EventScheduled, Entry: entry.ID, Name: entry.Name, Scheduled: entry.Next, Time: now})
+	})
+	c.logger.Info("reloaded time zones", "entries", reloaded)
+	if c.running {
+		// Wake the scheduler to sleep until the new earliest time.
+		select {
+		case c.poke <- struct{}{}:
+		default:
+		}
+	}
+	return firstErr
+}
+
+// relocate returns a copy of the schedule in the locations that reload
+// returns for its own, and whether there were any. reload returns nil for a
+// location that is to be kept.
+func relocate(s Schedule, reload func(*time.Location) *time.Location) (Schedule, bool) {
+	switch s := s.(type) {
+	case *SpecSchedule:
+		loc := reload(s.Location)
+		if loc == nil {
+			return s, false
+		}
+		relocated := *s
+		relocated.Location = loc
+		return &relocated, true
+	case OffsetSchedule:
+		inner, ok := relocate(s.Schedule, reload)
+		s.Schedule = inner
+		return s, ok
+	case MissingDaySchedule:
+		inner, ok := relocate(s.Schedule, reload)
+		s.Schedule = inner.(*SpecSchedule)
+		return s, ok
+	case YearSchedule:
+		inner, ok := relocate(s.Schedule, reload)
+		s.Schedule = inner
+		return s, ok
+	case *NthSchedule:
+		inner, ok := relocate(s.Schedule, reload)
+		if !ok {
+			return s, false
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return &NthSchedule{Schedule: inner, N: s.N, Anchor: s.Anchor}, true
+	}
+	return s, false
+}
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReloadTimeZones(t *testing.T) {
+	old := time.FixedZone("Test/Zone", 0)
+	updated := time.FixedZone("Test/Zone", 2*60*60)
+	defer func(f func(string) (*time.Location, error)) { loadLocation = f }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) {
+		if name == "Test/Zone" {
+			return updated, nil
+		}
+		return nil, errors.New("unknown time zone " + name)
+	}
+
+	now := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	cron := New(WithClock(fixedClock(now)), WithLocation(time.UTC))
+	spec := func() *SpecSchedule {
+		s, _ := standardParser.Parse("0 9 * * *")
+		s.(*SpecSchedule).Location = old
+		return s.(*SpecSchedule)
+	}
+	plain := cron.Schedule(spec(), FuncJob(func() {}))
+	nth := cron.Schedule(EveryNth(OffsetSchedule{spec(), time.Minute}, 1), FuncJob(func() {}))
+	utc, _ := cron.AddFunc("0 9 * * *", func() {})
+	cron.Start()
+	defer cron.Stop()
+
+	if err := cron.ReloadTimeZones(); err != nil {
+		t.Fatal(err)
+	}
+	// It is 10:30 in the updated zone, so the next 09:00 there is tomorrow.
+	if next := cron.Entry(plain).Next; !next.Equal(time.Date(2024, 3, 2, 9, 0, 0, 0, updated)) {
+		t.Errorf("expected the entry rescheduled in the updated zone, got %v", next)
+	}
+	if loc := cron.Entry(plain).Schedule.(*SpecSchedule).Location; loc != updated {
+		t.Errorf("expected the schedule in the updated zone, got %v", loc)
+	}
+	n := cron.Entry(nth).Schedule.(*NthSchedule)
+	if !n.Anchor.Equal(now) || n.Schedule.(OffsetSchedule).Schedule.(*SpecSchedule).Location != updated {
+		t.Errorf("expected the wrapped schedule relocated and its anchor kept, got %+v", n)
+	}
+	if next := cron.Entry(utc).Next; !next.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the UTC entry left alone, got %v", next)
+	}
+}
+
+// A reloaded schedule is published even if its next activation is the same.
+func TestReloadTimeZonesSameNext(t *testing.T) {
+	old := time.FixedZone("Test/Zone", 0)
+	updated := time.FixedZone("Test/Zone", 0)
+	defer func(f func(string) (*time.Location, error)) { loadLocation = f }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) { return updated, nil }
+
+	for _, running := range []bool{false, true} {
+		cron := New(WithLocation(time.UTC))
+		s, _ := standardParser.Parse("0 9 * * *")
+		s.(*SpecSchedule).Location = old
+		id := cron.Schedule(s, FuncJob(func() {}))
+		if running {
+			cron.Start()
+		}
+		next := cron.Entry(id).Next
+
+		if err := cron.ReloadTimeZones(); err != nil {
+			t.Fatal(err)
+		}
+		e := cron.Entry(id)
+		if loc := e.Schedule.(*SpecSchedule).Location; loc != updated {
+			t.Errorf("running %v: expected the schedule in the updated zone, got %p", running, loc)
+		}
+		if !e.Next.Equal(next) {
+			t.Errorf("running %v: expected the next activation %v kept, got %v", running, next, e.Next)
+		}
+		cron.Stop()
+	}
+}
+
+func TestReloadTimeZonesError(t *testing.T) {
+	defer func(f func(string) (*time.Location, error)) { loadLocation = f }(loadLocation)
+	loadLocation = func(name string) (*time.Location, error) {
+		return nil, errors.New("no such zone")
+	}
+	gone := time.FixedZone("Gone/Zone", 0)
+	cron := New(WithLocation(gone))
+	s, _ := standardParser.Parse("CRON_TZ=UTC 0 9 * * *")
+	s.(*SpecSchedule).Location = gone
+	id := cron.Schedule(s, FuncJob(func() {}))
+
+	if err := cron.ReloadTimeZones(); err == nil {
+		t.Error("expected an error")
+	}
+	if cron.Location() != gone || cron.Entry(id).Schedule != s {
+		t.Error("expected the zone that failed to load kept")
+	}
+}
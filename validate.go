@@ -0,0 +1,44 @@
+package cron
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ValidateAll parses each of the specs with the standard parser, as
+// ParseStandard does, and returns the error for each, at the same index, or
+// nil if it is valid. The specs are parsed in parallel, so that thousands of
+// stored schedules can be checked quickly.
+func ValidateAll(specs []string) []error {
+	return standardParser.ValidateAll(specs)
+}
+
+// ValidateAll parses each of the specs in parallel, and returns the error for
+// each, at the same index, or nil if it is valid.
+func (p Parser) ValidateAll(specs []string) []error {
+	errs := make([]error, len(specs))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(specs) {
+		workers = len(specs)
+	}
+	var (
+		next int64 = -1
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(specs) {
+					return
+				}
+				_, errs[i] = p.Parse(specs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
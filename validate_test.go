@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateAll(t *testing.T) {
+	var specs []string
+	for i := 0; i < 1000; i++ {
+		if i%7 == 0 {
+			specs = append(specs, fmt.Sprintf("%d * * * *", 60+i))
+		} else {
+			specs = append(specs, fmt.Sprintf("%d * * * *", i%60))
+		}
+	}
+	errs := ValidateAll(specs)
+	if len(errs) != len(specs) {
+		t.Fatalf("expected %d results, got %d", len(specs), len(errs))
+	}
+	for i, err := range errs {
+		if (i%7 == 0) != (err != nil) {
+			t.Errorf("%q: unexpected result %v", specs[i], err)
+		}
+	}
+	if errs := ValidateAll(nil); len(errs) != 0 {
+		t.Errorf("expected no results, got %v", errs)
+	}
+	if errs := secondParser.ValidateAll([]string{"0 0 * * * *", "0 * * *"}); errs[0] != nil || errs[1] == nil {
+		t.Errorf("expected the parser's fields used, got %v", errs)
+	}
+}
@@ -0,0 +1,250 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// walPrefix is the Store key prefix of write-ahead log records.
+const walPrefix = "wal/"
+
+// walLease is how long a write-ahead log record may go without being renewed
+// before its run is taken to be abandoned. Runs renew their records three
+// times per lease.
+const walLease = time.Minute
+
+// walRecord is the intent to run an entry, recorded before the run starts and
+// deleted once it completes. Its owner renews it while the run goes on.
+type walRecord struct {
+	Name      string          `json:"name"`
+	Scheduled time.Time       `json:"scheduled"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Owner     string          `json:"owner,omitempty"`
+	Renewed   time.Time       `json:"renewed"`
+}
+
+// writeAheadLog is the state of a Cron's write-ahead log.
+type writeAheadLog struct {
+	store Store
+	owner string // identifies this Cron in the records of its runs
+
+	mu   sync.Mutex
+	seen map[string]walSeen // by key, the records of other owners
+}
+
+// walSeen is when a record of another owner was first seen with its current
+// renewal time.
+type walSeen struct {
+	renewed time.Time
+	at      time.Time
+}
+
+// WithWAL records the intent to run each named entry in the given Store
+// before the run starts, and deletes the record once the job returns. While
+// the job runs its record is renewed. Runs whose record was never completed,
+// because the process crashed or was killed mid-run, are run again with their
+// original scheduled time and run ID once their record has gone a minute
+// without renewal. This gives at-least-once execution: a run is never lost,
+// but may be repeated.
+//
+// The log is checked on startup and every minute while the Cron runs. Runs
+// are only repeated while this Cron may fire jobs, so Crons sharing the Store
+// leave each other's runs alone unless they are abandoned, and only the
+// leader of an Elector or the holder of a singleton lock repeats them.
+// Repeated runs go through the Dispatcher if there is one, and wait for the
+// end of any blackout and for their entry's shard to be owned here. Whether
+// a record has gone without renewal is judged by this process's clock from
+// when it saw the record change, so clocks need not agree between hosts.
+//
+// Records are matched to entries by name, so only named entries are logged.
+// A run that is canceled before it starts, by CancelRun, is not repeated.
+// The payload of a PayloadJob is recorded too, and given back to the job when
+// its run is repeated.
+func WithWAL(s Store) Option {
+	return func(c *Cron) {
+		c.wal = &writeAheadLog{store: s, owner: newRunID(), seen: make(map[string]walSeen)}
+	}
+}
+
+// logIntent records the run in the write-ahead log and starts renewing the
+// record, reporting whether it did.
+func (c *Cron) logIntent(ri *runInfo) bool {
+	if c.wal == nil || ri.entry.Name == "" {
+		return false
+	}
+	payload, err := marshalPayload(&ri.entry)
+	if err != nil {
+		c.logger.Error(err, "wal", "entry", ri.entry.ID, "run", ri.id)
+		return false
+	}
+	rec := walRecord{Name: ri.entry.Name, Scheduled: ri.scheduled, Payload: payload, Owner: c.wal.owner, Renewed: c.now()}
+	if !c.putIntent(ri, rec) {
+		return false
+	}
+	ri.walStop = c.renewIntent(ri, rec)
+	return true
+}
+
+// putIntent writes the run's record to the write-ahead log, reporting whether
+// it did.
+func (c *Cron) putIntent(ri *runInfo, rec walRecord) bool {
+	data, err := json.Marshal(rec)
+	if err == nil {
+		err = c.wal.store.Put(context.Background(), walPrefix+ri.id, encodeValue(data))
+	}
+	if err != nil {
+		c.logger.Error(err, "wal", "entry", ri.entry.ID, "run", ri.id)
+		return false
+	}
+	return true
+}
+
+// renewIntent renews the run's record three times per lease until the
+// returned function is called. Once it returns, the record is not written
+// again.
+func (c *Cron) renewIntent(ri *runInfo, rec walRecord) (stop func()) {
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			timer := c.clock.NewTimer(walLease / 3)
+			select {
+			case <-timer.C():
+			case <-done:
+				timer.Stop()
+				return
+			}
+			rec.Renewed = c.now()
+			c.putIntent(ri, rec)
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// logDone stops renewing the run's record and removes it from the
+// write-ahead log.
+func (c *Cron) logDone(ri *runInfo) {
+	ri.walStop()
+	if err := c.wal.store.Delete(context.Background(), walPrefix+ri.id); err != nil {
+		c.logger.Error(err, "wal", "entry", ri.entry.ID, "run", ri.id)
+	}
+}
+
+// watchWAL replays abandoned runs every lease until stop is closed.
+func (c *Cron) watchWAL(stop <-chan struct{}) {
+	for {
+		timer := c.clock.NewTimer(walLease)
+		select {
+		case <-timer.C():
+		case <-stop:
+			timer.Stop()
+			return
+		}
+		c.replayWAL()
+	}
+}
+
+// replayWAL runs again the runs left in the write-ahead log by processes that
+// stopped renewing them, if this Cron may fire jobs. Records of entries that
+// no longer exist are dropped; those of entries in a blackout or owned by
+// another shard are left for a later check.
+func (c *Cron) replayWAL() {
+	if fire, reason := c.mayFire(true); !fire {
+		c.logger.Info("wal skip", "reason", reason)
+		return
+	}
+	ctx := context.Background()
+	keys, err := c.wal.store.List(ctx, walPrefix)
+	if err != nil {
+		c.logger.Error(err, "wal replay")
+		return
+	}
+	byName := make(map[string]*Entry)
+	c.entries.each(func(e *Entry) {
+		if e.Name != "" {
+			byName[e.Name] = e
+		}
+	})
+	now := c.now()
+	c.wal.mu.Lock()
+	defer c.wal.mu.Unlock()
+	seen := make(map[string]walSeen, len(keys))
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, walPrefix)
+		value, err := c.wal.store.Get(ctx, key)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			c.logger.Error(err, "wal replay", "run", id)
+			continue
+		}
+		var rec walRecord
+		data, err := decodeValue("wal", value, c.migration)
+		if err == nil {
+			err = json.Unmarshal(data, &rec)
+		}
+		if err != nil {
+			c.logger.Error(err, "wal replay", "run", id)
+			c.wal.store.Delete(ctx, key)
+			continue
+		}
+		if rec.Owner == c.wal.owner {
+			continue
+		}
+		// Records without a renewal time predate leases and are taken to be
+		// abandoned at once.
+		if !rec.Renewed.IsZero() {
+			s, ok := c.wal.seen[key]
+			if !ok || !s.renewed.Equal(rec.Renewed) {
+				s = walSeen{renewed: rec.Renewed, at: now}
+			}
+			if now.Sub(s.at) < walLease {
+				seen[key] = s
+				continue
+			}
+		}
+		e, ok := byName[rec.Name]
+		if !ok {
+			c.logger.Info("wal drop", "run", id, "name", rec.Name, "reason", "no such entry")
+			c.wal.store.Delete(ctx, key)
+			continue
+		}
+		if !c.owns(e) {
+			c.logger.Info("wal skip", "run", id, "entry", e.ID, "reason", "owned by another instance")
+			continue
+		}
+		if _, _, blackout := c.blackedOut(now); blackout {
+			c.logger.Info("wal skip", "run", id, "entry", e.ID, "reason", "blackout")
+			continue
+		}
+		c.logger.Info("wal replay", "run", id, "entry", e.ID, "name", e.Name, "scheduled", rec.Scheduled)
+		entry := *e
+		if pj, ok := e.Job.(PayloadJob); ok && len(rec.Payload) > 0 {
+			job, err := pj.WithPayload(rec.Payload)
+			if err != nil {
+				c.logger.Error(err, "wal replay", "run", id)
+				c.wal.store.Delete(ctx, key)
+				continue
+			}
+			entry.Job, entry.WrappedJob = job, c.chain.Then(job)
+		}
+		if c.dispatcher != nil {
+			c.dispatch(&entry, rec.Scheduled)
+			c.wal.store.Delete(ctx, key)
+			continue
+		}
+		c.jobWaiter.Add(1)
+		go func() {
+			defer c.jobWaiter.Done()
+			c.runEntryID(entry, rec.Scheduled, id)
+		}()
+	}
+	c.wal.seen = seen
+}
@@ -0,0 +1,142 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWALRecordsAndCompletes(t *testing.T) {
+	store := NewMemoryStore()
+	c := New(WithWAL(store))
+	var during []string
+	id, _ := c.AddFunc("@every 1h", func() {
+		during, _ = store.List(context.Background(), walPrefix)
+	}, WithName("report"))
+
+	c.runEntry(c.Entry(id), time.Now())
+	if len(during) != 1 {
+		t.Errorf("expected the run logged while running, got %v", during)
+	}
+	if keys, _ := store.List(context.Background(), walPrefix); len(keys) != 0 {
+		t.Errorf("expected the log empty after the run, got %v", keys)
+	}
+}
+
+func TestWALReplay(t *testing.T) {
+	store := NewMemoryStore()
+	scheduled := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.Put(context.Background(), walPrefix+"run1", []byte(`{"name":"report","scheduled":"2020-01-01T00:00:00Z"}`))
+	store.Put(context.Background(), walPrefix+"run2", []byte(`{"name":"gone","scheduled":"2020-01-01T00:00:00Z"}`))
+
+	type replay struct {
+		id        string
+		scheduled time.Time
+	}
+	ran := make(chan replay, 1)
+	c := New(WithWAL(store))
+	c.AddContextFunc("@every 1h", func(ctx context.Context) error {
+		id, _ := RunIDFromContext(ctx)
+		s, _ := ScheduledTimeFromContext(ctx)
+		ran <- replay{id, s}
+		return nil
+	}, WithName("report"))
+	c.Start()
+
+	select {
+	case r := <-ran:
+		if r.id != "run1" || !r.scheduled.Equal(scheduled) {
+			t.Errorf("expected the logged run replayed, got %+v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the logged run replayed")
+	}
+	<-c.Stop().Done()
+	if keys, _ := store.List(context.Background(), walPrefix); len(keys) != 0 {
+		t.Errorf("expected the log empty after replay, got %v", keys)
+	}
+}
+
+// Runs logged by another Cron are repeated only once their record has gone a
+// lease without renewal, as judged by when this Cron saw it change.
+func TestWALReplayAbandoned(t *testing.T) {
+	store := NewMemoryStore()
+	clock := &movingClock{now: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	put := func(renewed time.Time) {
+		store.Put(context.Background(), walPrefix+"run1", []byte(`{"name":"report","scheduled":"2024-03-01T00:00:00Z",`+
+			`"owner":"other","renewed":"`+renewed.Format(time.RFC3339)+`"}`))
+	}
+	// The other Cron's clock is an hour behind.
+	put(clock.now.Add(-time.Hour))
+
+	ran := make(chan string, 1)
+	c := New(WithWAL(store), WithClock(clock))
+	c.AddContextFunc("@every 1h", func(ctx context.Context) error {
+		id, _ := RunIDFromContext(ctx)
+		ran <- id
+		return nil
+	}, WithName("report"))
+
+	c.replayWAL()
+	clock.now = clock.now.Add(walLease / 2)
+	put(clock.now.Add(-time.Hour))
+	c.replayWAL()
+	clock.now = clock.now.Add(walLease / 2)
+	c.replayWAL()
+	select {
+	case id := <-ran:
+		t.Fatalf("expected a renewed run not to be repeated, ran %s", id)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.now = clock.now.Add(walLease / 2)
+	c.replayWAL()
+	select {
+	case id := <-ran:
+		if id != "run1" {
+			t.Errorf("expected the abandoned run repeated, got %s", id)
+		}
+	case <-time.After(OneSecond):
+		t.Fatal("expected the abandoned run repeated")
+	}
+	<-c.Stop().Done()
+}
+
+// Followers leave abandoned runs for the leader, which hands them to its
+// Dispatcher.
+func TestWALReplayLeader(t *testing.T) {
+	store := NewMemoryStore()
+	store.Put(context.Background(), walPrefix+"run1", []byte(`{"name":"report","scheduled":"2020-01-01T00:00:00Z"}`))
+
+	var leader bool
+	dispatched := make(chan Dispatch, 1)
+	c := New(WithWAL(store),
+		WithElector(electorFunc(func() bool { return leader })),
+		WithDispatcher(dispatchFunc(func(d Dispatch) error {
+			dispatched <- d
+			return nil
+		})))
+	c.AddFunc("@every 1h", func() {
+		t.Error("expected the job not to run locally")
+	}, WithName("report"))
+
+	c.replayWAL()
+	if keys, _ := store.List(context.Background(), walPrefix); len(keys) != 1 {
+		t.Fatalf("expected a follower to leave the record, got %v", keys)
+	}
+
+	leader = true
+	c.replayWAL()
+	select {
+	case d := <-dispatched:
+		if d.Name != "report" || !d.Scheduled.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected dispatch: %+v", d)
+		}
+	case <-time.After(OneSecond):
+		t.Fatal("expected the leader to dispatch the run")
+	}
+	<-c.Stop().Done()
+	if keys, _ := store.List(context.Background(), walPrefix); len(keys) != 0 {
+		t.Errorf("expected the record removed once dispatched, got %v", keys)
+	}
+}
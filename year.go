@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// years are the bounds of the Year field, as in Quartz.
+var years = bounds{1970, 2099, nil}
+
+// YearSchedule is a schedule that runs only in some years, for specs whose
+// Year field is not "*". Its Schedule is the *SpecSchedule or
+// MissingDaySchedule of the other fields.
+type YearSchedule struct {
+	Schedule Schedule
+
+	// Years are the years the schedule runs in, in ascending order, in the
+	// time zone of the spec.
+	Years []int
+}
+
+// Next returns the next activation time of the schedule after t, or the zero
+// time if there is none in the years of the schedule.
+func (s YearSchedule) Next(t time.Time) time.Time {
+	loc := scheduleLocation(s.Schedule, t)
+	for {
+		next := s.Schedule.Next(t)
+		if next.IsZero() {
+			return next
+		}
+		year := next.In(loc).Year()
+		i := sort.SearchInts(s.Years, year)
+		if i == len(s.Years) {
+			return time.Time{}
+		}
+		if s.Years[i] == year {
+			return next
+		}
+		// Skip to the last second before the next year of the schedule.
+		t = time.Date(s.Years[i], time.January, 1, 0, 0, 0, 0, loc).Add(-time.Second).In(t.Location())
+	}
+}
+
+// Prev returns the previous activation time of the schedule before t, or the
+// zero time if there is none in the years of the schedule, or if its Schedule
+// is not a PrevSchedule.
+func (s YearSchedule) Prev(t time.Time) time.Time {
+	loc := scheduleLocation(s.Schedule, t)
+	for {
+		prev, _ := Prev(s.Schedule, t)
+		if prev.IsZero() {
+			return prev
+		}
+		year := prev.In(loc).Year()
+		i := sort.SearchInts(s.Years, year+1) - 1
+		if i < 0 {
+			return time.Time{}
+		}
+		if s.Years[i] == year {
+			return prev
+		}
+		// Skip to the first second after the previous year of the schedule.
+		t = time.Date(s.Years[i]+1, time.January, 1, 0, 0, 0, 0, loc).In(t.Location())
+	}
+}
+
+// scheduleLocation returns the time zone of the fields of a schedule that
+// YearSchedule wraps, when it is given t. Schedules without a time zone of
+// their own, in time.Local, use the time zone of t, as SpecSchedule does.
+func scheduleLocation(s Schedule, t time.Time) *time.Location {
+	loc := time.Local
+	switch s := s.(type) {
+	case *SpecSchedule:
+		loc = s.Location
+	case MissingDaySchedule:
+		loc = s.Schedule.Location
+	}
+	if loc == time.Local {
+		return t.Location()
+	}
+	return loc
+}
+
+// getYears returns the years of the Year field, in ascending order, or nil
+// if the field is "*" or "?". It takes a list of ranges as getField does.
+func getYears(field string) ([]int, error) {
+	if field == "*" || field == "?" {
+		return nil, nil
+	}
+	set := make([]bool, years.max-years.min+1)
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		start, end, step, _, err := parseRange(expr, years)
+		if err != nil {
+			return nil, err
+		}
+		for y := start; y <= end; y += step {
+			set[y-years.min] = true
+		}
+	}
+	var list []int
+	for i, ok := range set {
+		if ok {
+			list = append(list, int(years.min)+i)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,155 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestYear(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional)
+	tests := []struct {
+		spec string
+		from time.Time
+		want []time.Time
+	}{
+		{"0 0 1 1 * 2026", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), {},
+		}},
+		{"30 9 * * MON 2027,2029", time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2027, 1, 4, 9, 30, 0, 0, time.UTC),
+			time.Date(2027, 1, 11, 9, 30, 0, 0, time.UTC),
+		}},
+		{"0 0 31 12 * 2027,2029", time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC), {},
+		}},
+		{"0 0 29 2 * 2020-2099/4", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 1 1 * 2020", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []time.Time{{}}},
+		{"0 0 1 1 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"TZ=Asia/Tokyo 0 0 1 1 * 2027", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2026, 12, 31, 15, 0, 0, 0, time.UTC), {},
+		}},
+	}
+	for _, tt := range tests {
+		s, err := p.Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		next := tt.from
+		for _, want := range tt.want {
+			next = s.Next(next)
+			if !next.Equal(want) {
+				t.Errorf("%s: got %v, want %v", tt.spec, next, want)
+				break
+			}
+		}
+	}
+}
+
+// Specs without a time zone take the year in the time zone of the Cron, not
+// that of the process.
+func TestYearCronLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = ny
+
+	now := time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)
+	p := NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional)
+	c := New(WithClock(fixedClock(now)), WithLocation(time.UTC), WithParser(p))
+	id, err := c.AddFunc("0 0 0 1 1 * 2026", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	defer c.Stop()
+
+	want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := c.Entry(id)
+	if !e.Next.Equal(want) {
+		t.Errorf("expected the next run at %v, got %v", want, e.Next)
+	}
+	if prev := e.Schedule.(YearSchedule).Prev(now.AddDate(1, 0, 0)); !prev.Equal(want) {
+		t.Errorf("expected the previous run at %v, got %v", want, prev)
+	}
+}
+
+func TestYearFields(t *testing.T) {
+	tests := []struct {
+		options ParseOption
+		spec    string
+		years   []int
+	}{
+		{Second | Minute | Hour | Dom | Month | Dow | YearOptional, "0 0 0 1 1 ? 2030", []int{2030}},
+		{Second | Minute | Hour | Dom | Month | Dow | YearOptional, "0 0 0 1 1 ?", nil},
+		{Minute | Hour | Dom | Month | Dow | Year, "0 0 1 1 * 2030-2040/5,2099", []int{2030, 2035, 2040, 2099}},
+		{Minute | Hour | Dom | Month | DowOptional | Year, "0 0 1 1 2030", []int{2030}},
+		{Minute | Hour | Dom | Month | DowOptional | Year, "0 0 1 1 MON 2030", []int{2030}},
+		{Minute | Hour | Dom | Month | Dow | Year, "0 0 1 1 * *", nil},
+	}
+	for _, tt := range tests {
+		s, err := NewParser(tt.options).Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		y, ok := s.(YearSchedule)
+		if !ok {
+			if tt.years != nil {
+				t.Errorf("%s: got %T, want a YearSchedule", tt.spec, s)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(y.Years, tt.years) {
+			t.Errorf("%s: got years %v, want %v", tt.spec, y.Years, tt.years)
+		}
+	}
+
+	// The day of week is the optional field, so its default goes before the
+	// year.
+	s, _ := NewParser(Minute | Hour | Dom | Month | DowOptional | Year).Parse("0 0 1 1 2030")
+	if dow := s.(YearSchedule).Schedule.(*SpecSchedule).Dow; dow&starBit == 0 {
+		t.Errorf("expected a star in the day of week, got %b", dow)
+	}
+}
+
+func TestYearErrors(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | Year)
+	for _, spec := range []string{
+		"0 0 1 1 * 1969",
+		"0 0 1 1 * 2100",
+		"0 0 1 1 * 2030-2020",
+		"0 0 1 1 * 2030/0",
+		"0 0 1 1 * twenty",
+		"0 0 1 1 *",
+	} {
+		if _, err := p.Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for two optional fields")
+		}
+	}()
+	NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | YearOptional)
+}
+
+func TestYearTokens(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional)
+	tokens := p.Tokenize("0 0 1 1 * 2030,2100")
+	last := tokens[len(tokens)-1]
+	if last.Field != Year || last.Err == nil {
+		t.Errorf("expected an error on the year 2100, got %+v", last)
+	}
+	if first := tokens[len(tokens)-3]; first.Field != Year || first.Err != nil {
+		t.Errorf("expected no error on the year 2030, got %+v", first)
+	}
+}